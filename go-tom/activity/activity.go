@@ -19,6 +19,8 @@ type Control struct {
 	createMissingTags     bool
 	allowMultipleActives  bool
 	startStopTime         time.Time
+	fields                map[string]string
+	billable              *bool
 }
 
 func NewActivityControl(ctx *context.TomContext, createMissing bool, allowMultipleActives bool, startStopTime time.Time) *Control {
@@ -31,6 +33,18 @@ func NewActivityControl(ctx *context.TomContext, createMissing bool, allowMultip
 	}
 }
 
+// WithFields sets the custom fields, which are set on the started and stopped frames. Empty values remove fields of stopped frames.
+func (a *Control) WithFields(fields map[string]string) *Control {
+	a.fields = fields
+	return a
+}
+
+// WithBillable sets the billable flag of the started frames. The flag of the project applies if it's nil.
+func (a *Control) WithBillable(billable *bool) *Control {
+	a.billable = billable
+	return a
+}
+
 func (a *Control) Start(projectNameOrID string, notes string, tags []*model.Tag) (*model.Frame, error) {
 	project, err := a.ctx.Query.ProjectByID(projectNameOrID)
 	if err != nil {
@@ -49,6 +63,8 @@ func (a *Control) Start(projectNameOrID string, notes string, tags []*model.Tag)
 	frame.Notes = notes
 	frame.Start = &a.startStopTime
 	frame.AddTags(tags...)
+	frame.SetFields(a.fields)
+	frame.Billable = a.billable
 	return a.ctx.Store.AddFrame(frame)
 }
 
@@ -69,6 +85,45 @@ func (a *Control) StopAll(notes string, tags []*model.Tag) ([]*model.Frame, erro
 	return a.stopActivities(true, notes, tags)
 }
 
+// PauseNewest pauses the newest active frame until it's resumed
+func (a *Control) PauseNewest() (*model.Frame, error) {
+	frame := a.newestActive(false)
+	if frame == nil {
+		return nil, fmt.Errorf("no running activity found")
+	}
+
+	if err := frame.Pause(a.startStopTime); err != nil {
+		return nil, err
+	}
+	return a.ctx.Store.UpdateFrame(*frame)
+}
+
+// ResumeNewest ends the break of the newest paused frame
+func (a *Control) ResumeNewest() (*model.Frame, error) {
+	frame := a.newestActive(true)
+	if frame == nil {
+		return nil, fmt.Errorf("no paused activity found")
+	}
+
+	if err := frame.Resume(a.startStopTime); err != nil {
+		return nil, err
+	}
+	return a.ctx.Store.UpdateFrame(*frame)
+}
+
+func (a *Control) newestActive(paused bool) *model.Frame {
+	var result *model.Frame
+	for _, frame := range a.ctx.Query.ActiveFrames() {
+		if paused && !frame.IsPaused() {
+			continue
+		}
+		if result == nil || frame.Start.After(*result.Start) {
+			result = frame
+		}
+	}
+	return result
+}
+
 func (a *Control) stopActivities(all bool, notes string, tags []*model.Tag) ([]*model.Frame, error) {
 	actives := a.ctx.Query.ActiveFrames()
 
@@ -99,6 +154,7 @@ func (a *Control) stopActivities(all bool, notes string, tags []*model.Tag) ([]*
 		}
 
 		frame.AddTags(tags...)
+		frame.SetFields(a.fields)
 
 		if _, err := a.ctx.Store.UpdateFrame(*frame); err != nil {
 			return nil, err
@@ -60,3 +60,33 @@ func Test_ActivityNoteRequired(t *testing.T) {
 	require.NoError(t, err)
 	require.EqualValues(t, "note with content", stoppedFrame.Notes)
 }
+
+func Test_ActivityPauseResume(t *testing.T) {
+	ctx, err := test_setup.CreateTestContext(language.AmericanEnglish)
+	require.NoError(t, err)
+	defer test_setup.CleanupTestContext(ctx)
+
+	project, err := ctx.Store.AddProject(model.Project{Name: "Project1"})
+	require.NoError(t, err)
+
+	start := time.Now().Add(-3 * time.Hour)
+	frame, err := NewActivityControl(ctx, false, false, start).Start(project.ID, "", nil)
+	require.NoError(t, err)
+
+	_, err = NewActivityControl(ctx, false, false, start.Add(time.Hour)).ResumeNewest()
+	require.Error(t, err, "a running frame can't be resumed")
+
+	paused, err := NewActivityControl(ctx, false, false, start.Add(time.Hour)).PauseNewest()
+	require.NoError(t, err)
+	require.EqualValues(t, frame.ID, paused.ID)
+	require.True(t, paused.IsPaused())
+
+	resumed, err := NewActivityControl(ctx, false, false, start.Add(90*time.Minute)).ResumeNewest()
+	require.NoError(t, err)
+	require.False(t, resumed.IsPaused())
+	require.Len(t, resumed.Breaks, 1)
+
+	stopped, err := NewActivityControl(ctx, false, false, start.Add(2*time.Hour)).StopNewest("", nil)
+	require.NoError(t, err)
+	require.EqualValues(t, 90*time.Minute, stopped.Duration())
+}
@@ -0,0 +1,404 @@
+package check
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jansorg/tom/go-tom/model"
+	"github.com/jansorg/tom/go-tom/store"
+)
+
+// Types of the issues found by Check
+const (
+	UnknownProject = "unknownProject"
+	UnknownTag     = "unknownTag"
+	UnknownClient  = "unknownClient"
+	UnknownParent  = "unknownParent"
+	CyclicParent   = "cyclicParent"
+	InvalidName    = "invalidName"
+	EndBeforeStart = "endBeforeStart"
+	DuplicateID    = "duplicateID"
+	MultipleActive = "multipleActive"
+	Overlapping    = "overlapping"
+)
+
+// OrphanedProjectName is the name of the project which receives the frames of unknown projects
+const OrphanedProjectName = "orphaned frames"
+
+// Issue is a problem of the data of a store
+type Issue struct {
+	Type    string `json:"type"`
+	Entity  string `json:"entity"`
+	ID      string `json:"id"`
+	Message string `json:"message"`
+	Fixable bool   `json:"fixable"`
+	Fixed   bool   `json:"fixed"`
+}
+
+func (i Issue) String() string {
+	result := fmt.Sprintf("%s %s: %s", i.Entity, i.ID, i.Message)
+	if i.Fixed {
+		result += " (fixed)"
+	} else if i.Fixable {
+		result += " (fixable)"
+	}
+	return result
+}
+
+// Config defines which data is valid
+type Config struct {
+	// AllowMultipleActive defines if more than one frame may be active at the same time
+	AllowMultipleActive bool
+}
+
+// checker finds and fixes the issues of copies of the data of a store
+type checker struct {
+	store    model.Store
+	config   Config
+	projects []*model.Project
+	tags     []*model.Tag
+	frames   []*model.Frame
+	issues   []Issue
+}
+
+// Check returns the issues of the data in s
+func Check(s model.Store, config Config) []Issue {
+	c := newChecker(s, config)
+	c.run()
+	return c.issues
+}
+
+// Fix returns the issues of the data in s and repairs the fixable issues.
+// A backup is created before the data is modified.
+func Fix(s model.Store, config Config) ([]Issue, error) {
+	c := newChecker(s, config)
+	c.run()
+
+	fixed := false
+	for i := range c.issues {
+		if c.issues[i].Fixable {
+			c.issues[i].Fixed = true
+			fixed = true
+		}
+	}
+
+	if fixed {
+		if err := store.Replace(s, c.projects, c.tags, c.frames); err != nil {
+			return nil, err
+		}
+	}
+	return c.issues, nil
+}
+
+func sameJSON(a, b interface{}) bool {
+	dataA, errA := json.Marshal(a)
+	dataB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(dataA) == string(dataB)
+}
+
+func newChecker(s model.Store, config Config) *checker {
+	c := &checker{store: s, config: config}
+	for _, p := range s.Projects() {
+		copied := *p
+		c.projects = append(c.projects, &copied)
+	}
+	for _, t := range s.Tags() {
+		copied := *t
+		c.tags = append(c.tags, &copied)
+	}
+	for _, f := range s.Frames() {
+		copied := *f
+		copied.TagIDs = append([]string(nil), f.TagIDs...)
+		c.frames = append(c.frames, &copied)
+	}
+	return c
+}
+
+func (c *checker) add(issueType, entity, id string, fixable bool, message string, args ...interface{}) {
+	c.issues = append(c.issues, Issue{
+		Type:    issueType,
+		Entity:  entity,
+		ID:      id,
+		Message: fmt.Sprintf(message, args...),
+		Fixable: fixable,
+	})
+}
+
+// run finds the issues and applies the fixes to the copied data.
+// Duplicates are handled first because the other checks depend on unique IDs.
+func (c *checker) run() {
+	c.checkDuplicateProjects()
+	c.checkDuplicateTags()
+	c.checkDuplicateFrames()
+	c.checkParents()
+	c.checkTagParents()
+	c.checkTagNames()
+	c.checkClientReferences()
+	c.checkFrameReferences()
+	c.checkFrameTimes()
+	c.checkActiveFrames()
+	c.checkOverlaps()
+}
+
+// duplicates are removed if they're identical. Different projects and tags with the same ID can't be fixed
+// because it's unknown which of them is referenced.
+func (c *checker) checkDuplicateProjects() {
+	seen := map[string]*model.Project{}
+	var result []*model.Project
+	for _, p := range c.projects {
+		first, ok := seen[p.ID]
+		if !ok {
+			seen[p.ID] = p
+			result = append(result, p)
+			continue
+		}
+
+		identical := sameJSON(first, p)
+		c.add(DuplicateID, "project", p.ID, identical, "the ID is used by more than one project")
+		if !identical {
+			result = append(result, p)
+		}
+	}
+	c.projects = result
+}
+
+func (c *checker) checkDuplicateTags() {
+	seen := map[string]*model.Tag{}
+	var result []*model.Tag
+	for _, t := range c.tags {
+		first, ok := seen[t.ID]
+		if !ok {
+			seen[t.ID] = t
+			result = append(result, t)
+			continue
+		}
+
+		identical := sameJSON(first, t)
+		c.add(DuplicateID, "tag", t.ID, identical, "the ID is used by more than one tag")
+		if !identical {
+			result = append(result, t)
+		}
+	}
+	c.tags = result
+}
+
+// frames aren't referenced, a duplicate frame receives a new ID
+func (c *checker) checkDuplicateFrames() {
+	seen := map[string]bool{}
+	for _, f := range c.frames {
+		if !seen[f.ID] {
+			seen[f.ID] = true
+			continue
+		}
+
+		c.add(DuplicateID, "frame", f.ID, true, "the ID is used by more than one frame")
+		f.ID = model.NextID()
+		seen[f.ID] = true
+	}
+}
+
+// projects with an unknown parent and the first project of a cycle are moved to the top level
+func (c *checker) checkParents() {
+	byID := map[string]*model.Project{}
+	for _, p := range c.projects {
+		byID[p.ID] = p
+	}
+
+	for _, p := range c.projects {
+		if p.ParentID != "" && byID[p.ParentID] == nil {
+			c.add(UnknownParent, "project", p.ID, true, "the parent project %s doesn't exist", p.ParentID)
+			p.ParentID = ""
+		}
+	}
+
+	for _, p := range c.projects {
+		visited := map[string]bool{}
+		for current := p; current != nil && current.ParentID != ""; current = byID[current.ParentID] {
+			if visited[current.ID] {
+				break
+			}
+			visited[current.ID] = true
+
+			if current.ParentID == p.ID {
+				c.add(CyclicParent, "project", p.ID, true, "the project is its own parent project")
+				p.ParentID = ""
+				break
+			}
+		}
+	}
+}
+
+// tags with an unknown parent and the first tag of a cycle are moved to the top level
+func (c *checker) checkTagParents() {
+	byID := map[string]*model.Tag{}
+	for _, t := range c.tags {
+		byID[t.ID] = t
+	}
+
+	for _, t := range c.tags {
+		if t.ParentID != "" && byID[t.ParentID] == nil {
+			c.add(UnknownParent, "tag", t.ID, true, "the parent tag %s doesn't exist", t.ParentID)
+			t.ParentID = ""
+		}
+	}
+
+	for _, t := range c.tags {
+		visited := map[string]bool{}
+		for current := t; current != nil && current.ParentID != ""; current = byID[current.ParentID] {
+			if visited[current.ID] {
+				break
+			}
+			visited[current.ID] = true
+
+			if current.ParentID == t.ID {
+				c.add(CyclicParent, "tag", t.ID, true, "the tag is its own parent tag")
+				t.ParentID = ""
+				break
+			}
+		}
+	}
+}
+
+// tags with the delimiter in their name can't be told apart from nested tags.
+// The intended nesting is unknown, the tag has to be renamed.
+func (c *checker) checkTagNames() {
+	for _, t := range c.tags {
+		if strings.Contains(t.Name, model.TagDelimiter) {
+			c.add(InvalidName, "tag", t.ID, false, "the name %s contains %s, which separates the names of nested tags", t.Name, model.TagDelimiter)
+		}
+	}
+}
+
+// projects of an unknown client fall back to the client of their parent project
+func (c *checker) checkClientReferences() {
+	for _, p := range c.projects {
+		if p.ClientID == "" {
+			continue
+		}
+		if _, err := c.store.FindFirstClient(func(client *model.Client) bool { return client.ID == p.ClientID }); err != nil {
+			c.add(UnknownClient, "project", p.ID, true, "the client %s doesn't exist", p.ClientID)
+			p.ClientID = ""
+		}
+	}
+}
+
+func (c *checker) checkFrameReferences() {
+	projects := map[string]bool{}
+	for _, p := range c.projects {
+		projects[p.ID] = true
+	}
+	tags := map[string]bool{}
+	for _, t := range c.tags {
+		tags[t.ID] = true
+	}
+
+	var orphanedProject *model.Project
+	for _, f := range c.frames {
+		if !projects[f.ProjectId] {
+			c.add(UnknownProject, "frame", f.ID, true, "the project %s doesn't exist", f.ProjectId)
+			if orphanedProject == nil {
+				orphanedProject = c.orphanedProject()
+			}
+			f.ProjectId = orphanedProject.ID
+		}
+
+		var tagIDs []string
+		for _, id := range f.TagIDs {
+			if tags[id] {
+				tagIDs = append(tagIDs, id)
+			} else {
+				c.add(UnknownTag, "frame", f.ID, true, "the tag %s doesn't exist", id)
+			}
+		}
+		f.TagIDs = tagIDs
+	}
+}
+
+// orphanedProject returns the top-level project for frames of unknown projects, it's created if necessary
+func (c *checker) orphanedProject() *model.Project {
+	for _, p := range c.projects {
+		if p.ParentID == "" && p.Name == OrphanedProjectName {
+			return p
+		}
+	}
+
+	p := &model.Project{ID: model.NextID(), Name: OrphanedProjectName}
+	c.projects = append(c.projects, p)
+	return p
+}
+
+// the correct values of start and end are unknown
+func (c *checker) checkFrameTimes() {
+	for _, f := range c.frames {
+		if f.Start == nil {
+			c.add(EndBeforeStart, "frame", f.ID, false, "the frame has no start time")
+		} else if f.IsStopped() && f.End.Before(*f.Start) {
+			c.add(EndBeforeStart, "frame", f.ID, false, "the end %s is before the start %s", f.End.Format(time.RFC3339), f.Start.Format(time.RFC3339))
+		}
+	}
+}
+
+// older active frames are stopped when the next frame was started, as "tom start" does
+func (c *checker) checkActiveFrames() {
+	if c.config.AllowMultipleActive {
+		return
+	}
+
+	var active []*model.Frame
+	for _, f := range c.frames {
+		if f.IsActive() && f.Start != nil {
+			active = append(active, f)
+		}
+	}
+	if len(active) <= 1 {
+		return
+	}
+
+	sort.SliceStable(active, func(i, j int) bool {
+		return active[i].Start.Before(*active[j].Start)
+	})
+	for i, f := range active[:len(active)-1] {
+		next := active[i+1]
+		c.add(MultipleActive, "frame", f.ID, true, "the frame is active at the same time as frame %s", next.ID)
+		f.StopAt(*next.Start)
+	}
+}
+
+// overlapping frames may be intended and are only reported
+func (c *checker) checkOverlaps() {
+	now := time.Now()
+
+	var frames []*model.Frame
+	for _, f := range c.frames {
+		if f.Start != nil && (f.IsActive() || !f.End.Before(*f.Start)) {
+			frames = append(frames, f)
+		}
+	}
+	sort.SliceStable(frames, func(i, j int) bool {
+		return frames[i].Start.Before(*frames[j].Start)
+	})
+
+	// the frame with the latest end of all frames which started before the current frame
+	var latest *model.Frame
+	var latestEnd time.Time
+	for _, f := range frames {
+		end := now
+		if f.IsStopped() {
+			end = *f.End
+		}
+
+		if latest != nil && f.Start.Before(latestEnd) {
+			if !c.config.AllowMultipleActive || !f.IsActive() || !latest.IsActive() {
+				c.add(Overlapping, "frame", f.ID, false, "the frame overlaps with frame %s", latest.ID)
+			}
+		}
+
+		if latest == nil || end.After(latestEnd) {
+			latest = f
+			latestEnd = end
+		}
+	}
+}
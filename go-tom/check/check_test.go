@@ -0,0 +1,138 @@
+package check
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jansorg/tom/go-tom/model"
+	"github.com/jansorg/tom/go-tom/store"
+)
+
+const brokenProjects = `[
+	{"id":"p1","parent":"","name":"ok","client":"c1"},
+	{"id":"p1","parent":"","name":"ok","client":"c1"},
+	{"id":"p2","parent":"missing","name":"unknown parent"},
+	{"id":"p3","parent":"p4","name":"cycle a"},
+	{"id":"p4","parent":"p3","name":"cycle b"},
+	{"id":"p5","parent":"","name":"unknown client","client":"missing"}
+]`
+const brokenClients = `[
+	{"id":"c1","name":"ACME"}
+]`
+const brokenTags = `[
+	{"id":"t1","name":"tag"},
+	{"id":"t3","parent":"missing","name":"unknown parent"},
+	{"id":"t4","parent":"t5","name":"cycle a"},
+	{"id":"t5","parent":"t4","name":"cycle b"},
+	{"id":"t6","name":"flat/name"}
+]`
+const brokenFrames = `[
+	{"id":"f1","project":"p1","start":"2019-01-01T10:00:00Z","end":"2019-01-01T11:00:00Z","tags":["t1","t2"]},
+	{"id":"f1","project":"p1","start":"2019-01-02T10:00:00Z","end":"2019-01-02T11:00:00Z"},
+	{"id":"f2","project":"deleted","start":"2019-01-03T10:00:00Z","end":"2019-01-03T11:00:00Z"},
+	{"id":"f3","project":"p1","start":"2019-01-04T10:00:00Z","end":"2019-01-04T09:00:00Z"},
+	{"id":"f4","project":"p1","start":"2019-01-05T10:00:00Z","end":"2019-01-05T12:00:00Z"},
+	{"id":"f5","project":"p1","start":"2019-01-05T11:00:00Z","end":"2019-01-05T13:00:00Z"},
+	{"id":"f6","project":"p1","start":"2019-02-01T10:00:00Z"},
+	{"id":"f7","project":"p1","start":"2019-02-02T10:00:00Z"}
+]`
+
+func newBrokenStore(t *testing.T) (model.Store, string) {
+	dir, err := ioutil.TempDir("", "tom")
+	require.NoError(t, err)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "version.json"), []byte(fmt.Sprintf(`{"version":%d}`, store.FormatVersion)), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "projects.json"), []byte(brokenProjects), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "tags.json"), []byte(brokenTags), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "clients.json"), []byte(brokenClients), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "frames-2019.json"), []byte(brokenFrames), 0600))
+
+	s, err := store.NewStore(dir, filepath.Join(dir, "backup"), 10)
+	require.NoError(t, err)
+	return s, dir
+}
+
+func issueTypes(issues []Issue) map[string]int {
+	result := map[string]int{}
+	for _, issue := range issues {
+		result[issue.Type]++
+	}
+	return result
+}
+
+func Test_Check(t *testing.T) {
+	s, dir := newBrokenStore(t)
+	defer os.RemoveAll(dir)
+
+	issues := Check(s, Config{})
+	assert.EqualValues(t, map[string]int{
+		DuplicateID:    2,
+		UnknownParent:  2,
+		CyclicParent:   2,
+		InvalidName:    1,
+		UnknownProject: 1,
+		UnknownTag:     1,
+		UnknownClient:  1,
+		EndBeforeStart: 1,
+		MultipleActive: 1,
+		Overlapping:    1,
+	}, issueTypes(issues))
+
+	for _, issue := range issues {
+		assert.False(t, issue.Fixed)
+	}
+	assert.Len(t, s.Frames(), 8, "the data must not be modified without fix")
+
+	// multiple active frames are fine if they're allowed
+	issues = Check(s, Config{AllowMultipleActive: true})
+	assert.EqualValues(t, 0, issueTypes(issues)[MultipleActive])
+}
+
+func Test_Fix(t *testing.T) {
+	s, dir := newBrokenStore(t)
+	defer os.RemoveAll(dir)
+
+	issues, err := Fix(s, Config{})
+	require.NoError(t, err)
+	for _, issue := range issues {
+		assert.EqualValues(t, issue.Type != EndBeforeStart && issue.Type != Overlapping && issue.Type != InvalidName, issue.Fixed, issue.String())
+	}
+
+	// only the unfixable issues remain
+	issues = Check(s, Config{})
+	assert.EqualValues(t, map[string]int{EndBeforeStart: 1, Overlapping: 1, InvalidName: 1}, issueTypes(issues))
+
+	assert.Len(t, s.Projects(), 6, "the identical duplicate must be removed and the project for orphaned frames added")
+	orphaned, err := s.FindFirstProject(func(p *model.Project) bool {
+		return p.Name == OrphanedProjectName
+	})
+	require.NoError(t, err)
+	frame, err := s.FindFirstFrame(func(f *model.Frame) bool {
+		return f.ID == "f2"
+	})
+	require.NoError(t, err)
+	assert.EqualValues(t, orphaned.ID, frame.ProjectId)
+
+	project, err := s.ProjectByID("p5")
+	require.NoError(t, err)
+	assert.Empty(t, project.ClientID, "the reference to an unknown client must be removed")
+	project, err = s.ProjectByID("p1")
+	require.NoError(t, err)
+	assert.EqualValues(t, "c1", project.ClientID)
+
+	for _, tag := range s.Tags() {
+		if tag.ID == "t3" || tag.ID == "t4" {
+			assert.Empty(t, tag.ParentID, "tags with an unknown parent and the first tag of a cycle must be moved to the top level")
+		}
+	}
+
+	backups, err := store.ListBackups(s)
+	require.NoError(t, err)
+	assert.Len(t, backups, 1, "a backup must be created before the data is fixed")
+}
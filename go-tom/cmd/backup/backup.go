@@ -0,0 +1,22 @@
+package backup
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/jansorg/tom/go-tom/context"
+)
+
+func NewCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "backup",
+		Short: "manage the backups of the data directory",
+	}
+
+	newListCommand(ctx, cmd)
+	newDiffCommand(ctx, cmd)
+	newRestoreCommand(ctx, cmd)
+	newCreateCommand(ctx, cmd)
+
+	parent.AddCommand(cmd)
+	return cmd
+}
@@ -0,0 +1,33 @@
+package backup
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jansorg/tom/go-tom/context"
+	"github.com/jansorg/tom/go-tom/store"
+	"github.com/jansorg/tom/go-tom/util"
+)
+
+func newCreateCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
+	label := ""
+
+	var cmd = &cobra.Command{
+		Use:   "create",
+		Short: "creates a backup of the current data. Backups with a label are never removed automatically.",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			info, err := store.CreateBackup(ctx.Store, label)
+			if err != nil {
+				util.Fatal(err)
+			}
+			fmt.Printf("Successfully created backup %s with label %s\n", info.Name(), info.Label)
+		},
+	}
+
+	cmd.Flags().StringVarP(&label, "label", "l", "", "The label of the new backup")
+	_ = cmd.MarkFlagRequired("label")
+	parent.AddCommand(cmd)
+	return cmd
+}
@@ -0,0 +1,50 @@
+package backup
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jansorg/tom/go-tom/cmd/cmdUtil"
+	"github.com/jansorg/tom/go-tom/context"
+	"github.com/jansorg/tom/go-tom/store"
+	"github.com/jansorg/tom/go-tom/util"
+)
+
+func newDiffCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
+	output := ""
+
+	var cmd = &cobra.Command{
+		Use:   "diff <backup name or label>",
+		Short: "prints the projects, tags and frames which were added, removed or changed since the backup was created",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			info, err := store.FindBackup(ctx.Store, args[0])
+			if err != nil {
+				util.Fatal(err)
+			}
+
+			backup, err := store.OpenBackup(ctx.Store, info.Dir)
+			if err != nil {
+				util.Fatal(err)
+			}
+			defer store.CloseStore(backup)
+
+			changes := store.Diff(backup, ctx.Store)
+			switch output {
+			case "json":
+				cmdUtil.PrintJSON(changes)
+			case "plain":
+				for _, change := range changes {
+					fmt.Println(change.String())
+				}
+			default:
+				util.Fatal(fmt.Errorf("unsupported output type %s", output))
+			}
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "plain", "Output format. Supported: plain | json. Default: plain")
+	parent.AddCommand(cmd)
+	return cmd
+}
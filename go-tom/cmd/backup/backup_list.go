@@ -0,0 +1,85 @@
+package backup
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jansorg/tom/go-tom/cmd/cmdUtil"
+	"github.com/jansorg/tom/go-tom/context"
+	"github.com/jansorg/tom/go-tom/store"
+	"github.com/jansorg/tom/go-tom/util"
+)
+
+type backupSummary struct {
+	info     store.BackupInfo
+	projects int
+	tags     int
+	frames   int
+}
+
+type backupList []backupSummary
+
+func (o backupList) Size() int {
+	return len(o)
+}
+
+func (o backupList) Get(index int, prop string, format string, ctx *context.TomContext) (interface{}, error) {
+	switch prop {
+	case "name":
+		return o[index].info.Name(), nil
+	case "label":
+		return o[index].info.Label, nil
+	case "created":
+		return o[index].info.Created, nil
+	case "command":
+		return o[index].info.Command, nil
+	case "projects":
+		return o[index].projects, nil
+	case "tags":
+		return o[index].tags, nil
+	case "frames":
+		return o[index].frames, nil
+	case "path":
+		return o[index].info.Dir, nil
+	default:
+		return "", fmt.Errorf("unknown property %s", prop)
+	}
+}
+
+func newListCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "list",
+		Short: "prints the backups, from oldest to newest",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			backups, err := store.ListBackups(ctx.Store)
+			if err != nil {
+				util.Fatal(err)
+			}
+
+			var list backupList
+			for _, info := range backups {
+				backup, err := store.OpenBackup(ctx.Store, info.Dir)
+				if err != nil {
+					util.Fatal(err)
+				}
+				list = append(list, backupSummary{
+					info:     info,
+					projects: len(backup.Projects()),
+					tags:     len(backup.Tags()),
+					frames:   len(backup.Frames()),
+				})
+				store.CloseStore(backup)
+			}
+
+			if err := cmdUtil.PrintList(cmd, list, ctx); err != nil {
+				util.Fatal(err)
+			}
+		},
+	}
+
+	cmdUtil.AddListOutputFlags(cmd, "name,label,projects,frames,command", []string{"name", "label", "created", "command", "projects", "tags", "frames", "path"})
+	parent.AddCommand(cmd)
+	return cmd
+}
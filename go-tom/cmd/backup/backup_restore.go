@@ -0,0 +1,33 @@
+package backup
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jansorg/tom/go-tom/context"
+	"github.com/jansorg/tom/go-tom/store"
+	"github.com/jansorg/tom/go-tom/util"
+)
+
+func newRestoreCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "restore <backup name or label>",
+		Short: "replaces the current data with the data of a backup. A backup of the current data is created first.",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			info, err := store.FindBackup(ctx.Store, args[0])
+			if err != nil {
+				util.Fatal(err)
+			}
+
+			if err := store.RestoreBackup(ctx.Store, info.Dir); err != nil {
+				util.Fatal(err)
+			}
+			fmt.Printf("Successfully restored backup %s. Use \"tom undo\" to revert the restore.\n", info.Name())
+		},
+	}
+
+	parent.AddCommand(cmd)
+	return cmd
+}
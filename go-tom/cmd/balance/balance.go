@@ -0,0 +1,167 @@
+package balance
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jansorg/tom/go-tom/cmd/cmdUtil"
+	"github.com/jansorg/tom/go-tom/context"
+	"github.com/jansorg/tom/go-tom/dateTime"
+	"github.com/jansorg/tom/go-tom/model"
+	"github.com/jansorg/tom/go-tom/report"
+	"github.com/jansorg/tom/go-tom/util"
+	"github.com/jansorg/tom/go-tom/worktime"
+)
+
+type balanceList []*report.BalanceEntry
+
+func (o balanceList) Size() int {
+	return len(o)
+}
+
+func (o balanceList) Get(index int, prop string, format string, ctx *context.TomContext) (interface{}, error) {
+	entry := o[index]
+	switch prop {
+	case "dateRange":
+		if format == "plain" {
+			return entry.DateRange.MinimalString(), nil
+		}
+		return entry.DateRange, nil
+	case "start":
+		return *entry.DateRange.Start, nil
+	case "tracked":
+		return durationValue(entry.Tracked, format, ctx), nil
+	case "target":
+		return durationValue(entry.Target, format, ctx), nil
+	case "difference":
+		return durationValue(entry.Difference(), format, ctx), nil
+	case "balance":
+		return durationValue(entry.Balance, format, ctx), nil
+	default:
+		return "", fmt.Errorf("unknown property %s", prop)
+	}
+}
+
+// durationValue returns the duration, plain output prints it with a sign
+func durationValue(d time.Duration, format string, ctx *context.TomContext) interface{} {
+	if format != "plain" {
+		return d
+	}
+	if d < 0 {
+		return "-" + ctx.DurationPrinter.Minimal(-d, false)
+	}
+	return ctx.DurationPrinter.Minimal(d, false)
+}
+
+func NewCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
+	var fromDate, toDate, split string
+	var year, month, week, day int
+
+	var cmd = &cobra.Command{
+		Use:   "balance",
+		Short: "prints the tracked and the target time per day, week or month and the overtime account",
+		Long: "The balance compares the tracked time with the target time, which is defined by 'tom balance target add'. " +
+			"The overtime account is carried forward from the first day of the targets. Days after today aren't counted. The current month is printed by default.",
+		Example: "balance --month -1 --split week",
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			targets, err := LoadTargets(ctx)
+			if err != nil {
+				util.Fatal(err)
+			}
+
+			dateRange := dateTime.NewMonthRange(time.Now(), ctx.Locale, time.Local)
+			if fromDate != "" || toDate != "" {
+				dateRange = dateTime.NewDateRange(nil, nil, ctx.Locale)
+				if dateRange.Start, err = parseDate(fromDate); err != nil {
+					util.Fatal(err)
+				}
+				if dateRange.End, err = parseDate(toDate); err != nil {
+					util.Fatal(err)
+				}
+				if dateRange.End != nil {
+					// the last day is included
+					end := dateRange.End.AddDate(0, 0, 1)
+					dateRange.End = &end
+				}
+			}
+
+			if cmd.Flag("day").Changed {
+				dateRange = dateTime.NewDayRange(time.Now(), ctx.Locale, time.Local).Shift(0, 0, day)
+			} else if cmd.Flag("week").Changed {
+				dateRange = dateTime.NewWeekRange(time.Now(), ctx.Locale, time.Local).Shift(0, 0, week*7)
+			} else if cmd.Flag("month").Changed {
+				dateRange = dateTime.NewMonthRange(time.Now(), ctx.Locale, time.Local).Shift(0, month, 0)
+			} else if cmd.Flag("year").Changed {
+				dateRange = dateTime.NewYearRange(time.Now(), ctx.Locale, time.Local).Shift(year, 0, 0)
+			}
+
+			var splitOperation report.SplitOperation
+			switch split {
+			case "day":
+				splitOperation = report.SplitByDay
+			case "week":
+				splitOperation = report.SplitByWeek
+			case "month":
+				splitOperation = report.SplitByMonth
+			default:
+				util.Fatal(fmt.Errorf("unsupported split %s. Possible values: day,week,month", split))
+			}
+
+			// the frames since the start of the overtime account are needed
+			framesStart := targets.Start()
+			if framesStart != nil && dateRange.Start != nil && dateRange.Start.Before(*framesStart) {
+				framesStart = dateRange.Start
+			}
+			frames := model.NewSortedFrameList(ctx.Query.FramesInRange(framesStart, nil))
+
+			balance, err := report.NewBalance(ctx, targets, *frames, dateRange, splitOperation, time.Now())
+			if err != nil {
+				util.Fatal(err)
+			}
+
+			if err := cmdUtil.PrintList(cmd, balanceList(balance.Entries), ctx); err != nil {
+				util.Fatal(err)
+			}
+
+			if output, _ := cmd.Flags().GetString("output"); output == "plain" {
+				// fixme i18n?
+				fmt.Printf("Tracked: %s, target: %s, overtime account: %s\n", durationValue(balance.Tracked(), output, ctx),
+					durationValue(balance.Target(), output, ctx), durationValue(balance.Final, output, ctx))
+			}
+		},
+	}
+
+	cmd.Flags().StringVarP(&fromDate, "from", "", "", "The first day of the balance, e.g. 2019-01-01")
+	cmd.Flags().StringVarP(&toDate, "to", "", "", "The last day of the balance, e.g. 2019-12-31")
+	cmd.Flags().IntVarP(&year, "year", "y", 0, "Prints the balance of a year. 0 is the current year, -1 is last year, etc.")
+	cmd.Flags().IntVarP(&month, "month", "m", 0, "Prints the balance of a month. 0 is the current month, -1 is last month, etc.")
+	cmd.Flags().IntVarP(&week, "week", "w", 0, "Prints the balance of a week. 0 is the current week, -1 is one week ago, etc.")
+	cmd.Flags().IntVarP(&day, "day", "", 0, "Prints the balance of a day. 0 is today, -1 is one day ago, etc.")
+	cmd.Flags().StringVarP(&split, "split", "s", "day", "Prints the balance per day, week or month. Possible values: day,week,month")
+	cmdUtil.AddListOutputFlags(cmd, "dateRange,tracked,target,difference,balance", []string{"dateRange", "start", "tracked", "target", "difference", "balance"})
+
+	newTargetCommand(ctx, cmd)
+	parent.AddCommand(cmd)
+	return cmd
+}
+
+// LoadTargets reads the targets of the data directory
+func LoadTargets(ctx *context.TomContext) (*worktime.Targets, error) {
+	return worktime.Load(ctx.Store)
+}
+
+// parseDate parses a day, an empty value is returned as nil
+func parseDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	date, err := time.ParseInLocation(worktime.DateFormat, value, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %s, the format is %s", value, worktime.DateFormat)
+	}
+	return &date, nil
+}
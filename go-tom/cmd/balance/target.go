@@ -0,0 +1,113 @@
+package balance
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jansorg/tom/go-tom/cmd/cmdUtil"
+	"github.com/jansorg/tom/go-tom/context"
+	"github.com/jansorg/tom/go-tom/util"
+	"github.com/jansorg/tom/go-tom/worktime"
+)
+
+type targetList []worktime.Target
+
+func (o targetList) Size() int {
+	return len(o)
+}
+
+func (o targetList) Get(index int, prop string, format string, ctx *context.TomContext) (interface{}, error) {
+	target := o[index]
+	switch prop {
+	case "id":
+		return target.ID, nil
+	case "from":
+		if target.From == nil {
+			return "", nil
+		}
+		return target.From.Format(worktime.DateFormat), nil
+	case "until":
+		if target.Until == nil {
+			return "", nil
+		}
+		return target.Until.Format(worktime.DateFormat), nil
+	case "dailyHours":
+		return target.DailyHours, nil
+	case "weeklyHours":
+		return target.WeeklyHours, nil
+	case "weekdays":
+		return strings.Join(target.WeekdayNames(), ","), nil
+	case "overrides":
+		var overrides []string
+		for name, hours := range target.Overrides {
+			overrides = append(overrides, fmt.Sprintf("%s=%gh", name, hours))
+		}
+		sort.Strings(overrides)
+		return strings.Join(overrides, ","), nil
+	default:
+		return "", fmt.Errorf("unknown property %s", prop)
+	}
+}
+
+func newTargetCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "target",
+		Short: "manage the target working time",
+		Long: "A target defines the daily or the weekly hours in a validity period. The weekly hours are distributed evenly on the working days, " +
+			"which have no override. If the validity periods of targets overlap, then the target with the latest first day applies.",
+	}
+
+	newTargetAddCommand(ctx, cmd)
+	newTargetListCommand(ctx, cmd)
+	newTargetRemoveCommand(ctx, cmd)
+
+	parent.AddCommand(cmd)
+	return cmd
+}
+
+func newTargetListCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "list",
+		Short: "prints the targets",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			targets, err := LoadTargets(ctx)
+			if err != nil {
+				util.Fatal(err)
+			}
+
+			if err := cmdUtil.PrintList(cmd, targetList(targets.Targets), ctx); err != nil {
+				util.Fatal(err)
+			}
+		},
+	}
+
+	cmdUtil.AddListOutputFlags(cmd, "id,from,until,dailyHours,weeklyHours,weekdays,overrides", []string{"id", "from", "until", "dailyHours", "weeklyHours", "weekdays", "overrides"})
+	parent.AddCommand(cmd)
+	return cmd
+}
+
+func newTargetRemoveCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "remove <id>",
+		Short: "removes a target",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			targets, err := LoadTargets(ctx)
+			if err != nil {
+				util.Fatal(err)
+			}
+
+			if _, err := targets.Remove(args[0]); err != nil {
+				util.Fatal(err)
+			}
+			fmt.Printf("Successfully removed target %s\n", args[0])
+		},
+	}
+
+	parent.AddCommand(cmd)
+	return cmd
+}
@@ -0,0 +1,83 @@
+package balance
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jansorg/tom/go-tom/context"
+	"github.com/jansorg/tom/go-tom/util"
+	"github.com/jansorg/tom/go-tom/worktime"
+)
+
+func newTargetAddCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
+	var daily, weekly time.Duration
+	var weekdays, overrides []string
+	var fromDate, untilDate string
+
+	var cmd = &cobra.Command{
+		Use:     "add",
+		Short:   "adds a target with daily or weekly hours",
+		Example: "balance target add --weekly 40h --weekdays mon-fri --override fri=6h --from 2019-01-01",
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			target := worktime.Target{
+				DailyHours:  daily.Hours(),
+				WeeklyHours: weekly.Hours(),
+			}
+
+			var err error
+			if target.From, err = parseDate(fromDate); err != nil {
+				util.Fatal(err)
+			}
+			if target.Until, err = parseDate(untilDate); err != nil {
+				util.Fatal(err)
+			}
+			if target.Weekdays, err = worktime.ParseWeekdays(weekdays); err != nil {
+				util.Fatal(err)
+			}
+
+			for _, override := range overrides {
+				parts := strings.SplitN(override, "=", 2)
+				if len(parts) != 2 {
+					util.Fatal(fmt.Errorf("invalid override %s, the format is weekday=duration, e.g. fri=6h", override))
+				}
+
+				day, err := worktime.ParseWeekday(parts[0])
+				if err != nil {
+					util.Fatal(err)
+				}
+				hours, err := time.ParseDuration(parts[1])
+				if err != nil {
+					util.Fatal(err)
+				}
+
+				if target.Overrides == nil {
+					target.Overrides = map[string]float64{}
+				}
+				target.Overrides[strings.ToLower(day.String()[:3])] = hours.Hours()
+			}
+
+			targets, err := LoadTargets(ctx)
+			if err != nil {
+				util.Fatal(err)
+			}
+			if target, err = targets.Add(target); err != nil {
+				util.Fatal(err)
+			}
+			fmt.Printf("Successfully added target %s\n", target.ID)
+		},
+	}
+
+	cmd.Flags().DurationVarP(&daily, "daily", "", 0, "The target time of each working day, e.g. 8h")
+	cmd.Flags().DurationVarP(&weekly, "weekly", "", 0, "The target time of a week, e.g. 40h. It's distributed evenly on the working days without an override.")
+	cmd.Flags().StringSliceVarP(&weekdays, "weekdays", "", nil, "The working days, e.g. mon-fri or mon,tue,thu. Default: mon-fri")
+	cmd.Flags().StringArrayVarP(&overrides, "override", "", nil, "The target time of a weekday, e.g. fri=6h. Use it multiple times to override more weekdays.")
+	cmd.Flags().StringVarP(&fromDate, "from", "", "", "The first day of the target, e.g. 2019-01-01. The target applies since ever if it's not set.")
+	cmd.Flags().StringVarP(&untilDate, "until", "", "", "The last day of the target, e.g. 2019-12-31. The target applies until further notice if it's not set.")
+
+	parent.AddCommand(cmd)
+	return cmd
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/jansorg/tom/go-tom/check"
+	"github.com/jansorg/tom/go-tom/cmd/cmdUtil"
+	"github.com/jansorg/tom/go-tom/config"
+	"github.com/jansorg/tom/go-tom/context"
+	"github.com/jansorg/tom/go-tom/util"
+)
+
+func newCheckCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
+	fix := false
+	output := ""
+
+	var cmd = &cobra.Command{
+		Use:   "check",
+		Short: "checks the integrity of the data and optionally repairs it. The exit code is 1 if problems were found which weren't fixed.",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			checkConfig := check.Config{AllowMultipleActive: !viper.GetBool(config.KeyActivityStopOnStart)}
+
+			var issues []check.Issue
+			if fix {
+				var err error
+				if issues, err = check.Fix(ctx.Store, checkConfig); err != nil {
+					util.Fatal(err)
+				}
+			} else {
+				issues = check.Check(ctx.Store, checkConfig)
+			}
+
+			remaining := 0
+			for _, issue := range issues {
+				if !issue.Fixed {
+					remaining++
+				}
+			}
+
+			switch output {
+			case "json":
+				if issues == nil {
+					issues = []check.Issue{}
+				}
+				cmdUtil.PrintJSON(issues)
+			case "plain":
+				for _, issue := range issues {
+					fmt.Println(issue.String())
+				}
+				if len(issues) == 0 {
+					fmt.Println("No problems found")
+				} else {
+					fmt.Printf("Found %d problems, %d were fixed\n", len(issues), len(issues)-remaining)
+				}
+			default:
+				util.Fatal(fmt.Errorf("unsupported output type %s", output))
+			}
+
+			if remaining > 0 {
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().BoolVarP(&fix, "fix", "", false, "Repair the problems which can be fixed safely. A backup is created first.")
+	cmd.Flags().StringVarP(&output, "output", "o", "plain", "Output format. Supported: plain | json. Default: plain")
+	parent.AddCommand(cmd)
+	return cmd
+}
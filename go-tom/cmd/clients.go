@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jansorg/tom/go-tom/cmd/cmdUtil"
+	"github.com/jansorg/tom/go-tom/context"
+	"github.com/jansorg/tom/go-tom/model"
+	"github.com/jansorg/tom/go-tom/util"
+)
+
+type clientList []*model.Client
+
+func (o clientList) Size() int {
+	return len(o)
+}
+
+func (o clientList) Get(index int, prop string, format string, ctx *context.TomContext) (interface{}, error) {
+	client := o[index]
+	switch prop {
+	case "id":
+		return client.ID, nil
+	case "name":
+		return client.Name, nil
+	case "address":
+		return client.Address, nil
+	case "currency":
+		return client.Currency, nil
+	case "hourlyRate":
+		if client.HourlyRate == nil {
+			return "", nil
+		}
+		return client.HourlyRate.ParsableString(), nil
+	case "taxID":
+		return client.TaxID, nil
+	case "taxRate":
+		return client.TaxRate, nil
+	default:
+		return "", fmt.Errorf("unknown property %s", prop)
+	}
+}
+
+func newClientsCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "clients",
+		Short: "Prints clients",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := cmdUtil.PrintList(cmd, clientList(ctx.Store.Clients()), ctx); err != nil {
+				util.Fatal(err)
+			}
+		},
+	}
+
+	cmdUtil.AddListOutputFlags(cmd, "name", []string{"id", "name", "address", "currency", "hourlyRate", "taxID", "taxRate"})
+	parent.AddCommand(cmd)
+	return cmd
+}
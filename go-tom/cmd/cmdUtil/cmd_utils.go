@@ -3,15 +3,23 @@ package cmdUtil
 import (
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"os"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
+	"github.com/jansorg/tom/go-tom/config"
 	"github.com/jansorg/tom/go-tom/context"
 	"github.com/jansorg/tom/go-tom/dateTime"
+	"github.com/jansorg/tom/go-tom/hooks"
+	"github.com/jansorg/tom/go-tom/model"
+	"github.com/jansorg/tom/go-tom/store"
 	"github.com/jansorg/tom/go-tom/util"
+	"github.com/jansorg/tom/go-tom/workspace"
 )
 
 type PropList interface {
@@ -86,6 +94,73 @@ func PrintList(cmd *cobra.Command, data PropList, ctx *context.TomContext) error
 	return nil
 }
 
+// StoreOptions returns the options to open the data directory, as defined by the current configuration
+func StoreOptions() store.Options {
+	return storeOptions(viper.GetViper())
+}
+
+// WorkspaceStoreOptions returns the options to open the data directory of a workspace, as defined by its configuration
+func WorkspaceStoreOptions(registry *workspace.Registry, w workspace.Workspace) (store.Options, error) {
+	if w.Name == registry.ActiveWorkspace().Name {
+		return StoreOptions(), nil
+	}
+
+	v, err := config.WorkspaceConfig(w.DataDir, w.BackupDir)
+	if err != nil {
+		return store.Options{}, err
+	}
+	return storeOptions(v), nil
+}
+
+func storeOptions(v *viper.Viper) store.Options {
+	return store.Options{
+		Format:             v.GetString(config.KeyDataFormat),
+		Dir:                v.GetString(config.KeyDataDir),
+		BackupDir:          v.GetString(config.KeyBackupDir),
+		MaxBackups:         v.GetInt(config.KeyMaxBackups),
+		Journal:            v.GetBool(config.KeyJournal),
+		JournalCompactSize: v.GetInt(config.KeyJournalCompactSize),
+		LockTimeout:        v.GetDuration(config.KeyLockTimeout),
+		Command:            strings.Join(append([]string{"tom"}, os.Args[1:]...), " "),
+		Passphrase:         passphrase(v),
+	}
+}
+
+// Passphrase returns the passphrase of the encrypted data directory.
+// It's read from the configured key file or, if there's none, from the configured environment variable.
+func Passphrase() string {
+	return passphrase(viper.GetViper())
+}
+
+func passphrase(v *viper.Viper) string {
+	if keyFile := v.GetString(config.KeyEncryptionKeyFile); keyFile != "" {
+		data, err := ioutil.ReadFile(keyFile)
+		if err != nil {
+			util.Fatal(fmt.Errorf("unable to read the key file: %s", err.Error()))
+		}
+		return strings.TrimSpace(string(data))
+	}
+	return os.Getenv(v.GetString(config.KeyEncryptionPassphraseEnv))
+}
+
+// ExpireTrash removes the entries of the trash, which are older than the configured number of days
+func ExpireTrash(s model.Store) error {
+	_, err := store.ExpireTrash(s, time.Duration(viper.GetInt(config.KeyTrashExpiryDays))*24*time.Hour)
+	return err
+}
+
+// HookConfig returns the hooks, which are defined by the current configuration
+func HookConfig() hooks.Config {
+	return hooks.Config{
+		Scripts: map[string]string{
+			hooks.FrameStarted: viper.GetString(config.KeyHookFrameStarted),
+			hooks.FrameStopped: viper.GetString(config.KeyHookFrameStopped),
+			hooks.FrameUpdated: viper.GetString(config.KeyHookFrameUpdated),
+		},
+		Timeout: viper.GetDuration(config.KeyHookTimeout),
+	}
+}
+
 func PrintJSON(value interface{}) {
 	if value == nil {
 		return
@@ -3,15 +3,11 @@ package config
 import (
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
-	"os"
-	"path/filepath"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"gopkg.in/yaml.v1"
 
-	"github.com/jansorg/tom/go-tom/config"
 	"github.com/jansorg/tom/go-tom/context"
 	"github.com/jansorg/tom/go-tom/util"
 )
@@ -59,11 +55,3 @@ func doConfigCommand(outputFormat string, keys ...string) ([]byte, error) {
 		return nil, fmt.Errorf("unsupported format %s", outputFormat)
 	}
 }
-
-func createConfigIfNotExists(dataDir string) error {
-	filePath := filepath.Join(dataDir, fmt.Sprintf("%s.yaml", config.ConfigFilename))
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
-		return ioutil.WriteFile(filePath, []byte{}, 0600)
-	}
-	return nil
-}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 
 	"github.com/jansorg/tom/go-tom/config"
 	"github.com/jansorg/tom/go-tom/context"
@@ -21,12 +20,7 @@ func newSetCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Comman
 		ValidArgs:             config.Keys,
 		DisableFlagsInUseLine: true,
 		Run: func(cmd *cobra.Command, args []string) {
-			if err := createConfigIfNotExists(viper.GetString(config.KeyDataDir)); err != nil {
-				util.Fatal(err)
-			}
-
-			viper.Set(args[0], args[1])
-			if err := viper.WriteConfig(); err != nil {
+			if err := config.WriteValue(args[0], args[1]); err != nil {
 				util.Fatal("error updating configuration file: ", err)
 			}
 
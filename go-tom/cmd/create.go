@@ -14,6 +14,7 @@ func newCreateCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Com
 
 	newCreateProjectCommand(ctx, cmd)
 	newCreateTagCommand(ctx, cmd)
+	newCreateClientCommand(ctx, cmd)
 
 	parent.AddCommand(cmd)
 	return cmd
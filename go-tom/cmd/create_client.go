@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jansorg/tom/go-tom/cmd/cmdUtil"
+	"github.com/jansorg/tom/go-tom/context"
+	"github.com/jansorg/tom/go-tom/model"
+	"github.com/jansorg/tom/go-tom/money"
+	"github.com/jansorg/tom/go-tom/util"
+)
+
+func newCreateClientCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
+	var address string
+	var currency string
+	var hourlyRate string
+	var taxID string
+	var taxRate string
+
+	var output string
+
+	var cmd = &cobra.Command{
+		Use:     "client name",
+		Short:   "Create a new client. Projects are assigned to a client by 'tom edit project --client'.",
+		Args:    cobra.ExactArgs(1),
+		Example: "tom create client \"ACME Inc.\" --currency EUR --hourly-rate \"85 EUR\" --tax-rate 19",
+		Run: func(cmd *cobra.Command, args []string) {
+			client, err := doCreateClientCommand(args[0], address, currency, hourlyRate, taxID, taxRate, ctx)
+			if err != nil {
+				util.Fatal(err)
+			}
+
+			if output == "json" {
+				cmdUtil.PrintJSON(client)
+			} else {
+				fmt.Printf("created client %s\n", client.Name)
+			}
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "plain", "Output format. Supported: plain | json. Default: plain")
+	cmd.Flags().StringVarP(&address, "address", "", "", "Optional postal address of the client")
+	cmd.Flags().StringVarP(&currency, "currency", "", "", "Optional currency code of the client, e.g. EUR. The default is the currency of the hourly rate.")
+	cmd.Flags().StringVarP(&hourlyRate, "hourly-rate", "", "", "Optional hourly rate, e.g. '85 EUR'. It applies to the projects of the client without an hourly rate in the project hierarchy.")
+	cmd.Flags().StringVarP(&taxID, "tax-id", "", "", "Optional tax ID of the client, e.g. the VAT ID")
+	cmd.Flags().StringVarP(&taxRate, "tax-rate", "", "", "Optional tax rate in percent, e.g. 19")
+	parent.AddCommand(cmd)
+
+	return cmd
+}
+
+func doCreateClientCommand(name, address, currency, hourlyRate, taxID, taxRate string, ctx *context.TomContext) (*model.Client, error) {
+	if _, err := ctx.Query.ClientByName(name); err == nil {
+		return nil, fmt.Errorf("the client %s does already exist", name)
+	}
+
+	client := model.Client{Name: name, Address: address, Currency: currency, TaxID: taxID}
+	if hourlyRate != "" {
+		rate, err := money.Parse(hourlyRate)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse hourly rate %s", hourlyRate)
+		}
+		client.HourlyRate = rate
+		if client.Currency == "" {
+			client.Currency = rate.CurrencyCode()
+		}
+	}
+	if taxRate != "" {
+		rate, err := strconv.ParseFloat(taxRate, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse tax rate %s", taxRate)
+		}
+		client.TaxRate = rate
+	}
+
+	return ctx.Store.AddClient(client)
+}
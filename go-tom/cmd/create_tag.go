@@ -1,22 +1,25 @@
 package cmd
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
 
 	"github.com/jansorg/tom/go-tom/context"
-	"github.com/jansorg/tom/go-tom/model"
 	"github.com/jansorg/tom/go-tom/util"
 )
 
 func newCreateTagCommand(context *context.TomContext, parent *cobra.Command) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "tag name...",
-		Short: "create a new tag",
+		Short: "create a new tag. A name like meeting/client creates the tag client below the tag meeting.",
 		Args:  cobra.MinimumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			for _, name := range args {
-				if _, err := context.Store.AddTag(model.Tag{Name: name}); err != nil {
+				if _, created, err := context.StoreHelper.GetOrCreateNestedTag(name); err != nil {
 					util.Fatal(err)
+				} else if !created {
+					util.Fatal(fmt.Errorf("tag %s already exists", name))
 				}
 			}
 		},
@@ -8,12 +8,14 @@ import (
 
 func NewEditCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
 	var cmd = &cobra.Command{
-		Use:   "edit project | frame",
-		Short: "edit properties of projects or frames",
+		Use:   "edit project | frame | tag | client",
+		Short: "edit properties of projects, frames, tags or clients",
 	}
 
 	newEditFrameCommand(ctx, cmd)
 	newEditProjectCommand(ctx, cmd)
+	newEditTagCommand(ctx, cmd)
+	newEditClientCommand(ctx, cmd)
 
 	parent.AddCommand(cmd)
 	return cmd
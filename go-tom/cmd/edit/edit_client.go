@@ -0,0 +1,149 @@
+package edit
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jansorg/tom/go-tom/context"
+	"github.com/jansorg/tom/go-tom/model"
+	"github.com/jansorg/tom/go-tom/money"
+	"github.com/jansorg/tom/go-tom/util"
+)
+
+func newEditClientCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
+	var name string
+	var address string
+	var currency string
+	var hourlyRate string
+	var taxID string
+	var taxRate string
+
+	var cmd = &cobra.Command{
+		Use:   "client name | ID",
+		Short: "edit properties of a client",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if cmd.Flag("name").Changed && len(name) == 0 {
+				util.Fatal("unable to use empty client name")
+			}
+
+			update := clientUpdate{}
+			if cmd.Flag("name").Changed {
+				update.name = &name
+			}
+			if cmd.Flag("address").Changed {
+				update.address = &address
+			}
+			if cmd.Flag("currency").Changed {
+				update.currency = &currency
+			}
+			if cmd.Flag("hourly-rate").Changed {
+				update.hourlyRate = &hourlyRate
+			}
+			if cmd.Flag("tax-id").Changed {
+				update.taxID = &taxID
+			}
+			if cmd.Flag("tax-rate").Changed {
+				update.taxRate = &taxRate
+			}
+			if update.isEmpty() {
+				util.Fatalf("no modification defined, use --name, --address, --currency, --hourly-rate, --tax-id or --tax-rate to update client data")
+			}
+
+			if err := doEditClientCommand(update, args, ctx); err != nil {
+				util.Fatal(err)
+			} else {
+				println("Successfully updated client data")
+			}
+		},
+	}
+
+	cmd.Flags().StringVarP(&name, "name", "n", "", "update the client name")
+	cmd.Flags().StringVarP(&address, "address", "", "", "update the postal address of the client")
+	cmd.Flags().StringVarP(&currency, "currency", "", "", "update the currency code of the client, e.g. EUR. Use an empty value to remove it.")
+	cmd.Flags().StringVarP(&hourlyRate, "hourly-rate", "", "", "update the hourly rate, which applies to the projects of the client without an hourly rate in the project hierarchy. Use an empty value to remove it.")
+	cmd.Flags().StringVarP(&taxID, "tax-id", "", "", "update the tax ID of the client, e.g. the VAT ID")
+	cmd.Flags().StringVarP(&taxRate, "tax-rate", "", "", "update the tax rate in percent, e.g. 19. Use an empty value to remove it.")
+
+	parent.AddCommand(cmd)
+	return cmd
+}
+
+// clientUpdate contains the modified values of a client. Undefined values are kept, empty values are removed.
+type clientUpdate struct {
+	name       *string
+	address    *string
+	currency   *string
+	hourlyRate *string
+	taxID      *string
+	taxRate    *string
+}
+
+func (u *clientUpdate) isEmpty() bool {
+	return u.name == nil && u.address == nil && u.currency == nil && u.hourlyRate == nil && u.taxID == nil && u.taxRate == nil
+}
+
+func (u *clientUpdate) apply(c *model.Client) error {
+	if u.name != nil && *u.name != "" {
+		c.Name = *u.name
+	}
+	if u.address != nil {
+		c.Address = *u.address
+	}
+	if u.currency != nil {
+		c.Currency = *u.currency
+	}
+	if u.hourlyRate != nil {
+		if *u.hourlyRate == "" {
+			c.HourlyRate = nil
+		} else {
+			rate, err := money.Parse(*u.hourlyRate)
+			if err != nil {
+				return fmt.Errorf("unable to parse hourly rate %s", *u.hourlyRate)
+			}
+			c.HourlyRate = rate
+		}
+	}
+	if u.taxID != nil {
+		c.TaxID = *u.taxID
+	}
+	if u.taxRate != nil {
+		if *u.taxRate == "" {
+			c.TaxRate = 0
+		} else {
+			rate, err := strconv.ParseFloat(*u.taxRate, 64)
+			if err != nil {
+				return fmt.Errorf("unable to parse tax rate %s", *u.taxRate)
+			}
+			c.TaxRate = rate
+		}
+	}
+	return nil
+}
+
+func doEditClientCommand(update clientUpdate, clientNamesOrIDs []string, ctx *context.TomContext) error {
+	// a single transaction to handle many clients at once
+	return ctx.Store.Update(func(tx model.Store) error {
+		var clients []*model.Client
+		for _, nameOrID := range clientNamesOrIDs {
+			client, err := ctx.Query.ClientByNameOrID(nameOrID)
+			if err != nil {
+				return err
+			}
+			clients = append(clients, client)
+		}
+
+		for _, c := range clients {
+			updated := *c
+			if err := update.apply(&updated); err != nil {
+				return err
+			}
+			if _, err := tx.UpdateClient(updated); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,48 @@
+package edit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+
+	"github.com/jansorg/tom/go-tom/model"
+	"github.com/jansorg/tom/go-tom/money"
+	"github.com/jansorg/tom/go-tom/test_setup"
+	"github.com/jansorg/tom/go-tom/util"
+)
+
+func Test_EditClient(t *testing.T) {
+	ctx, err := test_setup.CreateTestContext(language.English)
+	require.NoError(t, err)
+	defer test_setup.CleanupTestContext(ctx)
+
+	acme, err := ctx.Store.AddClient(model.Client{Name: "ACME", Address: "Main Street 1"})
+	require.NoError(t, err)
+
+	err = doEditClientCommand(clientUpdate{name: util.StringP("ACME Inc."), currency: util.StringP("EUR"), hourlyRate: util.StringP("85 EUR"), taxRate: util.StringP("19")}, []string{"ACME"}, ctx)
+	require.NoError(t, err)
+
+	client, err := ctx.Query.ClientByID(acme.ID)
+	require.NoError(t, err)
+	assert.EqualValues(t, "ACME Inc.", client.Name)
+	assert.EqualValues(t, "Main Street 1", client.Address, "undefined values must be kept")
+	assert.EqualValues(t, money.NewMoney(8500, "EUR"), client.HourlyRate)
+	assert.EqualValues(t, 19, client.TaxRate)
+
+	err = doEditClientCommand(clientUpdate{hourlyRate: util.StringP("85 USD")}, []string{acme.ID}, ctx)
+	require.Error(t, err, "the rate must use the currency of the client")
+	err = doEditClientCommand(clientUpdate{taxRate: util.StringP("-1")}, []string{acme.ID}, ctx)
+	require.Error(t, err, "negative tax rates must be rejected")
+	err = doEditClientCommand(clientUpdate{address: util.StringP("")}, []string{"unknown"}, ctx)
+	require.Error(t, err)
+
+	// empty values remove the rate and the tax rate
+	err = doEditClientCommand(clientUpdate{hourlyRate: util.StringP(""), taxRate: util.StringP("")}, []string{acme.ID}, ctx)
+	require.NoError(t, err)
+	client, err = ctx.Query.ClientByID(acme.ID)
+	require.NoError(t, err)
+	assert.Nil(t, client.HourlyRate)
+	assert.Zero(t, client.TaxRate)
+}
@@ -7,7 +7,9 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/jansorg/tom/go-tom/context"
+	"github.com/jansorg/tom/go-tom/model"
 	"github.com/jansorg/tom/go-tom/util"
+	"github.com/jansorg/tom/go-tom/util/tristate"
 )
 
 func newEditFrameCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
@@ -17,6 +19,8 @@ func newEditFrameCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.
 	var nameDelimiter string
 	var notes string
 	var archive bool
+	var fields []string
+	var billable string
 
 	var cmd = &cobra.Command{
 		Use:   "frame ID",
@@ -56,7 +60,21 @@ func newEditFrameCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.
 				archiveFrames = &archive
 			}
 
-			if err := doEditFrameCommand(ctx, args, usedStart, usedEnd, usedNotes, usedProjectID, nameDelimiter, archiveFrames); err != nil {
+			fieldValues, err := model.ParseFields(fields)
+			if err != nil {
+				util.Fatal(err)
+			}
+
+			var billableValue *tristate.Tristate
+			if cmd.Flag("billable").Changed {
+				value, err := tristate.FromString(billable)
+				if err != nil {
+					util.Fatalf("unable to parse value %s for billable. Valid values: true, false, empty value", billable)
+				}
+				billableValue = &value
+			}
+
+			if err := doEditFrameCommand(ctx, args, usedStart, usedEnd, usedNotes, usedProjectID, nameDelimiter, archiveFrames, fieldValues, billableValue); err != nil {
 				util.Fatal(err)
 			} else {
 				fmt.Println("successfully updated")
@@ -70,12 +88,14 @@ func newEditFrameCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.
 	cmd.Flags().StringVarP(&projectIDOrName, "project", "p", "", "Project ID or full name to use as new project for all passed frame IDs")
 	cmd.Flags().StringVarP(&nameDelimiter, "name-delimiter", "", "/", "Delimiter used in full project names")
 	cmd.Flags().BoolVarP(&archive, "archived", "", archive, "Sets the archived flag")
+	cmd.Flags().StringArrayVarP(&fields, "field", "", nil, "updates a custom field, e.g. --field ticket=PROJ-42. Pass an empty value to remove the field, e.g. --field ticket=")
+	cmd.Flags().StringVarP(&billable, "billable", "", "", "Overrides the billable flag of the project: true or false. Pass an empty value to use the flag of the project.")
 
 	parent.AddCommand(cmd)
 	return cmd
 }
 
-func doEditFrameCommand(ctx *context.TomContext, frameIDs []string, startTime, endTime, notes, projectIDOrName *string, nameDelimiter string, archived *bool) error {
+func doEditFrameCommand(ctx *context.TomContext, frameIDs []string, startTime, endTime, notes, projectIDOrName *string, nameDelimiter string, archived *bool, fields map[string]string, billable *tristate.Tristate) error {
 	// make sure that all frames exist before applying updates
 	frames, err := ctx.Query.FramesByID(frameIDs...)
 	if err != nil {
@@ -92,46 +112,50 @@ func doEditFrameCommand(ctx *context.TomContext, frameIDs []string, startTime, e
 		}
 	}
 
-	ctx.Store.StartBatch()
-	defer ctx.Store.StopBatch()
+	return ctx.Store.Update(func(tx model.Store) error {
+		for _, frame := range frames {
+			if startTime != nil {
+				if *startTime == "" {
+					return fmt.Errorf("empty start time is not allowed")
+				} else if start, err := time.Parse(time.RFC3339, *startTime); err != nil {
+					return err
+				} else {
+					frame.Start = &start
+				}
+			}
 
-	for _, frame := range frames {
-		if startTime != nil {
-			if *startTime == "" {
-				return fmt.Errorf("empty start time is not allowed")
-			} else if start, err := time.Parse(time.RFC3339, *startTime); err != nil {
-				return err
-			} else {
-				frame.Start = &start
+			if endTime != nil {
+				if *endTime == "" {
+					frame.End = nil
+				} else if end, err := time.Parse(time.RFC3339, *endTime); err != nil {
+					return err
+				} else {
+					frame.End = &end
+				}
 			}
-		}
 
-		if endTime != nil {
-			if *endTime == "" {
-				frame.End = nil
-			} else if end, err := time.Parse(time.RFC3339, *endTime); err != nil {
-				return err
-			} else {
-				frame.End = &end
+			if projectIDOrName != nil {
+				frame.ProjectId = validatedProjectID
 			}
-		}
 
-		if projectIDOrName != nil {
-			frame.ProjectId = validatedProjectID
-		}
+			if notes != nil {
+				frame.Notes = *notes
+			}
 
-		if notes != nil {
-			frame.Notes = *notes
-		}
+			if archived != nil {
+				frame.Archived = *archived
+			}
 
-		if archived != nil {
-			frame.Archived = *archived
-		}
+			frame.SetFields(fields)
 
-		if frame, err = ctx.Store.UpdateFrame(*frame); err != nil {
-			return err
-		}
-	}
+			if billable != nil {
+				frame.Billable = billable.ToBool()
+			}
 
-	return nil
+			if _, err := tx.UpdateFrame(*frame); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
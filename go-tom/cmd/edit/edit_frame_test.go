@@ -11,6 +11,7 @@ import (
 	"github.com/jansorg/tom/go-tom/model"
 	"github.com/jansorg/tom/go-tom/test_setup"
 	"github.com/jansorg/tom/go-tom/util"
+	"github.com/jansorg/tom/go-tom/util/tristate"
 )
 
 func TestEditFrame(t *testing.T) {
@@ -37,7 +38,7 @@ func TestEditFrame(t *testing.T) {
 	newEnd := end.Add(6 * time.Hour)
 	newEndString := newEnd.Format(time.RFC3339)
 	newNotes := "my new notes"
-	err = doEditFrameCommand(ctx, []string{f1.ID, f2.ID}, nil, &newEndString, &newNotes, &(p2.ID), "/", nil)
+	err = doEditFrameCommand(ctx, []string{f1.ID, f2.ID}, nil, &newEndString, &newNotes, &(p2.ID), "/", nil, nil, nil)
 	require.NoError(t, err)
 
 	newF1, err := ctx.Query.FrameByID(f1.ID)
@@ -56,16 +57,44 @@ func TestEditFrame(t *testing.T) {
 
 	// update f2 to use p1
 	projectName1 := p1.GetFullName("/")
-	err = doEditFrameCommand(ctx, []string{f2.ID}, nil, nil, nil, &projectName1, "/", nil)
+	err = doEditFrameCommand(ctx, []string{f2.ID}, nil, nil, nil, &projectName1, "/", nil, nil, nil)
 	require.NoError(t, err)
 	newF2, err = ctx.Query.FrameByID(f2.ID)
 	require.NoError(t, err)
 	assert.EqualValues(t, p1.ID, newF2.ProjectId)
 
 	// update f2 to be archived
-	err = doEditFrameCommand(ctx, []string{f2.ID}, nil, nil, nil, nil, "/", util.TrueP())
+	err = doEditFrameCommand(ctx, []string{f2.ID}, nil, nil, nil, nil, "/", util.TrueP(), nil, nil)
 	require.NoError(t, err)
 	assert.EqualValues(t, true, newF2.Archived)
+
+	// set and remove custom fields
+	err = doEditFrameCommand(ctx, []string{f2.ID}, nil, nil, nil, nil, "/", nil, map[string]string{"ticket": "PROJ-42", "location": "onsite"}, nil)
+	require.NoError(t, err)
+	newF2, err = ctx.Query.FrameByID(f2.ID)
+	require.NoError(t, err)
+	assert.EqualValues(t, map[string]string{"ticket": "PROJ-42", "location": "onsite"}, newF2.Fields)
+
+	err = doEditFrameCommand(ctx, []string{f2.ID}, nil, nil, nil, nil, "/", nil, map[string]string{"location": ""}, nil)
+	require.NoError(t, err)
+	newF2, err = ctx.Query.FrameByID(f2.ID)
+	require.NoError(t, err)
+	assert.EqualValues(t, map[string]string{"ticket": "PROJ-42"}, newF2.Fields)
+
+	// override the billable flag of the project and remove the override
+	err = doEditFrameCommand(ctx, []string{f2.ID}, nil, nil, nil, nil, "/", nil, nil, tristate.FalseP())
+	require.NoError(t, err)
+	newF2, err = ctx.Query.FrameByID(f2.ID)
+	require.NoError(t, err)
+	assert.EqualValues(t, util.FalseP(), newF2.Billable)
+	assert.False(t, ctx.Query.IsFrameBillable(newF2))
+
+	err = doEditFrameCommand(ctx, []string{f2.ID}, nil, nil, nil, nil, "/", nil, nil, tristate.InheritedP())
+	require.NoError(t, err)
+	newF2, err = ctx.Query.FrameByID(f2.ID)
+	require.NoError(t, err)
+	assert.Nil(t, newF2.Billable)
+	assert.True(t, ctx.Query.IsFrameBillable(newF2))
 }
 
 func TestEditFrameErrors(t *testing.T) {
@@ -81,14 +110,14 @@ func TestEditFrameErrors(t *testing.T) {
 	require.NoError(t, err)
 
 	empty := ""
-	err = doEditFrameCommand(ctx, []string{f.ID}, nil, nil, nil, &empty, "/", nil)
+	err = doEditFrameCommand(ctx, []string{f.ID}, nil, nil, nil, &empty, "/", nil, nil, nil)
 	require.Error(t, err, "empty project must not be accepted")
 
 	name := "Invalid/project/name"
-	err = doEditFrameCommand(ctx, []string{f.ID}, nil, nil, nil, &name, "/", nil)
+	err = doEditFrameCommand(ctx, []string{f.ID}, nil, nil, nil, &name, "/", nil, nil, nil)
 	require.Error(t, err, "not existing project must not be accepted")
 
-	err = doEditFrameCommand(ctx, []string{"does not exist"}, nil, nil, nil, &empty, "/", nil)
+	err = doEditFrameCommand(ctx, []string{"does not exist"}, nil, nil, nil, &empty, "/", nil, nil, nil)
 	require.Error(t, err, "invalid frame id must not be accepted")
 }
 
@@ -110,7 +139,7 @@ func TestEditFrameStartStopTimeZone(t *testing.T) {
 	var timezone = time.UTC
 	newStartString := start.Add(1 * time.Hour).In(timezone).Format(time.RFC3339)
 	newEndString := end.Add(1 * time.Hour).In(timezone).Format(time.RFC3339)
-	err = doEditFrameCommand(ctx, []string{frame.ID}, &newStartString, &newEndString, nil, nil, "/", nil)
+	err = doEditFrameCommand(ctx, []string{frame.ID}, &newStartString, &newEndString, nil, nil, "/", nil, nil, nil)
 	require.NoError(t, err)
 
 	updatedFrame, err := ctx.Query.FrameByID(frame.ID)
@@ -2,6 +2,7 @@ package edit
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -19,6 +20,11 @@ func newEditProjectCommand(ctx *context.TomContext, parent *cobra.Command) *cobr
 	var nameDelimiter string
 	var hourlyRate string
 	var noteRequired string
+	var budgetHours string
+	var budgetMoney string
+	var budgetPeriod string
+	var clientNameOrID string
+	var billable string
 
 	var cmd = &cobra.Command{
 		Use:   "project fullName | ID",
@@ -27,8 +33,9 @@ func newEditProjectCommand(ctx *context.TomContext, parent *cobra.Command) *cobr
 		Run: func(cmd *cobra.Command, args []string) {
 			if cmd.Flag("name").Changed && len(name) == 0 {
 				util.Fatal("unable to use empty project name")
-			} else if !cmd.Flag("name").Changed && !cmd.Flag("parent").Changed && !cmd.Flag("hourly-rate").Changed && !cmd.Flag("note-required").Changed {
-				util.Fatalf("no modification defined, use --name, --parent, or --hourly-rate to update project data")
+			} else if !cmd.Flag("name").Changed && !cmd.Flag("parent").Changed && !cmd.Flag("hourly-rate").Changed && !cmd.Flag("note-required").Changed &&
+				!cmd.Flag("budget-hours").Changed && !cmd.Flag("budget-money").Changed && !cmd.Flag("budget-period").Changed && !cmd.Flag("client").Changed && !cmd.Flag("billable").Changed {
+				util.Fatalf("no modification defined, use --name, --parent, --hourly-rate, --budget-hours, --client or --billable to update project data")
 			}
 
 			var parent *string
@@ -50,7 +57,35 @@ func newEditProjectCommand(ctx *context.TomContext, parent *cobra.Command) *cobr
 				noteRequiredValue = &value
 			}
 
-			if err := doEditProjectCommand(name, parent, nameDelimiter, hourlyRateValue, noteRequiredValue, args, ctx); err != nil {
+			var billableValue *tristate.Tristate
+			if cmd.Flag("billable").Changed {
+				value, err := tristate.FromString(billable)
+				if err != nil {
+					util.Fatalf("unable to parse value %s for billable. Valid values: true, false, empty value", billable)
+				}
+				billableValue = &value
+			}
+
+			var budget *budgetUpdate
+			if cmd.Flag("budget-hours").Changed || cmd.Flag("budget-money").Changed || cmd.Flag("budget-period").Changed {
+				budget = &budgetUpdate{}
+				if cmd.Flag("budget-hours").Changed {
+					budget.hours = &budgetHours
+				}
+				if cmd.Flag("budget-money").Changed {
+					budget.money = &budgetMoney
+				}
+				if cmd.Flag("budget-period").Changed {
+					budget.period = &budgetPeriod
+				}
+			}
+
+			var client *string
+			if cmd.Flag("client").Changed {
+				client = &clientNameOrID
+			}
+
+			if err := doEditProjectCommand(name, parent, nameDelimiter, hourlyRateValue, noteRequiredValue, billableValue, budget, client, args, ctx); err != nil {
 				util.Fatal(err)
 			} else {
 				println("Successfully updated project data")
@@ -63,14 +98,20 @@ func newEditProjectCommand(ctx *context.TomContext, parent *cobra.Command) *cobr
 	cmd.Flags().StringVarP(&nameDelimiter, "name-delimiter", "", "/", "Delimiter used in full project names")
 	cmd.Flags().StringVarP(&hourlyRate, "hourly-rate", "", "", "Optional hourly rate which applies to this project and all subproject without hourly rate values")
 	cmd.Flags().StringVarP(&noteRequired, "note-required", "", "", "An optional flag to enforce a note for time entries of this project and all subprojects, where this setting is not turned off.")
+	cmd.Flags().StringVarP(&budgetHours, "budget-hours", "", "", "Optional budget in hours, e.g. 40 or 7.5. It applies to this project and all subprojects without a budget. Use an empty value to remove it.")
+	cmd.Flags().StringVarP(&budgetMoney, "budget-money", "", "", "Optional budget in money, e.g. '5000 EUR'. The sales are calculated with the hourly rates of the projects. Use an empty value to remove it.")
+	cmd.Flags().StringVarP(&budgetPeriod, "budget-period", "", "", "The period of the budget: total or monthly. The default is total.")
+	cmd.Flags().StringVarP(&billable, "billable", "", "", "An optional flag to define if the time of this project and all subprojects, where this setting is not defined, is billable. Only billable time is counted as sales. Valid values: true, false, empty value")
+	cmd.Flags().StringVarP(&clientNameOrID, "client", "", "", "Name or ID of the client of this project and all subprojects without a client. Use an empty value to remove it.")
 
 	parent.AddCommand(cmd)
 	return cmd
 }
 
-func doEditProjectCommand(newName string, parentNameOrID *string, nameDelimiter string, hourlyRate *string, noteRequired *tristate.Tristate, projectIDsOrNames []string, ctx *context.TomContext) error {
+func doEditProjectCommand(newName string, parentNameOrID *string, nameDelimiter string, hourlyRate *string, noteRequired *tristate.Tristate, billable *tristate.Tristate, budget *budgetUpdate, clientNameOrID *string, projectIDsOrNames []string, ctx *context.TomContext) error {
 	var err error
 	var parentProjectID string
+	var clientID string
 
 	// a non-nil, but empty parentNameOrID points to the top-level
 	if parentNameOrID != nil && *parentNameOrID != "" {
@@ -81,6 +122,15 @@ func doEditProjectCommand(newName string, parentNameOrID *string, nameDelimiter
 		}
 	}
 
+	// a non-nil, but empty clientNameOrID removes the client
+	if clientNameOrID != nil && *clientNameOrID != "" {
+		if client, err := ctx.Query.ClientByNameOrID(*clientNameOrID); err != nil {
+			return fmt.Errorf("client %s not found", *clientNameOrID)
+		} else {
+			clientID = client.ID
+		}
+	}
+
 	var parsedHourlyRate *money.Money
 	if hourlyRate != nil {
 		if *hourlyRate == "" {
@@ -93,44 +143,108 @@ func doEditProjectCommand(newName string, parentNameOrID *string, nameDelimiter
 		}
 	}
 
-	// batch mode to handle many projects at once
-	ctx.Store.StartBatch()
-	defer ctx.Store.StopBatch()
+	// a single transaction to handle many projects at once
+	return ctx.Store.Update(func(tx model.Store) error {
+		var projects []*model.Project
+		for _, idOrName := range projectIDsOrNames {
+			var project *model.Project
+			if project, err = ctx.Query.ProjectByID(idOrName); err != nil {
+				if project, err = ctx.Query.ProjectByFullName(strings.Split(idOrName, nameDelimiter)); err != nil {
+					return err
+				}
+			}
+			projects = append(projects, project)
+		}
+
+		for _, p := range projects {
+			if len(newName) > 0 {
+				p.Name = newName
+			}
+
+			if hourlyRate != nil {
+				p.SetHourlyRate(parsedHourlyRate)
+			}
+
+			if noteRequired != nil {
+				p.SetNoteRequired(noteRequired.ToBool())
+			}
 
-	var projects []*model.Project
-	for _, idOrName := range projectIDsOrNames {
-		var project *model.Project
-		if project, err = ctx.Query.ProjectByID(idOrName); err != nil {
-			if project, err = ctx.Query.ProjectByFullName(strings.Split(idOrName, nameDelimiter)); err != nil {
+			if billable != nil {
+				p.SetBillable(billable.ToBool())
+			}
+
+			if budget != nil {
+				if err = budget.apply(p); err != nil {
+					return err
+				}
+			}
+
+			if clientNameOrID != nil {
+				p.ClientID = clientID
+			}
+
+			if parentNameOrID != nil {
+				if p, err = ctx.StoreHelper.MoveProject(p, parentProjectID); err != nil {
+					return err
+				}
+			}
+
+			if _, err = tx.UpdateProject(*p); err != nil {
 				return err
 			}
 		}
-		projects = append(projects, project)
-	}
+		return nil
+	})
+}
 
-	for _, p := range projects {
-		if len(newName) > 0 {
-			p.Name = newName
-		}
+// budgetUpdate contains the modified values of a project budget. Undefined values are kept, empty values are removed.
+type budgetUpdate struct {
+	hours  *string
+	money  *string
+	period *string
+}
 
-		if hourlyRate != nil {
-			p.SetHourlyRate(parsedHourlyRate)
-		}
+func (u *budgetUpdate) apply(p *model.Project) error {
+	budget := model.Budget{}
+	if current := p.Budget(); current != nil {
+		budget = *current
+	}
 
-		if noteRequired != nil {
-			p.SetNoteRequired(noteRequired.ToBool())
+	if u.hours != nil {
+		if *u.hours == "" {
+			budget.Hours = 0
+		} else {
+			hours, err := strconv.ParseFloat(*u.hours, 64)
+			if err != nil {
+				return fmt.Errorf("unable to parse budget hours %s", *u.hours)
+			}
+			budget.Hours = hours
 		}
+	}
 
-		if parentNameOrID != nil {
-			if p, err = ctx.StoreHelper.MoveProject(p, parentProjectID); err != nil {
-				return err
+	if u.money != nil {
+		if *u.money == "" {
+			budget.Money = nil
+		} else {
+			value, err := money.Parse(*u.money)
+			if err != nil {
+				return fmt.Errorf("unable to parse budget money %s", *u.money)
 			}
+			budget.Money = value
 		}
+	}
 
-		if _, err = ctx.Store.UpdateProject(*p); err != nil {
+	if u.period != nil {
+		period, err := model.BudgetPeriodByName(*u.period)
+		if err != nil {
 			return err
 		}
+		budget.Period = period
 	}
 
+	if err := budget.Validate(); err != nil {
+		return err
+	}
+	p.SetBudget(&budget)
 	return nil
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"golang.org/x/text/language"
 
+	"github.com/jansorg/tom/go-tom/model"
 	"github.com/jansorg/tom/go-tom/money"
 	"github.com/jansorg/tom/go-tom/test_setup"
 	"github.com/jansorg/tom/go-tom/util"
@@ -28,7 +29,7 @@ func Test_EditProject(t *testing.T) {
 	require.NoError(t, err)
 
 	parentName := newParent.GetFullName("/")
-	err = doEditProjectCommand("my new project name", &parentName, "/", util.StringP("10.50 USD"), nil, []string{p1.ID, p2.ID}, ctx)
+	err = doEditProjectCommand("my new project name", &parentName, "/", util.StringP("10.50 USD"), nil, nil, nil, nil, []string{p1.ID, p2.ID}, ctx)
 	require.NoError(t, err)
 
 	p, err := ctx.Query.ProjectByFullName([]string{"parent", "child 1"})
@@ -59,7 +60,7 @@ func Test_EditProjectMoveToTop(t *testing.T) {
 	require.NoError(t, err)
 
 	emptyID := ""
-	err = doEditProjectCommand("", &emptyID, "/", nil, nil, []string{p1.ID}, ctx)
+	err = doEditProjectCommand("", &emptyID, "/", nil, nil, nil, nil, nil, []string{p1.ID}, ctx)
 	require.NoError(t, err)
 
 	p, err := ctx.Query.ProjectByFullName([]string{"child 1"})
@@ -83,10 +84,10 @@ func Test_EditProjectMoveToOwnChild(t *testing.T) {
 	child, _, err := ctx.StoreHelper.GetOrCreateNestedProjectNames("parent", "child 1")
 	require.NoError(t, err)
 
-	err = doEditProjectCommand("", &child.ID, "/", nil, nil, []string{top.ID}, ctx)
+	err = doEditProjectCommand("", &child.ID, "/", nil, nil, nil, nil, nil, []string{top.ID}, ctx)
 	require.Error(t, err, "moving a project into it's own child scope must fail")
 
-	err = doEditProjectCommand("", &top.ID, "/", nil, nil, []string{top.ID}, ctx)
+	err = doEditProjectCommand("", &top.ID, "/", nil, nil, nil, nil, nil, []string{top.ID}, ctx)
 	require.Error(t, err, "making a project its own child must fail")
 }
 
@@ -98,15 +99,18 @@ func Test_EditProjectHourlyRate(t *testing.T) {
 	top, _, err := ctx.StoreHelper.GetOrCreateNestedProjectNames("parent")
 	require.NoError(t, err)
 
-	err = doEditProjectCommand("", &top.ID, "/", util.StringP("100.50 EUR"), nil, []string{top.ID}, ctx)
+	err = doEditProjectCommand("", nil, "/", util.StringP("100.50 EUR"), nil, nil, nil, nil, []string{top.ID}, ctx)
+	require.NoError(t, err)
 	top, _, _ = ctx.StoreHelper.GetOrCreateNestedProjectNames("parent")
 	assert.EqualValues(t, money.NewMoney(10050, "EUR"), top.HourlyRate())
 
-	err = doEditProjectCommand("", &top.ID, "/", util.StringP("10.75 USD"), nil, []string{top.ID}, ctx)
+	err = doEditProjectCommand("", nil, "/", util.StringP("10.75 USD"), nil, nil, nil, nil, []string{top.ID}, ctx)
+	require.NoError(t, err)
 	top, _, _ = ctx.StoreHelper.GetOrCreateNestedProjectNames("parent")
 	assert.EqualValues(t, money.NewMoney(1075, "USD"), top.HourlyRate())
 
-	err = doEditProjectCommand("", &top.ID, "/", util.StringP(""), nil, []string{top.ID}, ctx)
+	err = doEditProjectCommand("", nil, "/", util.StringP(""), nil, nil, nil, nil, []string{top.ID}, ctx)
+	require.NoError(t, err)
 	top, _, _ = ctx.StoreHelper.GetOrCreateNestedProjectNames("parent")
 	assert.Nil(t, top.HourlyRate())
 }
@@ -119,21 +123,121 @@ func Test_EditProjectNoteRequired(t *testing.T) {
 	top, _, err := ctx.StoreHelper.GetOrCreateNestedProjectNames("parent")
 	require.NoError(t, err)
 
-	err = doEditProjectCommand("", &top.ID, "/", nil, tristate.TrueP(), []string{top.ID}, ctx)
+	err = doEditProjectCommand("", nil, "/", nil, tristate.TrueP(), nil, nil, nil, []string{top.ID}, ctx)
+	require.NoError(t, err)
 	top, _, _ = ctx.StoreHelper.GetOrCreateNestedProjectNames("parent")
 	assert.EqualValues(t, util.TrueP(), top.IsNoteRequired())
 
-	err = doEditProjectCommand("", &top.ID, "/", nil, tristate.FalseP(), []string{top.ID}, ctx)
+	err = doEditProjectCommand("", nil, "/", nil, tristate.FalseP(), nil, nil, nil, []string{top.ID}, ctx)
+	require.NoError(t, err)
 	top, _, _ = ctx.StoreHelper.GetOrCreateNestedProjectNames("parent")
 	assert.EqualValues(t, util.FalseP(), top.IsNoteRequired())
 
 	// passing nil must not modify the settings
-	err = doEditProjectCommand("", &top.ID, "/", nil, nil, []string{top.ID}, ctx)
+	err = doEditProjectCommand("", nil, "/", nil, nil, nil, nil, nil, []string{top.ID}, ctx)
+	require.NoError(t, err)
 	top, _, _ = ctx.StoreHelper.GetOrCreateNestedProjectNames("parent")
 	assert.EqualValues(t, util.FalseP(), top.IsNoteRequired())
 
 	// passing "inherited" must modify the value to nil
-	err = doEditProjectCommand("", &top.ID, "/", nil, tristate.InheritedP(), []string{top.ID}, ctx)
+	err = doEditProjectCommand("", nil, "/", nil, tristate.InheritedP(), nil, nil, nil, []string{top.ID}, ctx)
+	require.NoError(t, err)
 	top, _, _ = ctx.StoreHelper.GetOrCreateNestedProjectNames("parent")
 	assert.Nil(t, top.IsNoteRequired())
 }
+
+func Test_EditProjectBillable(t *testing.T) {
+	ctx, err := test_setup.CreateTestContext(language.English)
+	require.NoError(t, err)
+	defer test_setup.CleanupTestContext(ctx)
+
+	top, _, err := ctx.StoreHelper.GetOrCreateNestedProjectNames("parent")
+	require.NoError(t, err)
+	child, _, err := ctx.StoreHelper.GetOrCreateNestedProjectNames("parent", "child")
+	require.NoError(t, err)
+
+	// the subproject inherits the flag of its parent
+	err = doEditProjectCommand("", nil, "/", nil, nil, tristate.FalseP(), nil, nil, []string{top.ID}, ctx)
+	require.NoError(t, err)
+	billable, err := ctx.Query.IsBillable(child.ID)
+	require.NoError(t, err)
+	assert.False(t, *billable)
+
+	err = doEditProjectCommand("", nil, "/", nil, nil, tristate.TrueP(), nil, nil, []string{child.ID}, ctx)
+	require.NoError(t, err)
+	billable, err = ctx.Query.IsBillable(child.ID)
+	require.NoError(t, err)
+	assert.True(t, *billable)
+
+	err = doEditProjectCommand("", nil, "/", nil, nil, tristate.InheritedP(), nil, nil, []string{top.ID, child.ID}, ctx)
+	require.NoError(t, err)
+	_, err = ctx.Query.IsBillable(child.ID)
+	require.Error(t, err)
+	top, _, _ = ctx.StoreHelper.GetOrCreateNestedProjectNames("parent")
+	assert.Nil(t, top.Properties)
+}
+
+func Test_EditProjectBudget(t *testing.T) {
+	ctx, err := test_setup.CreateTestContext(language.English)
+	require.NoError(t, err)
+	defer test_setup.CleanupTestContext(ctx)
+
+	top, _, err := ctx.StoreHelper.GetOrCreateNestedProjectNames("parent")
+	require.NoError(t, err)
+
+	err = doEditProjectCommand("", nil, "/", nil, nil, nil, &budgetUpdate{hours: util.StringP("40"), money: util.StringP("5000 EUR")}, nil, []string{top.ID}, ctx)
+	require.NoError(t, err)
+	top, _, _ = ctx.StoreHelper.GetOrCreateNestedProjectNames("parent")
+	assert.EqualValues(t, &model.Budget{Hours: 40, Money: money.NewMoney(500000, "EUR")}, top.Budget())
+
+	// undefined values must be kept
+	err = doEditProjectCommand("", nil, "/", nil, nil, nil, &budgetUpdate{period: util.StringP("monthly")}, nil, []string{top.ID}, ctx)
+	require.NoError(t, err)
+	top, _, _ = ctx.StoreHelper.GetOrCreateNestedProjectNames("parent")
+	assert.EqualValues(t, &model.Budget{Hours: 40, Money: money.NewMoney(500000, "EUR"), Period: model.BudgetMonthly}, top.Budget())
+
+	err = doEditProjectCommand("", nil, "/", nil, nil, nil, &budgetUpdate{period: util.StringP("weekly")}, nil, []string{top.ID}, ctx)
+	require.Error(t, err, "unknown periods must be rejected")
+	err = doEditProjectCommand("", nil, "/", nil, nil, nil, &budgetUpdate{hours: util.StringP("-1")}, nil, []string{top.ID}, ctx)
+	require.Error(t, err, "negative hours must be rejected")
+
+	// removing all values removes the budget
+	err = doEditProjectCommand("", nil, "/", nil, nil, nil, &budgetUpdate{hours: util.StringP(""), money: util.StringP("")}, nil, []string{top.ID}, ctx)
+	require.NoError(t, err)
+	top, _, _ = ctx.StoreHelper.GetOrCreateNestedProjectNames("parent")
+	assert.Nil(t, top.Budget())
+	assert.Nil(t, top.Properties)
+}
+
+func Test_EditProjectClient(t *testing.T) {
+	ctx, err := test_setup.CreateTestContext(language.English)
+	require.NoError(t, err)
+	defer test_setup.CleanupTestContext(ctx)
+
+	acme, err := ctx.Store.AddClient(model.Client{Name: "ACME", HourlyRate: money.NewMoney(8500, "EUR")})
+	require.NoError(t, err)
+	top, _, err := ctx.StoreHelper.GetOrCreateNestedProjectNames("parent")
+	require.NoError(t, err)
+	child, _, err := ctx.StoreHelper.GetOrCreateNestedProjectNames("parent", "child")
+	require.NoError(t, err)
+
+	err = doEditProjectCommand("", nil, "/", nil, nil, nil, nil, util.StringP("ACME"), []string{top.ID}, ctx)
+	require.NoError(t, err)
+
+	// the subproject inherits the client and its hourly rate
+	client, err := ctx.Query.ProjectClient(child.ID)
+	require.NoError(t, err)
+	assert.EqualValues(t, acme.ID, client.ID)
+	rate, err := ctx.Query.HourlyRate(child.ID)
+	require.NoError(t, err)
+	assert.EqualValues(t, money.NewMoney(8500, "EUR"), rate)
+
+	err = doEditProjectCommand("", nil, "/", nil, nil, nil, nil, util.StringP("unknown"), []string{top.ID}, ctx)
+	require.Error(t, err)
+
+	// an empty value removes the client
+	err = doEditProjectCommand("", nil, "/", nil, nil, nil, nil, util.StringP(""), []string{top.ID}, ctx)
+	require.NoError(t, err)
+	_, err = ctx.Query.ProjectClient(child.ID)
+	require.Error(t, err)
+}
@@ -0,0 +1,108 @@
+package edit
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jansorg/tom/go-tom/context"
+	"github.com/jansorg/tom/go-tom/model"
+	"github.com/jansorg/tom/go-tom/util"
+)
+
+func newEditTagCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
+	var name string
+	var parentNameOrID string
+	var color string
+	var description string
+
+	var cmd = &cobra.Command{
+		Use:   "tag fullName | ID",
+		Short: "edit properties of a tag",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if cmd.Flag("name").Changed && len(name) == 0 {
+				util.Fatal("unable to use empty tag name")
+			} else if !cmd.Flag("name").Changed && !cmd.Flag("parent").Changed && !cmd.Flag("color").Changed && !cmd.Flag("description").Changed {
+				util.Fatalf("no modification defined, use --name, --parent, --color or --description to update tag data")
+			}
+
+			var parent, colorValue, descriptionValue *string
+			if cmd.Flag("parent").Changed {
+				parent = &parentNameOrID
+			}
+			if cmd.Flag("color").Changed {
+				colorValue = &color
+			}
+			if cmd.Flag("description").Changed {
+				descriptionValue = &description
+			}
+
+			if err := doEditTagCommand(name, parent, colorValue, descriptionValue, args, ctx); err != nil {
+				util.Fatal(err)
+			} else {
+				println("Successfully updated tag data")
+			}
+		},
+	}
+
+	cmd.Flags().StringVarP(&name, "name", "n", "", "update the tag name")
+	cmd.Flags().StringVarP(&parentNameOrID, "parent", "p", "", "update the parent tag. Use an empty value to make it a top-level tag. Frames filtered by a tag also match its subtags.")
+	cmd.Flags().StringVarP(&color, "color", "", "", "update the color of the tag, e.g. #ff8800 or a CSS color name. Use an empty value to remove it.")
+	cmd.Flags().StringVarP(&description, "description", "", "", "update the description of the tag")
+
+	parent.AddCommand(cmd)
+	return cmd
+}
+
+func doEditTagCommand(newName string, parentNameOrID *string, color *string, description *string, tagNamesOrIDs []string, ctx *context.TomContext) error {
+	var parentTagID string
+
+	// a non-nil, but empty parentNameOrID points to the top-level
+	if parentNameOrID != nil && *parentNameOrID != "" {
+		if parent, err := ctx.Query.TagByNameOrID(*parentNameOrID); err != nil {
+			return fmt.Errorf("parent tag %s not found", *parentNameOrID)
+		} else {
+			parentTagID = parent.ID
+		}
+	}
+
+	// a single transaction to handle many tags at once
+	return ctx.Store.Update(func(tx model.Store) error {
+		var tags []*model.Tag
+		for _, nameOrID := range tagNamesOrIDs {
+			tag, err := ctx.Query.TagByNameOrID(nameOrID)
+			if err != nil {
+				return err
+			}
+			tags = append(tags, tag)
+		}
+
+		for _, t := range tags {
+			updated := *t
+			if len(newName) > 0 {
+				updated.Name = newName
+			}
+
+			if color != nil {
+				updated.Color = *color
+			}
+
+			if description != nil {
+				updated.Description = *description
+			}
+
+			if parentNameOrID != nil {
+				if parentTagID == t.ID || util.MapStrings(ctx.Query.CollectSubtagIDs(t.ID))[parentTagID] {
+					return fmt.Errorf("unable to move tag %s below itself", ctx.Query.TagFullName(t))
+				}
+				updated.ParentID = parentTagID
+			}
+
+			if _, err := tx.UpdateTag(updated); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,50 @@
+package edit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+
+	"github.com/jansorg/tom/go-tom/test_setup"
+	"github.com/jansorg/tom/go-tom/util"
+)
+
+func Test_EditTag(t *testing.T) {
+	ctx, err := test_setup.CreateTestContext(language.English)
+	require.NoError(t, err)
+	defer test_setup.CleanupTestContext(ctx)
+
+	meeting, _, err := ctx.StoreHelper.GetOrCreateNestedTag("meeting")
+	require.NoError(t, err)
+	client, _, err := ctx.StoreHelper.GetOrCreateTag("client")
+	require.NoError(t, err)
+
+	err = doEditTagCommand("", util.StringP("meeting"), util.StringP("#ff8800"), util.StringP("meetings with clients"), []string{"client"}, ctx)
+	require.NoError(t, err)
+
+	tag, err := ctx.Query.TagByName("meeting/client")
+	require.NoError(t, err)
+	assert.EqualValues(t, client.ID, tag.ID)
+	assert.EqualValues(t, meeting.ID, tag.ParentID)
+	assert.EqualValues(t, "#ff8800", tag.Color)
+	assert.EqualValues(t, "meetings with clients", tag.Description)
+
+	// a tag must not be moved below one of its subtags
+	err = doEditTagCommand("", util.StringP("meeting/client"), nil, nil, []string{"meeting"}, ctx)
+	require.Error(t, err)
+
+	err = doEditTagCommand("", nil, util.StringP("red;"), nil, []string{"meeting"}, ctx)
+	require.Error(t, err, "invalid colors must be rejected")
+
+	// an empty parent moves the tag to the top-level
+	err = doEditTagCommand("customer", util.StringP(""), util.StringP(""), nil, []string{client.ID}, ctx)
+	require.NoError(t, err)
+
+	tag, err = ctx.Query.TagByName("customer")
+	require.NoError(t, err)
+	assert.Empty(t, tag.ParentID)
+	assert.Empty(t, tag.Color)
+	assert.EqualValues(t, "meetings with clients", tag.Description)
+}
@@ -6,6 +6,8 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/jansorg/tom/go-tom/context"
+	"github.com/jansorg/tom/go-tom/model"
+	"github.com/jansorg/tom/go-tom/store"
 	"github.com/jansorg/tom/go-tom/util"
 )
 
@@ -13,46 +15,71 @@ func newArchiveCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Co
 	projectIDOrName := ""
 	nameDelimiter := ""
 	includeSubprojects := false
+	moveToCold := false
 
 	var cmd = &cobra.Command{
 		Use:   "archive",
 		Short: "Archive a set of frames",
+		Long: "Archives the frames of a project. With --move-to-cold the archived frames are moved into the compressed cold archive of the data directory. " +
+			"Without --project all archived frames are moved. Frames of the cold archive are only reported with --include-archived, " +
+			"'tom frames cold restore' moves them back.",
 		Run: func(cmd *cobra.Command, args []string) {
-			if err := archiveFrames(projectIDOrName, nameDelimiter, includeSubprojects, ctx); err != nil {
-				util.Fatalf("Error archiving frames: %s", err.Error())
-			} else {
+			if projectIDOrName != "" || !moveToCold {
+				if err := archiveFrames(projectIDOrName, nameDelimiter, includeSubprojects, ctx); err != nil {
+					util.Fatalf("Error archiving frames: %s", err.Error())
+				}
 				fmt.Println("archived project frames")
 			}
+
+			if moveToCold {
+				moved, err := moveToColdArchive(projectIDOrName, nameDelimiter, includeSubprojects, ctx)
+				if err != nil {
+					util.Fatalf("Error moving frames into the cold archive: %s", err.Error())
+				}
+				fmt.Printf("moved %d archived frames into the cold archive\n", moved)
+			}
 		},
 	}
 
 	cmd.Flags().StringVarP(&projectIDOrName, "project", "p", "", "Only frames of this project will be archived")
 	cmd.Flags().StringVarP(&nameDelimiter, "name-delimiter", "", "/", "Delimiter used in the full project name")
 	cmd.Flags().BoolVarP(&includeSubprojects, "include-subprojects", "", includeSubprojects, "Also archive frames of all subprojects")
+	cmd.Flags().BoolVarP(&moveToCold, "move-to-cold", "", moveToCold, "Move the archived frames into the cold archive")
 
 	parent.AddCommand(cmd)
 	return cmd
 }
 
 func archiveFrames(projectIDOrName string, nameDelimiter string, includeSubprojects bool, ctx *context.TomContext) error {
-	ctx.Store.StartBatch()
-	defer ctx.Store.StopBatch()
+	return ctx.Store.Update(func(tx model.Store) error {
+		project, err := ctx.Query.ProjectByFullNameOrID(projectIDOrName, nameDelimiter)
+		if err != nil {
+			return err
+		}
 
-	project, err := ctx.Query.ProjectByFullNameOrID(projectIDOrName, nameDelimiter)
-	if err != nil {
-		return err
-	}
+		frames := ctx.Query.FramesByProject(project.ID, includeSubprojects)
+		frames.ExcludeArchived()
 
-	frames := ctx.Query.FramesByProject(project.ID, includeSubprojects)
-	frames.ExcludeArchived()
+		for _, f := range frames {
+			f.Archived = true
+			_, err := tx.UpdateFrame(*f)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
 
-	for _, f := range frames {
-		f.Archived = true
-		_, err := ctx.Store.UpdateFrame(*f)
+// moveToColdArchive moves the archived frames of the project into the cold archive, all archived frames if projectIDOrName is empty
+func moveToColdArchive(projectIDOrName string, nameDelimiter string, includeSubprojects bool, ctx *context.TomContext) (int, error) {
+	frames := ctx.Store.Frames()
+	if projectIDOrName != "" {
+		project, err := ctx.Query.ProjectByFullNameOrID(projectIDOrName, nameDelimiter)
 		if err != nil {
-			return err
+			return 0, err
 		}
+		frames = ctx.Query.FramesByProject(project.ID, includeSubprojects)
 	}
-
-	return nil
+	return store.MoveToColdArchive(ctx.Store, frames)
 }
@@ -13,6 +13,9 @@ import (
 	"github.com/jansorg/tom/go-tom/util"
 )
 
+// fieldPropPrefix is the prefix of the properties, which print the value of a custom field
+const fieldPropPrefix = "field:"
+
 type frameList model.FrameList
 
 func (f frameList) Size() int {
@@ -60,16 +63,34 @@ func (f frameList) Get(index int, prop string, format string, ctx *context.TomCo
 	case "tagIDs":
 		frame := f[index]
 		return strings.Join(frame.TagIDs, ","), nil;
+	case "tags":
+		return tagNames(ctx, f[index].TagIDs), nil
+	case "fields":
+		return model.FieldsString(f[index].Fields), nil
 	case "archived":
 		frame := f[index]
 		return frame.Archived, nil
+	case "billable":
+		return ctx.Query.IsFrameBillable(f[index]), nil
+	case "breakDuration":
+		frame := f[index]
+		if frame.IsActive() {
+			return frame.BreakDuration(time.Now()), nil
+		}
+		return frame.BreakDuration(*frame.End), nil
 	default:
+		// the value of a custom field, e.g. field:ticket
+		if strings.HasPrefix(prop, fieldPropPrefix) {
+			return f[index].Field(strings.TrimPrefix(prop, fieldPropPrefix)), nil
+		}
 		return "", fmt.Errorf("unknown property %s", prop)
 	}
 }
 
 func NewCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
 	projectIDOrName := ""
+	tagNameOrID := ""
+	var fieldFilters []string
 	includeSubprojects := false
 	showArchived := true
 
@@ -78,6 +99,18 @@ func NewCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
 		Short: "Print a listing of all frames",
 		Run: func(cmd *cobra.Command, args []string) {
 			frames := filterFrames(projectIDOrName, ctx, includeSubprojects, showArchived)
+			if tagNameOrID != "" {
+				frames = filterFramesByTag(frames, tagNameOrID, ctx)
+			}
+			if len(fieldFilters) > 0 {
+				filter, err := model.NewFieldFilter(fieldFilters)
+				if err != nil {
+					util.Fatal(err)
+				}
+				filtered := model.FrameList(frames)
+				filtered.Filter(filter.Matches)
+				frames = frameList(filtered)
+			}
 			if err := cmdUtil.PrintList(cmd, frames, ctx); err != nil {
 				util.Fatal(err)
 			}
@@ -86,10 +119,14 @@ func NewCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
 
 	cmd.Flags().StringVarP(&projectIDOrName, "project", "p", "", "Only frames of this project will be printed. Project IDs or full project names are accepted. Default: no project")
 	cmd.Flags().BoolVarP(&includeSubprojects, "subprojects", "s", false, "Include frames of subprojects")
+	cmd.Flags().StringVarP(&tagNameOrID, "tag", "t", "", "Only frames with this tag or with one of its subtags will be printed. Tag IDs or full tag names are accepted.")
+	cmd.Flags().StringArrayVarP(&fieldFilters, "field", "", nil, "Only frames with this custom field will be printed. name=value matches the value, name matches any value. Use it multiple times to match all of the fields.")
 	cmd.Flags().BoolVarP(&showArchived, "archived", "", showArchived, "Show/Hide archived frames")
-	cmdUtil.AddListOutputFlags(cmd, "id", []string{"id", "projectID", "projectName", "projectFullName", "startTime", "stopTime", "duration", "lastUpdated", "notes", "tagIDs", "archived"})
+	cmdUtil.AddListOutputFlags(cmd, "id", []string{"id", "projectID", "projectName", "projectFullName", "startTime", "stopTime", "duration", "lastUpdated", "notes", "tagIDs", "tags", "fields", "field:<name>", "archived", "billable", "breakDuration"})
 
 	newArchiveCommand(ctx, cmd)
+	newHistoryCommand(ctx, cmd)
+	newColdCommand(ctx, cmd)
 	parent.AddCommand(cmd)
 	return cmd
 }
@@ -115,3 +152,24 @@ func filterFrames(projectIDOrName string, ctx *context.TomContext, includeSubpro
 
 	return frameList(frames)
 }
+
+// filterFramesByTag returns the frames, which have the tag or one of its subtags
+func filterFramesByTag(frames frameList, tagNameOrID string, ctx *context.TomContext) frameList {
+	tag, err := ctx.Query.TagByNameOrID(tagNameOrID)
+	if err != nil {
+		util.Fatal(fmt.Errorf("no tag found for %s", tagNameOrID))
+	}
+
+	tagged := map[string]bool{}
+	for _, f := range ctx.Query.FramesByTag(tag.ID) {
+		tagged[f.ID] = true
+	}
+
+	var result frameList
+	for _, f := range frames {
+		if tagged[f.ID] {
+			result = append(result, f)
+		}
+	}
+	return result
+}
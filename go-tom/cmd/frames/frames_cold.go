@@ -0,0 +1,97 @@
+package frames
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jansorg/tom/go-tom/cmd/cmdUtil"
+	"github.com/jansorg/tom/go-tom/context"
+	"github.com/jansorg/tom/go-tom/store"
+	"github.com/jansorg/tom/go-tom/util"
+)
+
+func newColdCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "cold",
+		Short: "Manages the frames of the cold archive",
+	}
+
+	newColdListCommand(ctx, cmd)
+	newColdRestoreCommand(ctx, cmd)
+	parent.AddCommand(cmd)
+	return cmd
+}
+
+func newColdListCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "list",
+		Short: "Prints the frames of the cold archive",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			frames, err := store.ColdArchiveFrames(ctx.Store, nil, nil)
+			if err != nil {
+				util.Fatal(err)
+			}
+			if err := cmdUtil.PrintList(cmd, frameList(frames), ctx); err != nil {
+				util.Fatal(err)
+			}
+		},
+	}
+
+	cmdUtil.AddListOutputFlags(cmd, "id,projectFullName,startTime,stopTime", []string{"id", "projectID", "projectName", "projectFullName", "startTime", "stopTime", "duration", "lastUpdated", "notes", "tagIDs", "archived"})
+	parent.AddCommand(cmd)
+	return cmd
+}
+
+func newColdRestoreCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
+	all := false
+	projectIDOrName := ""
+	nameDelimiter := ""
+
+	var cmd = &cobra.Command{
+		Use:   "restore [frame id] ...",
+		Short: "Moves frames of the cold archive back into the data. The frames stay archived.",
+		Run: func(cmd *cobra.Command, args []string) {
+			ids := args
+			if all || projectIDOrName != "" {
+				frames, err := store.ColdArchiveFrames(ctx.Store, nil, nil)
+				if err != nil {
+					util.Fatal(err)
+				}
+
+				var projectIDs map[string]bool
+				if projectIDOrName != "" {
+					project, err := ctx.Query.ProjectByFullNameOrID(projectIDOrName, nameDelimiter)
+					if err != nil {
+						util.Fatal(err)
+					}
+					projectIDs = map[string]bool{project.ID: true}
+					for _, id := range ctx.Query.CollectSubprojectIDs(project.ID) {
+						projectIDs[id] = true
+					}
+				}
+
+				for _, f := range frames {
+					if projectIDs == nil || projectIDs[f.ProjectId] {
+						ids = append(ids, f.ID)
+					}
+				}
+			} else if len(ids) == 0 {
+				util.Fatal(fmt.Errorf("no frames to restore. Pass the IDs of frames, --project or --all"))
+			}
+
+			restored, err := store.RestoreFromColdArchive(ctx.Store, ids)
+			if err != nil {
+				util.Fatal(err)
+			}
+			fmt.Printf("restored %d frames from the cold archive\n", restored)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&all, "all", "", all, "Restore all frames of the cold archive")
+	cmd.Flags().StringVarP(&projectIDOrName, "project", "p", "", "Restore the frames of this project and of its subprojects")
+	cmd.Flags().StringVarP(&nameDelimiter, "name-delimiter", "", "/", "Delimiter used in the full project name")
+	parent.AddCommand(cmd)
+	return cmd
+}
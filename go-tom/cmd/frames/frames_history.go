@@ -0,0 +1,171 @@
+package frames
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jansorg/tom/go-tom/cmd/cmdUtil"
+	"github.com/jansorg/tom/go-tom/context"
+	"github.com/jansorg/tom/go-tom/model"
+	"github.com/jansorg/tom/go-tom/util"
+)
+
+// historyEntry is a single changed value of a frame
+type historyEntry struct {
+	changed  time.Time
+	command  string
+	field    string
+	oldValue interface{}
+	newValue interface{}
+}
+
+type historyList []historyEntry
+
+func (h historyList) Size() int {
+	return len(h)
+}
+
+func (h historyList) Get(index int, prop string, format string, ctx *context.TomContext) (interface{}, error) {
+	switch prop {
+	case "changed":
+		return h[index].changed.In(time.Local), nil
+	case "command":
+		return h[index].command, nil
+	case "field":
+		return h[index].field, nil
+	case "old":
+		return h[index].oldValue, nil
+	case "new":
+		return h[index].newValue, nil
+	default:
+		return "", fmt.Errorf("unknown property %s", prop)
+	}
+}
+
+func newHistoryCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "history <frame id>",
+		Short: "Prints the changes of a frame, from oldest to newest. A change of the project, start, end, notes, tags, custom fields, the billable flag or the breaks is recorded with the command which made it.",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			frame, err := ctx.Query.FrameByID(args[0])
+			if err != nil {
+				util.Fatal(fmt.Errorf("frame %s not found", args[0]))
+			}
+
+			if err := cmdUtil.PrintList(cmd, frameHistory(ctx, frame), ctx); err != nil {
+				util.Fatal(err)
+			}
+		},
+	}
+
+	cmdUtil.AddListOutputFlags(cmd, "changed,command,field,old,new", []string{"changed", "command", "field", "old", "new"})
+	parent.AddCommand(cmd)
+	return cmd
+}
+
+// frameHistory returns the creation of the frame and all changed values.
+// Each revision is compared with the following revision or, for the latest revision, with the current values of the frame.
+func frameHistory(ctx *context.TomContext, frame *model.Frame) historyList {
+	var result historyList
+	if frame.Created != nil {
+		result = append(result, historyEntry{changed: *frame.Created, command: frame.CreatedBy, field: "created", oldValue: "", newValue: ""})
+	}
+
+	for i, revision := range frame.History {
+		next := model.NewFrameRevision(frame, time.Time{}, "")
+		if i+1 < len(frame.History) {
+			next = frame.History[i+1]
+		}
+
+		add := func(field string, changed bool, oldValue, newValue interface{}) {
+			if changed {
+				result = append(result, historyEntry{changed: revision.Changed, command: revision.Command, field: field, oldValue: oldValue, newValue: newValue})
+			}
+		}
+		add("project", revision.ProjectId != next.ProjectId, projectName(ctx, revision.ProjectId), projectName(ctx, next.ProjectId))
+		add("start", !sameTime(revision.Start, next.Start), timeValue(revision.Start), timeValue(next.Start))
+		add("end", !sameTime(revision.End, next.End), timeValue(revision.End), timeValue(next.End))
+		add("notes", revision.Notes != next.Notes, revision.Notes, next.Notes)
+		add("tags", strings.Join(revision.TagIDs, ",") != strings.Join(next.TagIDs, ","), tagNames(ctx, revision.TagIDs), tagNames(ctx, next.TagIDs))
+		for _, name := range fieldNames(revision.Fields, next.Fields) {
+			add(fieldPropPrefix+name, revision.Fields[name] != next.Fields[name], revision.Fields[name], next.Fields[name])
+		}
+		add("billable", billableValue(revision.Billable) != billableValue(next.Billable), billableValue(revision.Billable), billableValue(next.Billable))
+		add("breaks", breaksValue(revision.Breaks) != breaksValue(next.Breaks), breaksValue(revision.Breaks), breaksValue(next.Breaks))
+	}
+	return result
+}
+
+func projectName(ctx *context.TomContext, id string) string {
+	if project, err := ctx.Query.ProjectByID(id); err == nil {
+		return project.GetFullName("/")
+	}
+	return id
+}
+
+func sameTime(a, b *time.Time) bool {
+	return a == nil && b == nil || a != nil && b != nil && a.Equal(*b)
+}
+
+func timeValue(t *time.Time) interface{} {
+	if t == nil {
+		return ""
+	}
+	return t.In(time.Local)
+}
+
+// billableValue returns the override of the billable flag, it's empty if the value of the project applies
+func billableValue(billable *bool) string {
+	if billable == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", *billable)
+}
+
+// breaksValue returns the time ranges of the breaks, the end of the current break is empty
+func breaksValue(breaks []model.FrameBreak) string {
+	var ranges []string
+	for _, b := range breaks {
+		ranges = append(ranges, breakTime(b.Start)+"-"+breakTime(b.End))
+	}
+	return strings.Join(ranges, ",")
+}
+
+func breakTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.In(time.Local).Format(time.RFC3339)
+}
+
+func tagNames(ctx *context.TomContext, ids []string) string {
+	var names []string
+	for _, id := range ids {
+		if tag, err := ctx.Query.TagByID(id); err == nil {
+			names = append(names, ctx.Query.TagFullName(tag))
+		} else {
+			names = append(names, id)
+		}
+	}
+	return strings.Join(names, ",")
+}
+
+// fieldNames returns the sorted names of the fields of both maps
+func fieldNames(a, b map[string]string) []string {
+	var names []string
+	for name := range a {
+		names = append(names, name)
+	}
+	for name := range b {
+		if _, ok := a[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
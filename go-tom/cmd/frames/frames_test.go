@@ -60,3 +60,32 @@ func TestShowExcluded(t *testing.T) {
 	frames = filterFrames("", ctx, true, false)
 	assert.EqualValues(t, frames.Size(), 1)
 }
+
+func TestFilterByParentTag(t *testing.T) {
+	ctx, err := test_setup.CreateTestContext(language.English)
+	require.NoError(t, err)
+	defer test_setup.CleanupTestContext(ctx)
+
+	p, _, err := ctx.StoreHelper.GetOrCreateNestedProjectNames("project")
+	require.NoError(t, err)
+
+	meeting, _, err := ctx.StoreHelper.GetOrCreateNestedTag("meeting")
+	require.NoError(t, err)
+	client, _, err := ctx.StoreHelper.GetOrCreateNestedTag("meeting/client")
+	require.NoError(t, err)
+	assert.EqualValues(t, meeting.ID, client.ParentID)
+	other, _, err := ctx.StoreHelper.GetOrCreateNestedTag("other")
+	require.NoError(t, err)
+
+	now := time.Now()
+	end := now.Add(10 * time.Minute)
+	for _, tag := range []*model.Tag{meeting, client, other} {
+		_, err = ctx.Store.AddFrame(model.Frame{Start: &now, End: &end, ProjectId: p.ID, TagIDs: []string{tag.ID}})
+		require.NoError(t, err)
+	}
+
+	frames := filterFrames("", ctx, true, true)
+	assert.EqualValues(t, 2, filterFramesByTag(frames, "meeting", ctx).Size(), "the parent tag must match its subtags")
+	assert.EqualValues(t, 1, filterFramesByTag(frames, "meeting/client", ctx).Size())
+	assert.EqualValues(t, 1, filterFramesByTag(frames, other.ID, ctx).Size())
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jansorg/tom/go-tom/activity"
+	"github.com/jansorg/tom/go-tom/context"
+	"github.com/jansorg/tom/go-tom/util"
+)
+
+func newPauseCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
+	var shiftedPause time.Duration
+
+	var cmd = &cobra.Command{
+		Use:   "pause [--past <duration>]",
+		Short: "pauses the newest active timer until it's resumed. The time of the break isn't tracked.",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			frame, err := activity.NewActivityControl(ctx, false, false, time.Now().Add(-shiftedPause)).PauseNewest()
+			if err != nil {
+				util.Fatal(err)
+			}
+
+			// fixme i18n?
+			if project, err := ctx.Query.ProjectByID(frame.ProjectId); err == nil {
+				fmt.Printf("Paused activity for %s at %s\n", project.GetFullName("/"), ctx.DateTimePrinter.Time(*frame.PausedSince()))
+			}
+		},
+	}
+
+	cmd.Flags().DurationVarP(&shiftedPause, "past", "d", 0, "Pause the activity this duration before now, e.g. `--past 5m` pauses the activity 5m before the current time")
+
+	parent.AddCommand(cmd)
+	return cmd
+}
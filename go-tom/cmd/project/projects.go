@@ -46,6 +46,24 @@ func (o projectList) Get(index int, prop string, format string, ctx *context.Tom
 			return "", nil
 		}
 		return rate.ParsableString(), nil
+	case "billable":
+		billable := o.projects[index].IsBillable()
+		if billable == nil {
+			return "", nil
+		}
+		return *billable, nil
+	case "appliedBillable":
+		billable, err := ctx.Query.IsBillable(o.projects[index].ID)
+		if billable == nil || err != nil {
+			return true, nil
+		}
+		return *billable, nil
+	case "client":
+		client, err := ctx.Query.ProjectClient(o.projects[index].ID)
+		if err != nil {
+			return "", nil
+		}
+		return client.Name, nil
 	case "noteRequired":
 		noteRequired := o.projects[index].IsNoteRequired()
 		if noteRequired == nil {
@@ -92,7 +110,7 @@ func NewCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
 
 	cmd.Flags().IntVarP(&recentProjects, "recent", "", 0, "If set then only the most recently tracked projects will be returned.")
 	cmd.Flags().StringVarP(&nameDelimiter, "name-delimiter", "", "/", "Delimiter used in the full project name")
-	cmdUtil.AddListOutputFlags(cmd, "fullName", []string{"id", "fullName", "name", "parentID", "hourlyRate", "appliedHourlyRate", "noteRequired", "appliedNoteRequired"})
+	cmdUtil.AddListOutputFlags(cmd, "fullName", []string{"id", "fullName", "name", "parentID", "hourlyRate", "appliedHourlyRate", "client", "noteRequired", "appliedNoteRequired", "billable", "appliedBillable"})
 
 	parent.AddCommand(cmd)
 	return cmd
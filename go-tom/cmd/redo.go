@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/jansorg/tom/go-tom/context"
+	"github.com/jansorg/tom/go-tom/store"
+	"github.com/jansorg/tom/go-tom/util"
+)
+
+func newRedoCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "redo",
+		Short: "reapplies the changes which were reverted by the last undo",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			info, changes, err := store.Redo(ctx.Store)
+			if err != nil {
+				util.Fatal(err)
+			}
+			printChanges("Reapplied", info, changes)
+		},
+	}
+
+	parent.AddCommand(cmd)
+	return cmd
+}
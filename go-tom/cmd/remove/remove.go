@@ -1,15 +1,19 @@
 package remove
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
 
+	"github.com/jansorg/tom/go-tom/cmd/cmdUtil"
 	"github.com/jansorg/tom/go-tom/context"
+	"github.com/jansorg/tom/go-tom/store"
 )
 
 func NewCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "remove [all projects | tags | frames] or remove project <project name> or frame ID",
-		Short: "remove projects, tags or frames",
+		Short: "remove projects, tags or frames. The removed data is moved into the trash.",
 	}
 
 	newRemoveProjectCommand(ctx, cmd)
@@ -19,3 +23,18 @@ func NewCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
 	parent.AddCommand(cmd)
 	return cmd
 }
+
+// moveToTrash moves the data of the entry into the trash. If dryRun is true, then the data is only printed.
+func moveToTrash(ctx *context.TomContext, entry store.TrashEntry, dryRun bool) error {
+	if dryRun {
+		for _, change := range entry.Changes(store.ChangeRemoved) {
+			fmt.Println(change.String())
+		}
+		return nil
+	}
+
+	if err := cmdUtil.ExpireTrash(ctx.Store); err != nil {
+		return err
+	}
+	return store.MoveToTrash(ctx.Store, entry)
+}
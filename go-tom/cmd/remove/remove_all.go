@@ -6,11 +6,14 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/jansorg/tom/go-tom/context"
+	"github.com/jansorg/tom/go-tom/model"
+	"github.com/jansorg/tom/go-tom/store"
 	"github.com/jansorg/tom/go-tom/util"
 )
 
 func newRemoveAllCommand(context *context.TomContext, parent *cobra.Command) *cobra.Command {
 	validArgs := []string{"all", "projects", "tags", "frames"}
+	dryRun := false
 
 	var cmd = &cobra.Command{
 		Use:       "all [all | projects | tags | frames]",
@@ -19,17 +22,34 @@ func newRemoveAllCommand(context *context.TomContext, parent *cobra.Command) *co
 		Args:      cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			arg := args[0]
-			projects := arg == "all" || arg == "projects"
-			tags := arg == "all" || arg == "tags"
-			frames := arg == "all" || arg == "frames"
 
-			if removedProjects, removedTags, removedFrames, err := context.Store.Reset(projects, tags, frames); err != nil {
+			var projects []*model.Project
+			var tags []*model.Tag
+			var frames []*model.Frame
+			if arg == "all" || arg == "projects" {
+				projects = context.Store.Projects()
+			}
+			if arg == "all" || arg == "tags" {
+				tags = context.Store.Tags()
+			}
+			if arg == "all" || arg == "frames" {
+				frames = context.Store.Frames()
+			}
+
+			entry := store.NewTrashEntry(context.Store, projects, tags, frames)
+			if err := moveToTrash(context, entry, dryRun); err != nil {
 				util.Fatal(err)
+			}
+
+			if dryRun {
+				fmt.Printf("Would remove %d projects, %d tags and %d frames\n", len(entry.Projects), len(entry.Tags), len(entry.Frames))
 			} else {
-				fmt.Printf("Successfully removed %d projects, %d tags and %d frames\n", removedProjects, removedTags, removedFrames)
+				fmt.Printf("Successfully removed %d projects, %d tags and %d frames\n", len(entry.Projects), len(entry.Tags), len(entry.Frames))
 			}
 		},
 	}
+
+	cmd.Flags().BoolVarP(&dryRun, "dry-run", "", false, "Print what would be removed, but don't modify the data")
 	parent.AddCommand(cmd)
 	return cmd
 }
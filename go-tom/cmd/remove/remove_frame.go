@@ -6,32 +6,45 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/jansorg/tom/go-tom/context"
+	"github.com/jansorg/tom/go-tom/model"
+	"github.com/jansorg/tom/go-tom/store"
+	"github.com/jansorg/tom/go-tom/util"
 )
 
 func newRemoveFrameCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
+	dryRun := false
+
 	var cmd = &cobra.Command{
 		Use:   "frame ID ...",
 		Short: "removes one or more frames, identified by ID",
 		Args:  cobra.MinimumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
-			removed := 0
+			var frames []*model.Frame
 			notFound := 0
-
-			ctx.Store.StartBatch()
-			defer ctx.Store.StopBatch()
-
 			for _, id := range args {
-				if err := ctx.Store.RemoveFrame(id); err != nil {
-					notFound ++
+				if frame, err := ctx.Query.FrameByID(id); err != nil {
+					notFound++
 				} else {
-					removed++
+					frames = append(frames, frame)
 				}
 			}
 
-			fmt.Printf("%d frames removed, %d frames not found.", removed, notFound)
+			entry := store.NewTrashEntry(ctx.Store, nil, nil, frames)
+			if len(frames) > 0 {
+				if err := moveToTrash(ctx, entry, dryRun); err != nil {
+					util.Fatal(err)
+				}
+			}
+
+			if dryRun {
+				fmt.Printf("%d frames would be removed, %d frames not found.", len(frames), notFound)
+			} else {
+				fmt.Printf("%d frames removed, %d frames not found.", len(frames), notFound)
+			}
 		},
 	}
-	parent.AddCommand(cmd)
 
+	cmd.Flags().BoolVarP(&dryRun, "dry-run", "", false, "Print what would be removed, but don't modify the data")
+	parent.AddCommand(cmd)
 	return cmd
 }
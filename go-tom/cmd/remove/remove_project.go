@@ -6,19 +6,24 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/jansorg/tom/go-tom/context"
+	"github.com/jansorg/tom/go-tom/model"
+	"github.com/jansorg/tom/go-tom/store"
 	"github.com/jansorg/tom/go-tom/util"
 )
 
 func newRemoveProjectCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
 	nameDelimiter := ""
+	dryRun := false
 
 	var cmd = &cobra.Command{
 		Use:   "project <project name or project ID> ...",
 		Short: "removes new project and all its associated data, including subprojects and time entries",
 		Args:  cobra.MinimumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
-			if removedProjects, removedFrames, err := doRemoveProjects(ctx, nameDelimiter, args); err != nil {
+			if removedProjects, removedFrames, err := doRemoveProjects(ctx, nameDelimiter, args, dryRun); err != nil {
 				util.Fatal("Error removing projects: %s", err.Error())
+			} else if dryRun {
+				fmt.Printf("Would remove %d projects and %d frames\n", removedProjects, removedFrames)
 			} else {
 				fmt.Printf("Successfully removed %d projects and %d frames\n", removedProjects, removedFrames)
 			}
@@ -26,27 +31,44 @@ func newRemoveProjectCommand(ctx *context.TomContext, parent *cobra.Command) *co
 	}
 
 	cmd.Flags().StringVarP(&nameDelimiter, "name-delimiter", "", "/", "Delimiter used in full project names")
+	cmd.Flags().BoolVarP(&dryRun, "dry-run", "", false, "Print what would be removed, but don't modify the data")
 	parent.AddCommand(cmd)
 	return cmd
 }
 
-func doRemoveProjects(ctx *context.TomContext, nameDelimiter string, idOrNames []string) (removedProjects, removedFrames int, err error) {
-	ctx.Store.StartBatch()
-	defer ctx.Store.StopBatch()
-
+func doRemoveProjects(ctx *context.TomContext, nameDelimiter string, idOrNames []string, dryRun bool) (removedProjects, removedFrames int, err error) {
+	var projects []*model.Project
+	var frames []*model.Frame
+	seen := map[string]bool{}
 	for _, idOrName := range idOrNames {
-		projects, err := ctx.Query.ProjectByFullNameOrID(idOrName, nameDelimiter)
+		project, err := ctx.Query.ProjectByFullNameOrID(idOrName, nameDelimiter)
 		if err != nil {
 			return 0, 0, err
 		}
 
-		projectCount, frameCount, err := ctx.StoreHelper.RemoveProject(projects)
+		projectRemoval, frameRemoval, err := ctx.StoreHelper.ProjectRemoval(project)
 		if err != nil {
 			return 0, 0, err
 		}
 
-		removedProjects += projectCount
-		removedFrames += frameCount
+		// a project may be a subproject of another project of the list
+		for _, p := range projectRemoval {
+			if !seen[p.ID] {
+				seen[p.ID] = true
+				projects = append(projects, p)
+			}
+		}
+		for _, f := range frameRemoval {
+			if !seen[f.ID] {
+				seen[f.ID] = true
+				frames = append(frames, f)
+			}
+		}
+	}
+
+	entry := store.NewTrashEntry(ctx.Store, projects, nil, frames)
+	if err := moveToTrash(ctx, entry, dryRun); err != nil {
+		return 0, 0, err
 	}
-	return
+	return len(projects), len(frames), nil
 }
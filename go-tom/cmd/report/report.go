@@ -16,6 +16,7 @@ import (
 	"github.com/jansorg/tom/go-tom/htmlreport"
 	"github.com/jansorg/tom/go-tom/model"
 	"github.com/jansorg/tom/go-tom/report"
+	"github.com/jansorg/tom/go-tom/store"
 	"github.com/jansorg/tom/go-tom/util"
 )
 
@@ -24,10 +25,12 @@ type flags struct {
 	description       string
 	showEmpty         bool
 	showStopTime      bool
+	markEdited        bool
 	showMatrixTables  bool
 	fromDateString    string
 	toDateString      string
 	projectFilter     []string
+	fieldFilter       []string
 	includeSubproject bool
 	day               int
 	week              int
@@ -44,6 +47,8 @@ type flags struct {
 	archivedFrames    bool
 	showTracked       bool
 	showUnTracked     bool
+	showBudgets       bool
+	showBillable      bool
 	shortTitles       bool
 	projectDelimiter  string
 	customCSSFile     string
@@ -56,6 +61,8 @@ var defaultFlags = flags{
 	showSales:        false,
 	showTracked:      false,
 	showUnTracked:    false,
+	showBudgets:      false,
+	showBillable:     false,
 	shortTitles:      true,
 	projectDelimiter: "→",
 }
@@ -67,6 +74,7 @@ func NewCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
 	var saveConfigFile string
 	var jsonOutput bool
 	var htmlOutputFile string
+	var workspaces []string
 
 	var cmd = &cobra.Command{
 		Use:   "report",
@@ -75,6 +83,12 @@ func NewCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
 			config := htmlreport.DefaultOptions
 			var err error
 
+			if len(workspaces) > 0 {
+				if ctx, err = newWorkspacesContext(ctx, workspaces); err != nil {
+					util.Fatal(err)
+				}
+			}
+
 			if configFile != "" {
 				config, err = loadJsonConfig(ctx, configFile)
 				if err != nil {
@@ -109,7 +123,25 @@ func NewCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
 				config.Report.DateFilterRange = config.Report.DateFilterRange.In(config.Report.TimezoneName.AsTimezone())
 			}
 
-			frameReport := report.NewBucketReport(model.NewSortedFrameList(ctx.Store.Frames()), config.Report, ctx)
+			// only the frames of the date range are read
+			var frames model.FrameList
+			filter := config.Report.DateFilterRange
+			if filter.Empty() {
+				frames = ctx.Store.Frames()
+			} else {
+				frames = ctx.Query.FramesInRange(filter.Start, filter.End)
+			}
+
+			// the cold archive only contains archived frames
+			if config.Report.IncludeArchived {
+				cold, err := store.ColdArchiveFrames(ctx.Store, filter.Start, filter.End)
+				if err != nil {
+					util.Fatal(err)
+				}
+				frames = append(append(model.FrameList{}, frames...), cold...)
+			}
+
+			frameReport := report.NewBucketReport(model.NewSortedFrameList(frames), config.Report, ctx)
 			result := frameReport.Update()
 
 			var data []byte
@@ -139,6 +171,7 @@ func NewCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
 	cmd.Flags().StringVarP(&configFile, "config", "", "", "Path to a json configuration")
 	cmd.Flags().StringVarP(&saveConfigFile, "save-config", "", "", "Path where the options are saved as a template")
 	cmd.Flags().StringVarP(&htmlOutputFile, "output-file", "o", "", "Path where the rendered data will be written")
+	cmd.Flags().StringSliceVarP(&workspaces, "workspace", "", nil, "Reports on the combined data of the given workspaces. The projects of each workspace are grouped in a project named like the workspace.")
 
 	cmd.Flags().StringVarP(&opts.templateName, "template", "", opts.templateName, "Built-in template to use for the report. Use --template-file to use a custom gohtml template file. Built-in templates: default,timelog")
 
@@ -166,25 +199,30 @@ func NewCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
 	cmd.Flags().StringSliceVarP(&opts.projectFilter, "project", "p", []string{}, "ID | NAME . Reports activities only for the given project. You can add other projects by using this option multiple times.")
 	cmd.Flags().BoolVarP(&opts.includeSubproject, "subprojects", "", true, "Automatically add the subprojects of the selected projects.")
 
-	cmd.Flags().StringVarP(&opts.splitModes, "split", "s", "project", "Split the report into groups. Multiple values are possible. Possible values: year,month,week,day,project")
+	cmd.Flags().StringArrayVarP(&opts.fieldFilter, "field", "", []string{}, "name=value | name . Reports only frames with the custom field. A name without value matches any value of the field. You can add other fields by using this option multiple times.")
+
+	cmd.Flags().StringVarP(&opts.splitModes, "split", "s", "project", "Split the report into groups. Multiple values are possible. Possible values: year,month,week,day,project,client,field:<name>")
 
 	cmd.Flags().StringVarP(&opts.roundModeFrames, "round-frames", "", "", "Rounding mode for sums of durations. Default: no rounding. Possible values: up,nearest")
 	cmd.Flags().DurationVarP(&opts.roundFrames, "round-frames-to", "", time.Minute, "Round durations of each frame to the nearest multiple of this duration")
 
 	cmd.Flags().BoolVarP(&opts.showEmpty, "show-empty", "", false, "Show empty groups")
 	cmd.Flags().BoolVarP(&opts.showStopTime, "show-stop-time", "", true, "Show end time in timelog reports")
+	cmd.Flags().BoolVarP(&opts.markEdited, "mark-edited", "", false, "Mark the frames, which were edited after they had been stopped, in timelog reports and count them in JSON reports")
 	cmd.Flags().BoolVarP(&opts.decimalDurations, "decimal", "", false, "Print durations as decimals 1.5h instead of 1:30h")
 	cmd.Flags().BoolVarP(&opts.showSummary, "show-summary", "", defaultFlags.showSummary, "Show a report summary at the top of the report")
 	cmd.Flags().BoolVarP(&opts.showMatrixTables, "matrix-tables", "", defaultFlags.showMatrixTables, "Show matrix tables when applicable instead of a list of tables")
 	cmd.Flags().StringVarP(&opts.title, "title", "", "", "This will be displayed as the reports title when you're using the default templates")
 	cmd.Flags().StringVarP(&opts.description, "description", "", "", "This will be displayed as the reports description when you're using the default templates")
-	cmd.Flags().BoolVarP(&opts.archivedFrames, "include-archived", "", defaultFlags.archivedFrames, "Include archived frames in the reported times")
+	cmd.Flags().BoolVarP(&opts.archivedFrames, "include-archived", "", defaultFlags.archivedFrames, "Include archived frames in the reported times, including the frames of the cold archive")
 	cmd.Flags().BoolVarP(&opts.showSales, "show-sales", "", defaultFlags.showSales, "Show sales summaries")
 	cmd.Flags().BoolVarP(&opts.shortTitles, "short-titles", "", defaultFlags.shortTitles, "Display short project titles inside of project containers. Short titles do not repeat the project name of the parent container.")
 	cmd.Flags().StringVarP(&opts.projectDelimiter, "project-delimiter", "", defaultFlags.projectDelimiter, "The string used to render a full project name. Default: /")
 
 	cmd.Flags().BoolVarP(&opts.showTracked, "show-tracked", "", defaultFlags.showTracked, "Show min/max/avg of daily tracked time")
 	cmd.Flags().BoolVarP(&opts.showUnTracked, "show-untracked", "", defaultFlags.showUnTracked, "Show min/max/avg of daily untracked time, i.e. the untracked time between first and last entries of a day")
+	cmd.Flags().BoolVarP(&opts.showBudgets, "show-budgets", "", defaultFlags.showBudgets, "Show the consumed and the remaining budgets of the reported projects per month")
+	cmd.Flags().BoolVarP(&opts.showBillable, "show-billable", "", defaultFlags.showBillable, "Show the billable and the non-billable durations and the utilization, i.e. the share of billable time")
 
 	parent.AddCommand(cmd)
 	return cmd
@@ -208,6 +246,10 @@ func applyFlags(cmd *cobra.Command, source htmlreport.Options, target *htmlrepor
 	}
 	if cmd.Flag("split").Changed {
 		target.Report.Splitting = source.Report.Splitting
+		target.Report.SplitFields = source.Report.SplitFields
+	}
+	if cmd.Flag("field").Changed {
+		target.Report.Fields = source.Report.Fields
 	}
 	if cmd.Flag("round-frames-to").Changed {
 		target.Report.EntryRounding.Size = source.Report.EntryRounding.Size
@@ -242,6 +284,12 @@ func applyFlags(cmd *cobra.Command, source htmlreport.Options, target *htmlrepor
 	if cmd.Flag("show-untracked").Changed {
 		target.ShowUnTracked = source.ShowUnTracked
 	}
+	if cmd.Flag("show-budgets").Changed {
+		target.ShowBudgets = source.ShowBudgets
+	}
+	if cmd.Flag("show-billable").Changed {
+		target.ShowBillable = source.ShowBillable
+	}
 	if cmd.Flag("short-titles").Changed {
 		target.Report.ShortTitles = source.Report.ShortTitles
 	}
@@ -251,6 +299,9 @@ func applyFlags(cmd *cobra.Command, source htmlreport.Options, target *htmlrepor
 	if cmd.Flag("css-file").Changed {
 		target.CustomCSSFile = source.CustomCSSFile
 	}
+	if cmd.Flag("mark-edited").Changed {
+		target.Report.MarkEdited = source.Report.MarkEdited
+	}
 }
 
 func loadJsonConfig(ctx *context.TomContext, filePath string) (htmlreport.Options, error) {
@@ -305,8 +356,19 @@ func configByFlags(opts flags, cmd *cobra.Command, ctx *context.TomContext) (htm
 	}
 
 	var splitOperations []report.SplitOperation
+	var splitFields []string
 	if opts.splitModes != "" {
 		for _, mode := range strings.Split(opts.splitModes, ",") {
+			// a split by a custom field is defined by field:<name>
+			if strings.HasPrefix(mode, "field:") {
+				name := strings.TrimPrefix(mode, "field:")
+				if name == "" {
+					util.Fatal(fmt.Errorf("missing field name in split operation %s", mode))
+				}
+				splitFields = append(splitFields, name)
+				mode = "field"
+			}
+
 			if op, err := report.SplitOperationByName(mode); err != nil {
 				util.Fatal(err)
 			} else {
@@ -329,6 +391,11 @@ func configByFlags(opts flags, cmd *cobra.Command, ctx *context.TomContext) (htm
 		projectIDs = append(projectIDs, id)
 	}
 
+	fields, err := model.NewFieldFilter(opts.fieldFilter)
+	if err != nil {
+		util.Fatal(err)
+	}
+
 	return htmlreport.Options{
 		TemplateFilePath:  &opts.templateFilePath,
 		TemplateName:      &opts.templateName,
@@ -340,16 +407,21 @@ func configByFlags(opts flags, cmd *cobra.Command, ctx *context.TomContext) (htm
 		ShowSales:         opts.showSales,
 		ShowTracked:       opts.showTracked,
 		ShowUnTracked:     opts.showUnTracked,
+		ShowBudgets:       opts.showBudgets,
+		ShowBillable:      opts.showBillable,
 		CustomCSSFile:     opts.customCSSFile,
 		Report: report.Config{
 			// fixme missing timezone
 			ProjectIDs:         projectIDs,
+			Fields:             fields,
 			IncludeSubprojects: opts.includeSubproject,
 			IncludeArchived:    opts.archivedFrames,
 			DateFilterRange:    filterRange,
 			Splitting:          splitOperations,
+			SplitFields:        splitFields,
 			ShowEmpty:          opts.showEmpty,
 			ShowStopTime:       opts.showStopTime,
+			MarkEdited:         opts.markEdited,
 			ShortTitles:        opts.shortTitles,
 			ProjectDelimiter:   opts.projectDelimiter,
 			EntryRounding: dateTime.RoundingConfig{
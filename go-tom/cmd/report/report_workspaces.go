@@ -0,0 +1,73 @@
+package report
+
+import (
+	"fmt"
+
+	"github.com/jansorg/tom/go-tom/cmd/cmdUtil"
+	"github.com/jansorg/tom/go-tom/context"
+	"github.com/jansorg/tom/go-tom/model"
+	"github.com/jansorg/tom/go-tom/query"
+	"github.com/jansorg/tom/go-tom/store"
+	"github.com/jansorg/tom/go-tom/storeHelper"
+)
+
+// newWorkspacesContext returns a copy of ctx, which contains the data of the given workspaces.
+// The top-level projects of each workspace are moved into a new project, which is named like the workspace.
+func newWorkspacesContext(ctx *context.TomContext, names []string) (*context.TomContext, error) {
+	var projects []*model.Project
+	var tags []*model.Tag
+	var clients []*model.Client
+	var frames []*model.Frame
+
+	seen := map[string]bool{}
+	for _, name := range names {
+		if seen[name] {
+			return nil, fmt.Errorf("workspace %s is listed more than once", name)
+		}
+		seen[name] = true
+
+		w, err := ctx.Workspaces.Find(name)
+		if err != nil {
+			return nil, err
+		}
+
+		source := ctx.Store
+		if w.Name != ctx.Workspaces.ActiveWorkspace().Name {
+			options, err := cmdUtil.WorkspaceStoreOptions(ctx.Workspaces, w)
+			if err != nil {
+				return nil, err
+			}
+			if source, err = store.Open(options); err != nil {
+				return nil, fmt.Errorf("unable to open workspace %s: %s", w.Name, err.Error())
+			}
+		}
+
+		root := &model.Project{ID: model.NextID(), Name: w.Name}
+		projects = append(projects, root)
+		for _, p := range source.Projects() {
+			project := *p
+			if project.ParentID == "" {
+				project.ParentID = root.ID
+			}
+			projects = append(projects, &project)
+		}
+		tags = append(tags, source.Tags()...)
+		clients = append(clients, source.Clients()...)
+		frames = append(frames, source.Frames()...)
+
+		if source != ctx.Store {
+			store.CloseStore(source)
+		}
+	}
+
+	combined := store.NewMemoryStore()
+	if err := store.ReplaceWithClients(combined, projects, tags, clients, frames); err != nil {
+		return nil, err
+	}
+
+	result := *ctx
+	result.Store = combined
+	result.StoreHelper = storeHelper.NewStoreHelper(combined)
+	result.Query = query.NewStoreQuery(combined)
+	return &result, nil
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jansorg/tom/go-tom/activity"
+	"github.com/jansorg/tom/go-tom/context"
+	"github.com/jansorg/tom/go-tom/util"
+)
+
+func newResumeCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
+	var shiftedResume time.Duration
+
+	var cmd = &cobra.Command{
+		Use:   "resume [--past <duration>]",
+		Short: "resumes the newest paused timer",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			resumeTime := time.Now().Add(-shiftedResume)
+			frame, err := activity.NewActivityControl(ctx, false, false, resumeTime).ResumeNewest()
+			if err != nil {
+				util.Fatal(err)
+			}
+
+			// fixme i18n?
+			if project, err := ctx.Query.ProjectByID(frame.ProjectId); err == nil {
+				lastBreak := frame.Breaks[len(frame.Breaks)-1]
+				fmt.Printf("Resumed activity for %s at %s after a break of %s\n", project.GetFullName("/"), ctx.DateTimePrinter.Time(resumeTime),
+					ctx.DurationPrinter.Minimal(lastBreak.End.Sub(*lastBreak.Start), true))
+			}
+		},
+	}
+
+	cmd.Flags().DurationVarP(&shiftedResume, "past", "d", 0, "Resume the activity this duration before now, e.g. `--past 5m` resumes the activity 5m before the current time")
+
+	parent.AddCommand(cmd)
+	return cmd
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
 	"runtime"
 	"runtime/pprof"
 
@@ -11,6 +12,9 @@ import (
 	"github.com/spf13/viper"
 	"golang.org/x/text/message"
 
+	"github.com/jansorg/tom/go-tom/cmd/backup"
+	"github.com/jansorg/tom/go-tom/cmd/balance"
+	"github.com/jansorg/tom/go-tom/cmd/cmdUtil"
 	_config "github.com/jansorg/tom/go-tom/cmd/config"
 	"github.com/jansorg/tom/go-tom/cmd/edit"
 	"github.com/jansorg/tom/go-tom/cmd/frames"
@@ -19,13 +23,18 @@ import (
 	"github.com/jansorg/tom/go-tom/cmd/remove"
 	"github.com/jansorg/tom/go-tom/cmd/report"
 	"github.com/jansorg/tom/go-tom/cmd/status"
+	_store "github.com/jansorg/tom/go-tom/cmd/store"
+	"github.com/jansorg/tom/go-tom/cmd/trash"
+	_workspace "github.com/jansorg/tom/go-tom/cmd/workspace"
 	"github.com/jansorg/tom/go-tom/config"
 	"github.com/jansorg/tom/go-tom/context"
+	"github.com/jansorg/tom/go-tom/hooks"
 	"github.com/jansorg/tom/go-tom/i18n"
 	"github.com/jansorg/tom/go-tom/query"
 	"github.com/jansorg/tom/go-tom/store"
 	"github.com/jansorg/tom/go-tom/storeHelper"
 	"github.com/jansorg/tom/go-tom/util"
+	"github.com/jansorg/tom/go-tom/workspace"
 )
 
 var ctx context.TomContext
@@ -35,6 +44,7 @@ func init() {
 	cobra.OnInitialize(initConfig)
 
 	RootCmd.PersistentFlags().String("data-dir", "", "data directory (default is $HOME/.tom)")
+	RootCmd.PersistentFlags().String("data-format", "", "format of the data directory, json or sqlite (default is json)")
 	RootCmd.PersistentFlags().String("backup-dir", "", "backup directory (default is $HOME/.tom/backup)")
 	RootCmd.PersistentFlags().StringVarP(&configFile, "config", "c", "", "config file (default is $HOME/.tom/tom.yaml)")
 	RootCmd.PersistentFlags().Bool("iso-dates", false, "use ISO date format instead of a locale-specific format (default is false)")
@@ -46,24 +56,38 @@ func init() {
 
 	project.NewCommand(&ctx, RootCmd)
 	newTagsCommand(&ctx, RootCmd)
+	newClientsCommand(&ctx, RootCmd)
 	frames.NewCommand(&ctx, RootCmd)
 	newCreateCommand(&ctx, RootCmd)
 	remove.NewCommand(&ctx, RootCmd)
 	newRenameCommand(&ctx, RootCmd)
 	newStartCommand(&ctx, RootCmd)
 	newStopCommand(&ctx, RootCmd)
+	newPauseCommand(&ctx, RootCmd)
+	newResumeCommand(&ctx, RootCmd)
 	newCancelCommand(&ctx, RootCmd)
 	edit.NewEditCommand(&ctx, RootCmd)
 	report.NewCommand(&ctx, RootCmd)
 	imports.NewCommand(&ctx, RootCmd)
 	status.NewCommand(&ctx, RootCmd)
 	_config.NewCommand(&ctx, RootCmd)
+	_store.NewCommand(&ctx, RootCmd)
+	newUndoCommand(&ctx, RootCmd)
+	newRedoCommand(&ctx, RootCmd)
+	backup.NewCommand(&ctx, RootCmd)
+	newCheckCommand(&ctx, RootCmd)
+	_workspace.NewCommand(&ctx, RootCmd)
+	trash.NewCommand(&ctx, RootCmd)
+	balance.NewCommand(&ctx, RootCmd)
 	// hidden command
 	newCompletionCommand(&ctx, RootCmd)
 
 	if err := viper.BindPFlag(config.KeyDataDir, RootCmd.PersistentFlags().Lookup("data-dir")); err != nil {
 		util.Fatal(err)
 	}
+	if err := viper.BindPFlag(config.KeyDataFormat, RootCmd.PersistentFlags().Lookup("data-format")); err != nil {
+		util.Fatal(err)
+	}
 	if err := viper.BindPFlag(config.KeyBackupDir, RootCmd.PersistentFlags().Lookup("backup-dir")); err != nil {
 		util.Fatal(err)
 	}
@@ -78,6 +102,19 @@ func initConfig() {
 		viper.SetConfigFile(configFile)
 	}
 
+	_ = viper.ReadInConfig()
+
+	// the active workspace overrides the data directories of the configuration
+	workspaces, err := workspace.Load(filepath.Join(config.Dir(), workspace.RegistryFilename))
+	if err != nil {
+		util.Fatal(err)
+	}
+	if active := workspaces.ActiveWorkspace(); !active.IsDefault() {
+		if err := config.UseWorkspace(active.DataDir, active.BackupDir); err != nil {
+			util.Fatal(err)
+		}
+	}
+
 	// setup config dir if it doesn't exist
 	dataDir := viper.GetString(config.KeyDataDir)
 	if _, err := os.Stat(dataDir); os.IsNotExist(err) {
@@ -88,18 +125,17 @@ func initConfig() {
 
 	// setup backup dir if it doesn't exist
 	backupDir := viper.GetString(config.KeyBackupDir)
-	if _, err := os.Stat(dataDir); os.IsNotExist(err) {
-		if err := os.MkdirAll(dataDir, 0700); err != nil {
+	if _, err := os.Stat(backupDir); backupDir != "" && os.IsNotExist(err) {
+		if err := os.MkdirAll(backupDir, 0700); err != nil {
 			util.Fatal(err)
 		}
 	}
 
-	_ = viper.ReadInConfig()
-
-	dataStore, err := store.NewStore(dataDir, backupDir, viper.GetInt(config.KeyMaxBackups))
+	dataStore, err := store.Open(cmdUtil.StoreOptions())
 	if err != nil {
 		util.Fatal(err)
 	}
+	hooks.New(dataStore, cmdUtil.HookConfig()).Subscribe()
 
 	isoDates := viper.GetBool(config.KeyIsoDates)
 
@@ -112,6 +148,7 @@ func initConfig() {
 	ctx.DurationPrinter = i18n.NewDurationPrinter(ctx.Language)
 	ctx.DecimalDurationPrinter = i18n.NewDecimalDurationPrinter(ctx.Language)
 	ctx.DateTimePrinter = i18n.NewDateTimePrinter(ctx.Language)
+	ctx.Workspaces = workspaces
 }
 
 const (
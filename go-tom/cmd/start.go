@@ -12,11 +12,14 @@ import (
 	"github.com/jansorg/tom/go-tom/config"
 	"github.com/jansorg/tom/go-tom/context"
 	"github.com/jansorg/tom/go-tom/model"
+	"github.com/jansorg/tom/go-tom/report"
 	"github.com/jansorg/tom/go-tom/util"
 )
 
 func newStartCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
 	var notes string
+	var fields []string
+	var nonBillable bool
 
 	var cmd = &cobra.Command{
 		Use:     "start <project> [time shift into past] [+tag1 +tag2]",
@@ -44,6 +47,11 @@ func newStartCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Comm
 				args = args[1:]
 			}
 
+			fieldValues, err := model.ParseFields(fields)
+			if err != nil {
+				util.Fatal(err)
+			}
+
 			control := activity.NewActivityControl(ctx, createMissingProject, false, time.Now().Add(shiftedStart))
 
 			tags, err := argsToTags(ctx, args)
@@ -57,7 +65,12 @@ func newStartCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Comm
 				stoppedFrames, _ = control.StopAll("", nil)
 			}
 
-			frame, err := control.Start(projectName, "", tags)
+			var billable *bool
+			if nonBillable {
+				billable = util.FalseP()
+			}
+
+			frame, err := control.WithFields(fieldValues).WithBillable(billable).Start(projectName, "", tags)
 			if err == activity.ProjectNotFoundErr {
 				util.Fatal(fmt.Errorf("project %s not found. Use --create-missing to create missing projects on-the-fly", projectName))
 			} else if err != nil {
@@ -73,6 +86,11 @@ func newStartCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Comm
 				// fixme i18n?
 				fmt.Printf("Started new activity for %s at %v. Tags: %s\n", project.GetFullName("/"), ctx.DateTimePrinter.Time(*frame.Start), args)
 			}
+
+			if budget, err := report.NewBudgetStatus(ctx, frame.ProjectId, time.Now()); err == nil && budget.IsExceeded() {
+				// fixme i18n?
+				fmt.Printf("Warning: the budget of project %s is exceeded. Used: %.1f%% of %s\n", budget.Project.GetFullName("/"), budget.UsedPercentage(), budget.Budget.String())
+			}
 		},
 	}
 
@@ -87,6 +105,8 @@ func newStartCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Comm
 	}
 
 	cmd.Flags().StringVarP(&notes, "notes", "", "", "Optional notes for the new time frame")
+	cmd.Flags().StringArrayVarP(&fields, "field", "", nil, "Optional custom field of the new time frame, e.g. --field ticket=PROJ-42. Use it multiple times to set more fields.")
+	cmd.Flags().BoolVarP(&nonBillable, "non-billable", "", false, "Marks the new time frame as non-billable, regardless of the billable flag of the project")
 
 	parent.AddCommand(cmd)
 	return cmd
@@ -21,12 +21,12 @@ func NewCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
 		Use:   "status",
 		Short: "Displays when the current project was started and the time spent...",
 		Run: func(cmd *cobra.Command, args []string) {
-			activeFrames := ctx.Query.ActiveFrames
+			activeFrames := ctx.Query.ActiveFrames()
 
 			if cmd.Flag("format").Changed {
 				flags := strings.Split(format, ",")
 
-				for _, frame := range activeFrames() {
+				for _, frame := range activeFrames {
 					project, err := ctx.Query.ProjectByID(frame.ProjectId)
 					if err != nil {
 						util.Fatal(err)
@@ -48,6 +48,12 @@ func NewCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
 							value = project.ParentID
 						case "startTime":
 							value = frame.Start.Format(time.RFC3339)
+						case "pausedSince":
+							if since := frame.PausedSince(); since != nil {
+								value = since.Format(time.RFC3339)
+							}
+						case "workspace":
+							value = ctx.Workspaces.ActiveWorkspace().Name
 						default:
 							util.Fatal(fmt.Errorf("unknown flag %s", flag))
 						}
@@ -60,27 +66,37 @@ func NewCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
 				projectCount := len(ctx.Store.Projects())
 				tagCount := len(ctx.Store.Tags())
 				frameCount := len(ctx.Store.Frames())
-				activeFrameCount := len(activeFrames())
+				activeFrameCount := len(activeFrames)
 
+				fmt.Printf("Workspace: %s\n", ctx.Workspaces.ActiveWorkspace().Name)
 				fmt.Printf("Projects: %d\nTags: %d\nFrames: %d\nStarted activites: %d\n", projectCount, tagCount, frameCount, activeFrameCount)
 			} else {
-				for _, frame := range activeFrames() {
+				// the default workspace isn't printed to keep the output of setups without workspaces
+				if active := ctx.Workspaces.ActiveWorkspace(); !active.IsDefault() {
+					fmt.Printf("Workspace: %s\n", active.Name)
+				}
+
+				for _, frame := range activeFrames {
 					project, err := ctx.Query.ProjectByID(frame.ProjectId)
 					if err != nil {
 						util.Fatal(err)
 					}
 
-					fmt.Printf("Project %s was started %s\n", project.FullName, ctx.DateTimePrinter.DateTime(*frame.Start))
+					if since := frame.PausedSince(); since != nil {
+						fmt.Printf("Project %s was started %s, paused since %s\n", project.FullName, ctx.DateTimePrinter.DateTime(*frame.Start), ctx.DateTimePrinter.DateTime(*since))
+					} else {
+						fmt.Printf("Project %s was started %s\n", project.FullName, ctx.DateTimePrinter.DateTime(*frame.Start))
+					}
 				}
 
-				if len(activeFrames()) == 0 {
-					fmt.Printf("%d active frames found\n", len(activeFrames()))
+				if len(activeFrames) == 0 {
+					fmt.Printf("%d active frames found\n", len(activeFrames))
 				}
 			}
 		},
 	}
 
-	cmd.Flags().StringVarP(&format, "format", "f", "", "Properties to print for each active frame. Possible values: id,projectID,projectName,projectFullName,projectParentID,startTime")
+	cmd.Flags().StringVarP(&format, "format", "f", "", "Properties to print for each active frame. Possible values: id,projectID,projectName,projectFullName,projectParentID,startTime,pausedSince,workspace")
 	cmd.Flags().StringVarP(&delimiter, "delimiter", "d", "\t", "Delimiter to separate flags on the same line. Only used when --format is specified.")
 	cmd.Flags().StringVarP(&nameDelimiter, "name-delimiter", "", "/", "Delimiter used in the full project name")
 	cmd.Flags().BoolVarP(&verbose, "verbose", "v", verbose, "Print details about the currently stored projects, tags and frames")
@@ -17,6 +17,8 @@ import (
 type projectStatusList struct {
 	nameDelimiter string
 	reports       []*report.ProjectSummary
+	// budgets maps project IDs to the status of the budget, which applies to the project
+	budgets map[string]*report.BudgetStatus
 }
 
 func (o projectStatusList) Size() int {
@@ -26,6 +28,10 @@ func (o projectStatusList) Size() int {
 func (o projectStatusList) Get(index int, prop string, format string, ctx *context.TomContext) (interface{}, error) {
 	summary := o.reports[index]
 
+	if strings.HasPrefix(prop, "budget") {
+		return o.budgetValue(summary, prop)
+	}
+
 	switch prop {
 	case "id":
 		return summary.Project.ID, nil
@@ -64,6 +70,40 @@ func (o projectStatusList) Get(index int, prop string, format string, ctx *conte
 	}
 }
 
+func (o projectStatusList) budgetValue(summary *report.ProjectSummary, prop string) (interface{}, error) {
+	budget, ok := o.budgets[summary.Project.ID]
+	if !ok {
+		switch prop {
+		case "budget", "budgetTracked", "budgetRemaining", "budgetSales", "budgetRemainingMoney", "budgetUsed":
+			return "", nil
+		}
+		return "", fmt.Errorf("unknown property %s", prop)
+	}
+
+	switch prop {
+	case "budget":
+		return budget.Budget.String(), nil
+	case "budgetTracked":
+		return budget.Tracked, nil
+	case "budgetRemaining":
+		return budget.RemainingDuration(), nil
+	case "budgetSales":
+		if budget.Sales == nil {
+			return "", nil
+		}
+		return budget.Sales.ParsableString(), nil
+	case "budgetRemainingMoney":
+		if remaining := budget.RemainingMoney(); remaining != nil {
+			return remaining.ParsableString(), nil
+		}
+		return "", nil
+	case "budgetUsed":
+		return fmt.Sprintf("%.1f%%", budget.UsedPercentage()), nil
+	default:
+		return "", fmt.Errorf("unknown property %s", prop)
+	}
+}
+
 func newProjectsStatusCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
 	showEmpty := false
 	includeActiveFrames := false
@@ -85,14 +125,18 @@ func newProjectsStatusCommand(ctx *context.TomContext, parent *cobra.Command) *c
 			projectReports := report.CreateProjectReports(refTime, showEmpty, includeArchivedFrames, refEnd, "ALL", ctx)
 
 			var reportList []*report.ProjectSummary
+			budgets := map[string]*report.BudgetStatus{}
 			for _, v := range projectReports {
 				reportList = append(reportList, v)
+				if status, err := report.NewBudgetStatus(ctx, v.Project.ID, refTime); err == nil {
+					budgets[v.Project.ID] = status
+				}
 			}
 			sort.Slice(reportList, func(i, j int) bool {
 				return strings.Compare(reportList[i].Project.GetFullName("/"), reportList[j].Project.GetFullName("/")) < 0
 			})
 
-			if err := cmdUtil.PrintList(cmd, projectStatusList{reports: reportList, nameDelimiter: nameDelimiter}, ctx); err != nil {
+			if err := cmdUtil.PrintList(cmd, projectStatusList{reports: reportList, budgets: budgets, nameDelimiter: nameDelimiter}, ctx); err != nil {
 				util.Fatal(err)
 			}
 		},
@@ -107,7 +151,8 @@ func newProjectsStatusCommand(ctx *context.TomContext, parent *cobra.Command) *c
 	cmdUtil.AddListOutputFlags(cmd, "fullName,trackedDay,trackedWeek,trackedMonth", []string{
 		"id", "fullName", "name", "parentID",
 		"trackedDay", "trackedYesterday", "trackedWeek", "trackedMonth", "trackedYear", "trackedAll",
-		"totalTrackedDay", "totalTrackedYesterday", "totalTrackedWeek", "totalTrackedMonth", "totalTrackedYear", "totalTrackedAll"})
+		"totalTrackedDay", "totalTrackedYesterday", "totalTrackedWeek", "totalTrackedMonth", "totalTrackedYear", "totalTrackedAll",
+		"budget", "budgetTracked", "budgetRemaining", "budgetSales", "budgetRemainingMoney", "budgetUsed"})
 	parent.AddCommand(cmd)
 	return cmd
 }
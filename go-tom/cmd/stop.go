@@ -15,6 +15,7 @@ import (
 func newStopCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
 	all := false
 	notes := ""
+	var fields []string
 	// var tags []string
 	var shiftedStop time.Duration
 
@@ -22,7 +23,12 @@ func newStopCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Comma
 		Use:   "stop [--past <duration>] [--all] [--notes \"override notes\"]",
 		Short: "stops the newest active timer. If --all is specified, then all active timers are stopped.",
 		Run: func(cmd *cobra.Command, args []string) {
-			a := activity.NewActivityControl(ctx, false, false, time.Now().Add(shiftedStop))
+			fieldValues, err := model.ParseFields(fields)
+			if err != nil {
+				util.Fatal(err)
+			}
+
+			a := activity.NewActivityControl(ctx, false, false, time.Now().Add(shiftedStop)).WithFields(fieldValues)
 
 			tags, err := argsToTags(ctx, args)
 			if err != nil {
@@ -52,6 +58,7 @@ func newStopCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Comma
 
 	cmd.Flags().BoolVarP(&all, "all", "a", false, "Stops all running activities, not just the newest")
 	cmd.Flags().StringVarP(&notes, "notes", "n", "", "Optional notes to set for all stopped activities")
+	cmd.Flags().StringArrayVarP(&fields, "field", "", nil, "Optional custom field to set for all stopped activities, e.g. --field ticket=PROJ-42. An empty value removes the field.")
 	// cmd.Flags().StringSliceVarP(&tags, "tags", "t", []string{}, "Optional tags to add to all stopped activities")
 	cmd.Flags().DurationVarP(&shiftedStop, "past", "d", 0, "Stop the activity this duration before now, e.g. `--past 5m` stops the activity 5m before the current time")
 
@@ -0,0 +1,24 @@
+package store
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/jansorg/tom/go-tom/context"
+)
+
+func NewCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "store",
+		Short: "manage the data store",
+	}
+
+	newMigrateCommand(ctx, cmd)
+	newCompactCommand(ctx, cmd)
+	newVersionCommand(ctx, cmd)
+	newEncryptCommand(ctx, cmd)
+	newDecryptCommand(ctx, cmd)
+	newMergeCommand(ctx, cmd)
+
+	parent.AddCommand(cmd)
+	return cmd
+}
@@ -0,0 +1,33 @@
+package store
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jansorg/tom/go-tom/context"
+	"github.com/jansorg/tom/go-tom/store"
+	"github.com/jansorg/tom/go-tom/util"
+)
+
+func newCompactCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "compact",
+		Short: "writes the changes recorded in the journal into the data files and removes the journal",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			dataStore, ok := ctx.Store.(*store.DataStore)
+			if !ok {
+				util.Fatal(fmt.Errorf("compaction is only supported by the json data format"))
+			}
+
+			if err := dataStore.Compact(); err != nil {
+				util.Fatal(err)
+			}
+			fmt.Println("Successfully compacted the journal")
+		},
+	}
+
+	parent.AddCommand(cmd)
+	return cmd
+}
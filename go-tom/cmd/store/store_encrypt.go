@@ -0,0 +1,54 @@
+package store
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/jansorg/tom/go-tom/cmd/cmdUtil"
+	"github.com/jansorg/tom/go-tom/config"
+	"github.com/jansorg/tom/go-tom/context"
+	"github.com/jansorg/tom/go-tom/store"
+	"github.com/jansorg/tom/go-tom/util"
+)
+
+func newEncryptCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "encrypt",
+		Short: "encrypts the data files and the backups with a key derived from the configured passphrase",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			passphrase := cmdUtil.Passphrase()
+			if passphrase == "" {
+				util.Fatal(fmt.Errorf("no passphrase found. Set the environment variable %s or configure a key file with %s",
+					viper.GetString(config.KeyEncryptionPassphraseEnv), config.KeyEncryptionKeyFile))
+			}
+
+			if err := store.Encrypt(ctx.Store, passphrase); err != nil {
+				util.Fatal(err)
+			}
+			fmt.Println("Successfully encrypted the data directory")
+		},
+	}
+
+	parent.AddCommand(cmd)
+	return cmd
+}
+
+func newDecryptCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "decrypt",
+		Short: "decrypts the data files and the backups",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := store.Decrypt(ctx.Store); err != nil {
+				util.Fatal(err)
+			}
+			fmt.Println("Successfully decrypted the data directory")
+		},
+	}
+
+	parent.AddCommand(cmd)
+	return cmd
+}
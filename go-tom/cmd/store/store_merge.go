@@ -0,0 +1,213 @@
+package store
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jansorg/tom/go-tom/cmd/cmdUtil"
+	"github.com/jansorg/tom/go-tom/context"
+	"github.com/jansorg/tom/go-tom/model"
+	"github.com/jansorg/tom/go-tom/store"
+	"github.com/jansorg/tom/go-tom/util"
+)
+
+const resolveAsk = "ask"
+
+type mergeOutput struct {
+	Base      string                `json:"base,omitempty"`
+	Changes   []store.Change        `json:"changes"`
+	Conflicts []store.MergeConflict `json:"conflicts"`
+}
+
+func newMergeCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
+	baseName := ""
+	noBase := false
+	otherBackupDir := ""
+	resolve := ""
+	dryRun := false
+	output := ""
+
+	var cmd = &cobra.Command{
+		Use:   "merge <other-dir>",
+		Short: "merges the projects, tags and frames of another data directory into the data directory. The exit code is 1 if conflicts weren't resolved.",
+		Long: "Merges another copy of the data directory, e.g. a conflicting copy created by a file-sync tool. Projects, tags and frames are matched by ID. " +
+			"The newest backup, which both data directories had before they diverged, is used as common ancestor to tell apart changes and deletions. " +
+			"Backups found in both backup directories, which were created after the data diverged, e.g. in a synced backup directory, are skipped. " +
+			"Frames changed on both sides are resolved by their update timestamps. Projects and tags added on both sides with the same full name are merged into one. " +
+			"The remaining conflicts are reported and the data is left unmodified, unless they're resolved with --resolve. " +
+			"A backup is created before the data is modified, the merge can be reverted by undo.",
+		Example: "tom store merge ~/Sync/tom --resolve ask",
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			otherDir := args[0]
+			if otherBackupDir == "" {
+				otherBackupDir = filepath.Join(otherDir, "backup")
+			}
+
+			result, base, err := doMerge(ctx, otherDir, otherBackupDir, baseName, noBase, resolve)
+			if err != nil {
+				util.Fatal(err)
+			}
+
+			apply := !dryRun && len(result.Conflicts) == 0
+			if apply {
+				if err := store.ApplyMerge(ctx.Store, result); err != nil {
+					util.Fatal(err)
+				}
+			}
+
+			switch output {
+			case "json":
+				data := mergeOutput{Base: base.Name(), Changes: result.Changes, Conflicts: result.Conflicts}
+				if base.Dir == "" {
+					data.Base = ""
+				}
+				if data.Changes == nil {
+					data.Changes = []store.Change{}
+				}
+				if data.Conflicts == nil {
+					data.Conflicts = []store.MergeConflict{}
+				}
+				cmdUtil.PrintJSON(data)
+			case "plain":
+				if base.Dir != "" {
+					fmt.Printf("Common backup: %s\n", base.Name())
+				}
+				for _, conflict := range result.Conflicts {
+					fmt.Printf("Conflict: %s\n", conflict.String())
+				}
+				for _, change := range result.Changes {
+					fmt.Printf("  %s\n", change.String())
+				}
+
+				switch {
+				case len(result.Conflicts) > 0:
+					fmt.Printf("Found %d conflicts, the data wasn't modified. Use --resolve to resolve them.\n", len(result.Conflicts))
+				case len(result.Changes) == 0:
+					fmt.Println("The data is up to date")
+				case apply:
+					fmt.Printf("Successfully merged %d changes\n", len(result.Changes))
+				default:
+					fmt.Printf("Found %d changes, the data wasn't modified\n", len(result.Changes))
+				}
+			default:
+				util.Fatal(fmt.Errorf("unsupported output type %s", output))
+			}
+
+			if len(result.Conflicts) > 0 {
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().StringVarP(&baseName, "base", "", "", "The common ancestor of both data directories, the name or label of a backup or a directory. Default: the newest backup, which both data directories had before they diverged")
+	cmd.Flags().BoolVarP(&noBase, "no-base", "", false, "Merge without a common ancestor. Nothing is removed by the merge.")
+	cmd.Flags().StringVarP(&otherBackupDir, "other-backup-dir", "", "", "The backup directory of the other data directory. Default: the subdirectory backup of the other data directory")
+	cmd.Flags().StringVarP(&resolve, "resolve", "", "", "Resolves the conflicts. Supported: local | other | ask. local keeps the local values, other takes the values of the other directory and ask prompts for every conflict.")
+	cmd.Flags().BoolVarP(&dryRun, "dry-run", "", false, "Print the changes, but don't modify the data")
+	cmd.Flags().StringVarP(&output, "output", "o", "plain", "Output format. Supported: plain | json. Default: plain")
+
+	parent.AddCommand(cmd)
+	return cmd
+}
+
+func doMerge(ctx *context.TomContext, otherDir, otherBackupDir, baseName string, noBase bool, resolve string) (*store.MergeResult, store.BackupInfo, error) {
+	if sameDir(otherDir, ctx.Store.DirPath()) {
+		return nil, store.BackupInfo{}, fmt.Errorf("%s is the data directory", otherDir)
+	}
+
+	var resolver store.MergeResolver
+	switch resolve {
+	case "":
+	case store.ResolveLocal, store.ResolveOther:
+		resolver = func(conflict store.MergeConflict) (string, error) {
+			return resolve, nil
+		}
+	case resolveAsk:
+		resolver = askResolver(bufio.NewReader(os.Stdin))
+	default:
+		return nil, store.BackupInfo{}, fmt.Errorf("unsupported resolution %s. Supported: local | other | ask", resolve)
+	}
+
+	// the other directory is opened like a backup, it's only read
+	other, err := store.OpenBackup(ctx.Store, otherDir)
+	if err != nil {
+		return nil, store.BackupInfo{}, err
+	}
+	defer store.CloseStore(other)
+
+	var base model.Store
+	var baseInfo store.BackupInfo
+	if !noBase {
+		switch {
+		case baseName != "" && isDir(baseName):
+			baseInfo = store.BackupInfo{Dir: baseName}
+		case baseName != "":
+			if baseInfo, err = store.FindBackup(ctx.Store, baseName); err != nil {
+				return nil, store.BackupInfo{}, err
+			}
+		default:
+			if baseInfo, err = store.FindMergeBase(ctx.Store, other, otherBackupDir); err == store.ErrNoMergeBase {
+				return nil, store.BackupInfo{}, fmt.Errorf("%s. Use --base to define the common ancestor or --no-base to merge without one", err.Error())
+			} else if err != nil {
+				return nil, store.BackupInfo{}, err
+			}
+		}
+
+		if base, err = store.OpenBackup(ctx.Store, baseInfo.Dir); err != nil {
+			return nil, store.BackupInfo{}, err
+		}
+		defer store.CloseStore(base)
+	}
+
+	result, err := store.Merge(ctx.Store, other, base, resolver)
+	return result, baseInfo, err
+}
+
+// askResolver prompts for the resolution of each conflict
+func askResolver(reader *bufio.Reader) store.MergeResolver {
+	return func(conflict store.MergeConflict) (string, error) {
+		fmt.Printf("Conflict: %s\n", conflict.String())
+		for _, side := range []struct {
+			name  string
+			value interface{}
+		}{{"local", conflict.Local}, {"other", conflict.Other}} {
+			data, err := json.Marshal(side.value)
+			if err != nil {
+				return "", err
+			}
+			fmt.Printf("  %s: %s\n", side.name, string(data))
+		}
+
+		for {
+			fmt.Print("Keep the (l)ocal or the (o)ther value? ")
+			answer, err := reader.ReadString('\n')
+			switch strings.ToLower(strings.TrimSpace(answer)) {
+			case "l", store.ResolveLocal:
+				return store.ResolveLocal, nil
+			case "o", store.ResolveOther:
+				return store.ResolveOther, nil
+			}
+			if err != nil {
+				return "", fmt.Errorf("no resolution for the conflict of %s %s", conflict.Entity, conflict.ID)
+			}
+		}
+	}
+}
+
+func sameDir(a, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	return errA == nil && errB == nil && filepath.Clean(absA) == filepath.Clean(absB)
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
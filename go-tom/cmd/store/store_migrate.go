@@ -0,0 +1,66 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jansorg/tom/go-tom/cmd/cmdUtil"
+	"github.com/jansorg/tom/go-tom/config"
+	"github.com/jansorg/tom/go-tom/context"
+	"github.com/jansorg/tom/go-tom/model"
+	"github.com/jansorg/tom/go-tom/store"
+	"github.com/jansorg/tom/go-tom/util"
+)
+
+func newMigrateCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
+	targetFormat := ""
+
+	var cmd = &cobra.Command{
+		Use:     "migrate --to json | sqlite",
+		Short:   "converts the data directory into another data format and makes it the configured format",
+		Long:    "Converts all projects, tags and frames into another data format. The files of the previous format are kept in the data directory. The configuration value of data_format is updated to the new format.",
+		Example: "tom store migrate --to sqlite",
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			options := cmdUtil.StoreOptions()
+			sourceFormat := options.Format
+
+			options.Format = targetFormat
+			target, err := doMigrate(ctx, sourceFormat, options)
+			if err != nil {
+				util.Fatal(err)
+			}
+
+			if err := config.WriteValue(config.KeyDataFormat, targetFormat); err != nil {
+				util.Fatal("error updating configuration file: ", err)
+			}
+
+			fmt.Printf("Successfully migrated %d projects, %d tags, %d clients and %d frames from %s to %s\n",
+				len(target.Projects()), len(target.Tags()), len(target.Clients()), len(target.Frames()), sourceFormat, targetFormat)
+		},
+	}
+
+	cmd.Flags().StringVarP(&targetFormat, "to", "", "", fmt.Sprintf("The new data format. Supported: %s", strings.Join(store.Formats, ", ")))
+	_ = cmd.MarkFlagRequired("to")
+
+	parent.AddCommand(cmd)
+	return cmd
+}
+
+func doMigrate(ctx *context.TomContext, sourceFormat string, target store.Options) (model.Store, error) {
+	if sourceFormat == target.Format {
+		return nil, fmt.Errorf("the data is already stored as %s", target.Format)
+	}
+
+	targetStore, err := store.Open(target)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := store.Migrate(ctx.Store, targetStore); err != nil {
+		return nil, err
+	}
+	return targetStore, nil
+}
@@ -0,0 +1,40 @@
+package store
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jansorg/tom/go-tom/context"
+	"github.com/jansorg/tom/go-tom/store"
+	"github.com/jansorg/tom/go-tom/util"
+)
+
+func newVersionCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
+	showMigrations := false
+
+	var cmd = &cobra.Command{
+		Use:   "version",
+		Short: "prints the version of the data format",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			version, err := store.Version(ctx.Store)
+			if err != nil {
+				util.Fatal(err)
+			}
+
+			fmt.Printf("Data format version: %d\n", version)
+			fmt.Printf("Latest supported version: %d\n", store.FormatVersion)
+			if showMigrations {
+				fmt.Println("Migrations:")
+				for _, m := range store.Migrations() {
+					fmt.Printf("  %d: %s\n", m.Version, m.Description)
+				}
+			}
+		},
+	}
+
+	cmd.Flags().BoolVarP(&showMigrations, "migrations", "m", false, "Print the migrations of the data format")
+	parent.AddCommand(cmd)
+	return cmd
+}
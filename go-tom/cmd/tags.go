@@ -13,32 +13,57 @@ import (
 	"github.com/jansorg/tom/go-tom/util"
 )
 
-type tagList []*model.Tag
+type tagEntry struct {
+	tag      *model.Tag
+	fullName string
+	// depth is the level of the tag in the tree, it's only set when the tree is printed
+	depth int
+}
+
+type tagList []tagEntry
 
 func (o tagList) Size() int {
 	return len(o)
 }
 
 func (t tagList) Get(index int, prop string, format string, ctx *context.TomContext) (interface{}, error) {
+	entry := t[index]
 	switch prop {
 	case "id":
-		return t[index].ID, nil
+		return entry.tag.ID, nil
 	case "name":
-		return t[index].Name, nil
+		return strings.Repeat("  ", entry.depth) + entry.tag.Name, nil
+	case "fullName":
+		return entry.fullName, nil
+	case "parentID":
+		return entry.tag.ParentID, nil
+	case "color":
+		return entry.tag.Color, nil
+	case "description":
+		return entry.tag.Description, nil
 	default:
 		return "", fmt.Errorf("unknown property %s", prop)
 	}
 }
 
 func newTagsCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
+	tree := false
+
 	var cmd = &cobra.Command{
 		Use:   "tags",
 		Short: "Prints tags",
 		Run: func(cmd *cobra.Command, args []string) {
-			var tags tagList = ctx.Store.Tags()
-			sort.SliceStable(tags, func(i, j int) bool {
-				return strings.Compare(tags[i].Name, tags[j].Name) < 0
-			})
+			var tags tagList
+			if tree {
+				tags = tagTree(ctx, ctx.Store.Tags())
+			} else {
+				for _, t := range ctx.Store.Tags() {
+					tags = append(tags, tagEntry{tag: t, fullName: ctx.Query.TagFullName(t)})
+				}
+				sort.SliceStable(tags, func(i, j int) bool {
+					return strings.Compare(tags[i].fullName, tags[j].fullName) < 0
+				})
+			}
 
 			if err := cmdUtil.PrintList(cmd, tags, ctx); err != nil {
 				util.Fatal(err)
@@ -46,7 +71,46 @@ func newTagsCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Comma
 		},
 	}
 
-	cmdUtil.AddListOutputFlags(cmd, "name", []string{"id", "name"})
+	cmd.Flags().BoolVarP(&tree, "tree", "", tree, "Print the tags as a tree, the names of subtags are indented below their parent")
+	cmdUtil.AddListOutputFlags(cmd, "name", []string{"id", "name", "fullName", "parentID", "color", "description"})
 	parent.AddCommand(cmd)
 	return cmd
 }
+
+// tagTree returns the tags in depth-first order, the children of a tag are sorted by name.
+// Tags with an unknown parent are printed at the top level.
+func tagTree(ctx *context.TomContext, tags []*model.Tag) tagList {
+	ids := map[string]bool{}
+	for _, t := range tags {
+		ids[t.ID] = true
+	}
+
+	children := map[string][]*model.Tag{}
+	for _, t := range tags {
+		parentID := t.ParentID
+		if !ids[parentID] {
+			parentID = ""
+		}
+		children[parentID] = append(children[parentID], t)
+	}
+
+	var result tagList
+	visited := map[string]bool{}
+	var add func(parentID string, depth int)
+	add = func(parentID string, depth int) {
+		current := children[parentID]
+		sort.SliceStable(current, func(i, j int) bool {
+			return strings.Compare(current[i].Name, current[j].Name) < 0
+		})
+		for _, t := range current {
+			if visited[t.ID] {
+				continue
+			}
+			visited[t.ID] = true
+			result = append(result, tagEntry{tag: t, fullName: ctx.Query.TagFullName(t), depth: depth})
+			add(t.ID, depth+1)
+		}
+	}
+	add("", 0)
+	return result
+}
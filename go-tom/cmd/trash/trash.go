@@ -0,0 +1,23 @@
+package trash
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/jansorg/tom/go-tom/context"
+)
+
+func NewCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "trash",
+		Short: "manage the projects, tags and frames removed by tom remove",
+		Long: "The projects, tags and frames removed by tom remove are moved into the trash. " +
+			"They're removed permanently after the number of days configured by trash.expiry_days, a value of 0 keeps them forever.",
+	}
+
+	newListCommand(ctx, cmd)
+	newRestoreCommand(ctx, cmd)
+	newEmptyCommand(ctx, cmd)
+
+	parent.AddCommand(cmd)
+	return cmd
+}
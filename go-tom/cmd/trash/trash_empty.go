@@ -0,0 +1,49 @@
+package trash
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jansorg/tom/go-tom/context"
+	"github.com/jansorg/tom/go-tom/store"
+	"github.com/jansorg/tom/go-tom/util"
+)
+
+func newEmptyCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
+	dryRun := false
+
+	var cmd = &cobra.Command{
+		Use:   "empty",
+		Short: "removes the data in the trash permanently",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if dryRun {
+				entries, err := store.ListTrash(ctx.Store)
+				if err != nil {
+					util.Fatal(err)
+				}
+
+				count := 0
+				for _, entry := range entries {
+					for _, change := range entry.Changes(store.ChangeRemoved) {
+						fmt.Println(change.String())
+						count++
+					}
+				}
+				fmt.Printf("Would remove %d projects, tags and frames permanently\n", count)
+				return
+			}
+
+			removed, err := store.EmptyTrash(ctx.Store)
+			if err != nil {
+				util.Fatal(err)
+			}
+			fmt.Printf("Successfully removed %d entries of the trash\n", removed)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&dryRun, "dry-run", "", false, "Print what would be removed, but don't modify the data")
+	parent.AddCommand(cmd)
+	return cmd
+}
@@ -0,0 +1,73 @@
+package trash
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jansorg/tom/go-tom/cmd/cmdUtil"
+	"github.com/jansorg/tom/go-tom/context"
+	"github.com/jansorg/tom/go-tom/store"
+	"github.com/jansorg/tom/go-tom/util"
+)
+
+type trashItem struct {
+	entry  store.TrashEntry
+	change store.Change
+}
+
+type trashList []trashItem
+
+func (o trashList) Size() int {
+	return len(o)
+}
+
+func (o trashList) Get(index int, prop string, format string, ctx *context.TomContext) (interface{}, error) {
+	switch prop {
+	case "entry":
+		return o[index].entry.ID, nil
+	case "removed":
+		return o[index].entry.Removed, nil
+	case "type":
+		return o[index].change.Entity, nil
+	case "id":
+		return o[index].change.ID, nil
+	case "name":
+		return o[index].change.Name, nil
+	default:
+		return "", fmt.Errorf("unknown property %s", prop)
+	}
+}
+
+func newListCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "list",
+		Short: "prints the projects, tags and frames in the trash, from oldest to newest. The name of a frame is the name of its project.",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := cmdUtil.ExpireTrash(ctx.Store); err != nil {
+				util.Fatal(err)
+			}
+
+			entries, err := store.ListTrash(ctx.Store)
+			if err != nil {
+				util.Fatal(err)
+			}
+
+			var list trashList
+			for _, entry := range entries {
+				for _, change := range entry.Changes(store.ChangeRemoved) {
+					list = append(list, trashItem{entry: entry, change: change})
+				}
+			}
+
+			if err := cmdUtil.PrintList(cmd, list, ctx); err != nil {
+				util.Fatal(err)
+			}
+		},
+	}
+
+	cmdUtil.AddListOutputFlags(cmd, "entry,removed,type,id,name", []string{"entry", "removed", "type", "id", "name"})
+	parent.AddCommand(cmd)
+	return cmd
+}
@@ -0,0 +1,36 @@
+package trash
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jansorg/tom/go-tom/context"
+	"github.com/jansorg/tom/go-tom/store"
+	"github.com/jansorg/tom/go-tom/util"
+)
+
+func newRestoreCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "restore <entry or ID> ...",
+		Short: "restores entries of the trash or single projects, tags and frames of the trash",
+		Long: "Restores all data of the given entries of the trash or the projects, tags and frames with the given IDs. " +
+			"The parent projects of a restored project and the project and the tags of a restored frame are restored as well. " +
+			"A restored tag is added to the frames it was assigned to. A backup is created before the data is modified.",
+		Args: cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			changes, err := store.RestoreFromTrash(ctx.Store, args)
+			if err != nil {
+				util.Fatal(err)
+			}
+
+			for _, change := range changes {
+				fmt.Printf("  %s\n", change.String())
+			}
+			fmt.Printf("Successfully restored %d projects, tags and frames\n", len(changes))
+		},
+	}
+
+	parent.AddCommand(cmd)
+	return cmd
+}
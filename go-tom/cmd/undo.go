@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jansorg/tom/go-tom/context"
+	"github.com/jansorg/tom/go-tom/store"
+	"github.com/jansorg/tom/go-tom/util"
+)
+
+func newUndoCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "undo",
+		Short: "reverts the changes of the last command which modified the data. The reverted changes can be reapplied by redo.",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			info, changes, err := store.Undo(ctx.Store)
+			if err != nil {
+				util.Fatal(err)
+			}
+			printChanges("Reverted", info, changes)
+		},
+	}
+
+	parent.AddCommand(cmd)
+	return cmd
+}
+
+func printChanges(action string, info store.BackupInfo, changes []store.Change) {
+	if info.Command != "" {
+		fmt.Printf("%s \"%s\"\n", action, info.Command)
+	} else {
+		fmt.Printf("%s the changes of %s\n", action, info.Created.Format("2006-01-02 15:04:05"))
+	}
+
+	for _, change := range changes {
+		fmt.Printf("  %s\n", change.String())
+	}
+}
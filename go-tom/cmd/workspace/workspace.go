@@ -0,0 +1,25 @@
+package workspace
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/jansorg/tom/go-tom/context"
+)
+
+func NewCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "workspace",
+		Short: "manage workspaces, each with its own data directory, backup directory and configuration",
+		Long: "Workspaces separate the tracked time, e.g. of personal and of client work. Each workspace has its own data directory, backup directory " +
+			"and an optional configuration file tom.yaml in its data directory, which overrides the values of the main configuration. " +
+			"The workspace named default uses the directories of the main configuration.",
+	}
+
+	newCreateCommand(ctx, cmd)
+	newListCommand(ctx, cmd)
+	newUseCommand(ctx, cmd)
+	newRemoveCommand(ctx, cmd)
+
+	parent.AddCommand(cmd)
+	return cmd
+}
@@ -0,0 +1,43 @@
+package workspace
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jansorg/tom/go-tom/context"
+	"github.com/jansorg/tom/go-tom/util"
+)
+
+func newCreateCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
+	dataDir := ""
+	backupDir := ""
+	use := false
+
+	var cmd = &cobra.Command{
+		Use:     "create <name>",
+		Short:   "creates a new workspace and its directories",
+		Example: "tom workspace create client --data-dir ~/Sync/tom-client",
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			w, err := ctx.Workspaces.Create(args[0], dataDir, backupDir)
+			if err != nil {
+				util.Fatal(err)
+			}
+			fmt.Printf("Successfully created workspace %s in %s\n", w.Name, w.DataDir)
+
+			if use {
+				if err := ctx.Workspaces.Use(w.Name); err != nil {
+					util.Fatal(err)
+				}
+				fmt.Printf("Using workspace %s\n", w.Name)
+			}
+		},
+	}
+
+	cmd.Flags().StringVarP(&dataDir, "data-dir", "", "", "The data directory of the workspace. Default: $HOME/.tom/workspaces/<name>")
+	cmd.Flags().StringVarP(&backupDir, "backup-dir", "", "", "The backup directory of the workspace. Default: the subdirectory backup of the data directory")
+	cmd.Flags().BoolVarP(&use, "use", "", false, "Make the new workspace the active workspace")
+	parent.AddCommand(cmd)
+	return cmd
+}
@@ -0,0 +1,79 @@
+package workspace
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jansorg/tom/go-tom/cmd/cmdUtil"
+	"github.com/jansorg/tom/go-tom/context"
+	"github.com/jansorg/tom/go-tom/util"
+)
+
+type workspaceSummary struct {
+	name      string
+	active    bool
+	dataDir   string
+	backupDir string
+}
+
+type workspaceList []workspaceSummary
+
+func (o workspaceList) Size() int {
+	return len(o)
+}
+
+func (o workspaceList) Get(index int, prop string, format string, ctx *context.TomContext) (interface{}, error) {
+	switch prop {
+	case "name":
+		return o[index].name, nil
+	case "active":
+		if format == "plain" {
+			if o[index].active {
+				return "*", nil
+			}
+			return " ", nil
+		}
+		return o[index].active, nil
+	case "dataDir":
+		return o[index].dataDir, nil
+	case "backupDir":
+		return o[index].backupDir, nil
+	default:
+		return "", fmt.Errorf("unknown property %s", prop)
+	}
+}
+
+func newListCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "list",
+		Short: "prints the workspaces. The active workspace is marked with *",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			active := ctx.Workspaces.ActiveWorkspace()
+
+			var list workspaceList
+			for _, w := range ctx.Workspaces.All() {
+				// the directories of the default workspace are defined by the configuration
+				options, err := cmdUtil.WorkspaceStoreOptions(ctx.Workspaces, w)
+				if err != nil {
+					util.Fatal(err)
+				}
+				list = append(list, workspaceSummary{
+					name:      w.Name,
+					active:    w.Name == active.Name,
+					dataDir:   options.Dir,
+					backupDir: options.BackupDir,
+				})
+			}
+
+			if err := cmdUtil.PrintList(cmd, list, ctx); err != nil {
+				util.Fatal(err)
+			}
+		},
+	}
+
+	cmdUtil.AddListOutputFlags(cmd, "active,name,dataDir", []string{"name", "active", "dataDir", "backupDir"})
+	parent.AddCommand(cmd)
+	return cmd
+}
@@ -0,0 +1,43 @@
+package workspace
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jansorg/tom/go-tom/context"
+	"github.com/jansorg/tom/go-tom/util"
+)
+
+func newRemoveCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
+	removeData := false
+
+	var cmd = &cobra.Command{
+		Use:   "remove <name>",
+		Short: "removes a workspace. Its data directory is kept, unless --data is passed. The active workspace can't be removed.",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			w, err := ctx.Workspaces.Remove(args[0])
+			if err != nil {
+				util.Fatal(err)
+			}
+
+			if !removeData {
+				fmt.Printf("Successfully removed workspace %s. Its data is kept in %s\n", w.Name, w.DataDir)
+				return
+			}
+
+			for _, dir := range []string{w.BackupDir, w.DataDir} {
+				if err := os.RemoveAll(dir); err != nil {
+					util.Fatal(err)
+				}
+			}
+			fmt.Printf("Successfully removed workspace %s and its data\n", w.Name)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&removeData, "data", "", false, "Also remove the data and the backup directory of the workspace")
+	parent.AddCommand(cmd)
+	return cmd
+}
@@ -0,0 +1,27 @@
+package workspace
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jansorg/tom/go-tom/context"
+	"github.com/jansorg/tom/go-tom/util"
+)
+
+func newUseCommand(ctx *context.TomContext, parent *cobra.Command) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "use <name>",
+		Short: "makes a workspace the active workspace. The following commands use its data.",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := ctx.Workspaces.Use(args[0]); err != nil {
+				util.Fatal(err)
+			}
+			fmt.Printf("Using workspace %s\n", args[0])
+		},
+	}
+
+	parent.AddCommand(cmd)
+	return cmd
+}
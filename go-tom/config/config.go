@@ -1,7 +1,9 @@
 package config
 
 import (
+	"fmt"
 	"log"
+	"os"
 	"path/filepath"
 
 	"github.com/mitchellh/go-homedir"
@@ -9,37 +11,148 @@ import (
 )
 
 const KeyDataDir = "data_dir"
+const KeyDataFormat = "data_format"
 const KeyBackupDir = "backup.directory"
 const KeyIsoDates = "iso_dates"
 const KeyMaxBackups = "backup.max_to_keep"
+const KeyJournal = "journal.enabled"
+const KeyJournalCompactSize = "journal.compact_size"
+const KeyLockTimeout = "lock.timeout"
 const KeyActivityStopOnStart = "activity.stop_on_start"
 const KeyProjectCreateMissing = "projects.create_missing"
+const KeyHookFrameStarted = "hooks.frame.started"
+const KeyHookFrameStopped = "hooks.frame.stopped"
+const KeyHookFrameUpdated = "hooks.frame.updated"
+const KeyHookTimeout = "hooks.timeout"
+const KeyEncryptionKeyFile = "encryption.keyfile"
+const KeyEncryptionPassphraseEnv = "encryption.passphrase_env"
+const KeyTrashExpiryDays = "trash.expiry_days"
 
 var Keys = []string{
 	KeyDataDir,
+	KeyDataFormat,
 	KeyBackupDir,
 	KeyMaxBackups,
+	KeyJournal,
+	KeyJournalCompactSize,
+	KeyLockTimeout,
 	KeyActivityStopOnStart,
 	KeyProjectCreateMissing,
+	KeyHookFrameStarted,
+	KeyHookFrameStopped,
+	KeyHookFrameUpdated,
+	KeyHookTimeout,
+	KeyEncryptionKeyFile,
+	KeyEncryptionPassphraseEnv,
+	KeyTrashExpiryDays,
 }
 
 const ConfigFilename = "tom"
 
-func SetDefaults() {
+// workspaceFile is the configuration file of the active workspace, it's empty for the default workspace
+var workspaceFile string
+
+// Dir returns the directory of the configuration file, $HOME/.tom
+func Dir() string {
 	home, err := homedir.Dir()
 	if err != nil {
 		log.Fatal(err)
 	}
+	return filepath.Join(home, ".tom")
+}
 
-	dataDirPath := filepath.Join(home, ".tom")
-	backupDirPath := filepath.Join(dataDirPath, "backup")
-	viper.SetDefault(KeyDataDir, dataDirPath)
-	viper.SetDefault(KeyBackupDir, backupDirPath)
-	viper.SetDefault(KeyMaxBackups, 10)
-	viper.SetDefault(KeyProjectCreateMissing, false)
-	viper.SetDefault(KeyActivityStopOnStart, true)
+func SetDefaults() {
+	dataDirPath := Dir()
+	setDefaults(viper.GetViper(), dataDirPath)
 
 	viper.SetConfigName(ConfigFilename)
 	// fixme add /etc?
 	viper.AddConfigPath(dataDirPath)
 }
+
+func setDefaults(v *viper.Viper, dataDirPath string) {
+	backupDirPath := filepath.Join(dataDirPath, "backup")
+	v.SetDefault(KeyDataDir, dataDirPath)
+	v.SetDefault(KeyDataFormat, "json")
+	v.SetDefault(KeyBackupDir, backupDirPath)
+	v.SetDefault(KeyMaxBackups, 10)
+	v.SetDefault(KeyJournal, false)
+	v.SetDefault(KeyJournalCompactSize, 500)
+	v.SetDefault(KeyLockTimeout, "10s")
+	v.SetDefault(KeyProjectCreateMissing, false)
+	v.SetDefault(KeyActivityStopOnStart, true)
+	v.SetDefault(KeyHookTimeout, "30s")
+	v.SetDefault(KeyEncryptionPassphraseEnv, "TOM_PASSPHRASE")
+	v.SetDefault(KeyTrashExpiryDays, 30)
+}
+
+// UseWorkspace makes the workspace with the given directories the active workspace.
+// The directories and the values of the configuration file in the workspace's data directory override the loaded configuration,
+// but not the command line flags. WriteValue writes into the configuration file of the workspace afterwards.
+func UseWorkspace(dataDir, backupDir string) error {
+	workspaceFile = workspaceConfigFile(dataDir)
+	return mergeWorkspace(viper.GetViper(), dataDir, backupDir)
+}
+
+// WorkspaceConfig returns the configuration of a workspace, which isn't the active workspace.
+// It's made of the defaults, the loaded configuration file and the configuration file of the workspace.
+// Empty directories denote the default workspace.
+func WorkspaceConfig(dataDir, backupDir string) (*viper.Viper, error) {
+	v := viper.New()
+	setDefaults(v, Dir())
+	if file := viper.ConfigFileUsed(); file != "" {
+		v.SetConfigFile(file)
+		if err := v.ReadInConfig(); err != nil {
+			return nil, err
+		}
+	}
+
+	if dataDir == "" {
+		return v, nil
+	}
+	return v, mergeWorkspace(v, dataDir, backupDir)
+}
+
+func mergeWorkspace(v *viper.Viper, dataDir, backupDir string) error {
+	if err := v.MergeConfigMap(map[string]interface{}{
+		KeyDataDir: dataDir,
+		"backup":   map[string]interface{}{"directory": backupDir},
+	}); err != nil {
+		return err
+	}
+
+	overrides := viper.New()
+	overrides.SetConfigFile(workspaceConfigFile(dataDir))
+	if err := overrides.ReadInConfig(); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	return v.MergeConfigMap(overrides.AllSettings())
+}
+
+func workspaceConfigFile(dataDir string) string {
+	return filepath.Join(dataDir, fmt.Sprintf("%s.yaml", ConfigFilename))
+}
+
+// WriteValue updates the value of key and writes the configuration file.
+// The configuration file is created in the data directory if no configuration file was loaded.
+// If a workspace is active, then the value is written into the configuration file of the workspace.
+func WriteValue(key string, value interface{}) error {
+	viper.Set(key, value)
+	if workspaceFile != "" {
+		overrides := viper.New()
+		overrides.SetConfigFile(workspaceFile)
+		if err := overrides.ReadInConfig(); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		overrides.Set(key, value)
+		return overrides.WriteConfigAs(workspaceFile)
+	}
+
+	if viper.ConfigFileUsed() != "" {
+		return viper.WriteConfig()
+	}
+	return viper.WriteConfigAs(workspaceConfigFile(viper.GetString(KeyDataDir)))
+}
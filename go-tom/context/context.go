@@ -9,6 +9,7 @@ import (
 	"github.com/jansorg/tom/go-tom/model"
 	"github.com/jansorg/tom/go-tom/query"
 	"github.com/jansorg/tom/go-tom/storeHelper"
+	"github.com/jansorg/tom/go-tom/workspace"
 )
 
 type OutputFormat int8
@@ -23,4 +24,6 @@ type TomContext struct {
 	DurationPrinter        i18n.DurationPrinter
 	DecimalDurationPrinter i18n.DurationPrinter
 	DateTimePrinter        i18n.DateTimePrinter
+	// Workspaces is the registry of the workspaces, the data of the active workspace is available in Store
+	Workspaces *workspace.Registry
 }
@@ -25,9 +25,6 @@ func (fanurioImporter) Import(filePath string, ctx *context.TomContext) (dataImp
 	}
 	defer file.Close()
 
-	ctx.Store.StartBatch()
-	defer ctx.Store.StopBatch()
-
 	reader := csv.NewReader(file)
 	reader.TrimLeadingSpace = true
 	reader.LazyQuotes = true
@@ -40,52 +37,58 @@ func (fanurioImporter) Import(filePath string, ctx *context.TomContext) (dataImp
 	createdProjects := 0
 	reusedProjects := 0
 
-	for i, row := range rows {
-		if i == 0 {
-			continue
-		}
-
-		clientName := strings.TrimSpace(row[0])
-		projectName := strings.TrimSpace(row[1])
-		taskName := strings.TrimSpace(row[2])
-		notes := strings.TrimSpace(row[3])
-		dateString := row[4]
-		startString := row[5]
-		endString := row[6]
-
-		// Mon Jan 2 15:04:05 MST 2006
-		startTime, err := parseTime(fmt.Sprintf("%s %s", dateString, startString))
-		if err != nil {
-			return dataImport.Result{}, err
-		}
-
-		endTime, err := parseTime(fmt.Sprintf("%s %s", dateString, endString))
-		if err != nil {
-			return dataImport.Result{}, err
-		}
-
-		project, created, err := ctx.StoreHelper.GetOrCreateNestedProjectNames(clientName, projectName, taskName)
-		if err != nil {
-			return dataImport.Result{}, err
+	err = ctx.Store.Update(func(tx model.Store) error {
+		for i, row := range rows {
+			if i == 0 {
+				continue
+			}
+
+			clientName := strings.TrimSpace(row[0])
+			projectName := strings.TrimSpace(row[1])
+			taskName := strings.TrimSpace(row[2])
+			notes := strings.TrimSpace(row[3])
+			dateString := row[4]
+			startString := row[5]
+			endString := row[6]
+
+			// Mon Jan 2 15:04:05 MST 2006
+			startTime, err := parseTime(fmt.Sprintf("%s %s", dateString, startString))
+			if err != nil {
+				return err
+			}
+
+			endTime, err := parseTime(fmt.Sprintf("%s %s", dateString, endString))
+			if err != nil {
+				return err
+			}
+
+			project, created, err := ctx.StoreHelper.GetOrCreateNestedProjectNames(clientName, projectName, taskName)
+			if err != nil {
+				return err
+			}
+
+			if created {
+				createdProjects++
+			} else {
+				reusedProjects++
+			}
+
+			_, err = tx.AddFrame(model.Frame{
+				ProjectId: project.ID,
+				Notes:     notes,
+				Start:     &startTime,
+				End:       &endTime,
+			})
+			if err != nil {
+				return err
+			}
+
+			createdFrames++
 		}
-
-		if created {
-			createdProjects++
-		} else {
-			reusedProjects++
-		}
-
-		_, err = ctx.Store.AddFrame(model.Frame{
-			ProjectId: project.ID,
-			Notes:     notes,
-			Start:     &startTime,
-			End:       &endTime,
-		})
-		if err != nil {
-			return dataImport.Result{}, err
-		}
-
-		createdFrames++
+		return nil
+	})
+	if err != nil {
+		return dataImport.Result{}, err
 	}
 
 	return dataImport.Result{
@@ -26,9 +26,6 @@ func (macImporter) Import(filename string, ctx *context.TomContext) (dataImport.
 	}
 	defer file.Close()
 
-	ctx.Store.StartBatch()
-	defer ctx.Store.StopBatch()
-
 	reader := csv.NewReader(file)
 	reader.TrimLeadingSpace = true
 	reader.LazyQuotes = true
@@ -43,59 +40,65 @@ func (macImporter) Import(filename string, ctx *context.TomContext) (dataImport.
 	createdProjects := 0
 	reusedProjects := 0
 
-	for i, row := range rows {
-		if i == 0 {
-			// ignore header
-			continue
-		}
-
-		if len(row) != 7 {
-			return dataImport.Result{}, fmt.Errorf("unexpected number of columns %d instead of 7 expected", len(row))
-		}
-
-		projectName := strings.TrimSpace(row[0])
-		taskName := strings.TrimSpace(row[1])
-		// ignore start date
-		startString := row[3] // 2015-12-18 17:00:37
-		// ignore end date, using start+duration instead
-		// 00:23:17, the duration is sometimes different from end-start (off by 1s), we assume that TimeTracker is tracking in ms values and is rounding the duration
-		durationString := row[5]
-		// ignoring duration column
-		notes := strings.TrimSpace(row[6])
-
-		startTime, err := parseTime(startString)
-		if err != nil {
-			return dataImport.Result{}, err
+	err = ctx.Store.Update(func(tx model.Store) error {
+		for i, row := range rows {
+			if i == 0 {
+				// ignore header
+				continue
+			}
+
+			if len(row) != 7 {
+				return fmt.Errorf("unexpected number of columns %d instead of 7 expected", len(row))
+			}
+
+			projectName := strings.TrimSpace(row[0])
+			taskName := strings.TrimSpace(row[1])
+			// ignore start date
+			startString := row[3] // 2015-12-18 17:00:37
+			// ignore end date, using start+duration instead
+			// 00:23:17, the duration is sometimes different from end-start (off by 1s), we assume that TimeTracker is tracking in ms values and is rounding the duration
+			durationString := row[5]
+			// ignoring duration column
+			notes := strings.TrimSpace(row[6])
+
+			startTime, err := parseTime(startString)
+			if err != nil {
+				return err
+			}
+
+			duration, err := parseDuration(durationString)
+			if err != nil {
+				return err
+			}
+
+			endTime := startTime.Add(duration)
+
+			project, created, err := ctx.StoreHelper.GetOrCreateNestedProjectNames(projectName, taskName)
+			if err != nil {
+				return err
+			}
+
+			if created {
+				createdProjects++
+			} else {
+				reusedProjects++
+			}
+
+			if _, err = tx.AddFrame(model.Frame{
+				ProjectId: project.ID,
+				Notes:     notes,
+				Start:     &startTime,
+				End:       &endTime,
+			}); err != nil {
+				return err
+			}
+
+			createdFrames++
 		}
-
-		duration, err := parseDuration(durationString)
-		if err != nil {
-			return dataImport.Result{}, err
-		}
-
-		endTime := startTime.Add(duration)
-
-		project, created, err := ctx.StoreHelper.GetOrCreateNestedProjectNames(projectName, taskName)
-		if err != nil {
-			return dataImport.Result{}, err
-		}
-
-		if created {
-			createdProjects++
-		} else {
-			reusedProjects++
-		}
-
-		if _, err = ctx.Store.AddFrame(model.Frame{
-			ProjectId: project.ID,
-			Notes:     notes,
-			Start:     &startTime,
-			End:       &endTime,
-		}); err != nil {
-			return dataImport.Result{}, err
-		}
-
-		createdFrames++
+		return nil
+	})
+	if err != nil {
+		return dataImport.Result{}, err
 	}
 
 	return dataImport.Result{
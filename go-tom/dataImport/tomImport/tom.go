@@ -28,50 +28,56 @@ func (tomImporter) Import(directoryPath string, ctx *context.TomContext) (dataIm
 		return dataImport.Result{}, err
 	}
 
-	ctx.Store.StartBatch()
-	defer ctx.Store.StopBatch()
-
 	createdFrames := 0
 	createdTags := 0
 	createdProjects := 0
 	reusedProjects := 0
 
-	topLevelProject, err := ctx.Store.AddProject(model.Project{
-		Name: fmt.Sprintf("Import %s", time.Now().Format(time.RFC3339)),
-	})
-
-	// create projects, don't match by name
-	projectMapping := make(map[string]string)
-	for _, p := range importStore.Projects() {
-		if created, reused, err := importProject(*p, importStore, ctx, projectMapping, topLevelProject); err != nil {
-			return dataImport.Result{}, err
-		} else {
-			createdProjects += created
-			reusedProjects += reused
+	err = ctx.Store.Update(func(tx model.Store) error {
+		topLevelProject, err := tx.AddProject(model.Project{
+			Name: fmt.Sprintf("Import %s", time.Now().Format(time.RFC3339)),
+		})
+		if err != nil {
+			return err
 		}
-	}
 
-	// import tags, match by name
-	for _, t := range importStore.Tags() {
-		existingTag, _ := ctx.Store.FindFirstTag(func(tag *model.Tag) bool {
-			return tag.Name == t.Name
-		})
+		// create projects, don't match by name
+		projectMapping := make(map[string]string)
+		for _, p := range importStore.Projects() {
+			if created, reused, err := importProject(*p, importStore, ctx, projectMapping, topLevelProject); err != nil {
+				return err
+			} else {
+				createdProjects += created
+				reusedProjects += reused
+			}
+		}
 
-		if existingTag == nil {
-			if _, err := ctx.Store.AddTag(*t); err != nil {
-				return dataImport.Result{}, err
+		// import tags, match by name
+		for _, t := range importStore.Tags() {
+			existingTag, _ := tx.FindFirstTag(func(tag *model.Tag) bool {
+				return tag.Name == t.Name
+			})
+
+			if existingTag == nil {
+				if _, err := tx.AddTag(*t); err != nil {
+					return err
+				}
+				createdTags += 1
 			}
-			createdTags += 1
 		}
-	}
 
-	// import frames
-	for _, f := range importStore.Frames() {
-		created, err := importFrame(*f, projectMapping, importStore, ctx)
-		if err != nil {
-			return dataImport.Result{}, err
+		// import frames
+		for _, f := range importStore.Frames() {
+			created, err := importFrame(*f, projectMapping, importStore, ctx)
+			if err != nil {
+				return err
+			}
+			createdFrames += created
 		}
-		createdFrames += created
+		return nil
+	})
+	if err != nil {
+		return dataImport.Result{}, err
 	}
 
 	return dataImport.Result{
@@ -35,56 +35,59 @@ func (watsonImporter) Import(filename string, ctx *context.TomContext) (dataImpo
 	createdTags := 0
 	createdFrames := 0
 
-	ctx.Store.StartBatch()
-	defer ctx.Store.StopBatch()
-
-	for _, f := range frames {
-		start := f[0].(float64)
-		stop := f[1].(float64)
-		projectName := f[2].(string)
-		// ignoring frameID f[3]
-		tagNames := f[4].([]interface{})
-		updated := f[5].(float64)
-
-		startTime := epoch.Add(time.Duration(start) * time.Second)
-		stopTime := epoch.Add(time.Duration(stop) * time.Second)
-		udpatedTime := epoch.Add(time.Duration(updated) * time.Second)
-
-		project, createdProject, err := ctx.StoreHelper.GetOrCreateNestedProject(projectName)
-		if err != nil {
-			return dataImport.Result{}, err
-		}
-
-		if createdProject {
-			createdProjects++
-		} else {
-			reusedProjects++
-		}
-
-		var tagIDs []string
-		for _, tagName := range tagNames {
-			tag, createdTag, err := ctx.StoreHelper.GetOrCreateTag(tagName.(string))
+	err = ctx.Store.Update(func(tx model.Store) error {
+		for _, f := range frames {
+			start := f[0].(float64)
+			stop := f[1].(float64)
+			projectName := f[2].(string)
+			// ignoring frameID f[3]
+			tagNames := f[4].([]interface{})
+			updated := f[5].(float64)
+
+			startTime := epoch.Add(time.Duration(start) * time.Second)
+			stopTime := epoch.Add(time.Duration(stop) * time.Second)
+			udpatedTime := epoch.Add(time.Duration(updated) * time.Second)
+
+			project, createdProject, err := ctx.StoreHelper.GetOrCreateNestedProject(projectName)
 			if err != nil {
-				return dataImport.Result{}, err
+				return err
 			}
-			tagIDs = append(tagIDs, tag.ID)
 
-			if createdTag {
-				createdTags++
+			if createdProject {
+				createdProjects++
+			} else {
+				reusedProjects++
 			}
-		}
 
-		_, err = ctx.Store.AddFrame(model.Frame{
-			Start:     &startTime,
-			End:       &stopTime,
-			Updated:   &udpatedTime,
-			ProjectId: project.ID,
-			TagIDs:    tagIDs,
-		})
-		if err != nil {
-			return dataImport.Result{}, err
+			var tagIDs []string
+			for _, tagName := range tagNames {
+				tag, createdTag, err := ctx.StoreHelper.GetOrCreateTag(tagName.(string))
+				if err != nil {
+					return err
+				}
+				tagIDs = append(tagIDs, tag.ID)
+
+				if createdTag {
+					createdTags++
+				}
+			}
+
+			_, err = tx.AddFrame(model.Frame{
+				Start:     &startTime,
+				End:       &stopTime,
+				Updated:   &udpatedTime,
+				ProjectId: project.ID,
+				TagIDs:    tagIDs,
+			})
+			if err != nil {
+				return err
+			}
+			createdFrames++
 		}
-		createdFrames++;
+		return nil
+	})
+	if err != nil {
+		return dataImport.Result{}, err
 	}
 
 	return dataImport.Result{
@@ -83,6 +83,37 @@ func (d *DurationSum) AddStartEndP(start *time.Time, end *time.Time) {
 	}
 }
 
+// AddStartEndExcludingP adds the time from start to end without the excluded ranges, e.g. the breaks of a frame.
+// An excluded range without end lasts until end. The duration is rounded as a single value.
+func (d *DurationSum) AddStartEndExcludingP(start *time.Time, end *time.Time, excluded []DateRange) {
+	if end == nil {
+		end = d.referenceTime
+	}
+	if start == nil || end == nil {
+		return
+	}
+
+	duration := d.overlap(start, end)
+	for _, r := range excluded {
+		if r.Start == nil {
+			continue
+		}
+
+		excludedStart := *r.Start
+		if excludedStart.Before(*start) {
+			excludedStart = *start
+		}
+		excludedEnd := *end
+		if r.End != nil && r.End.Before(excludedEnd) {
+			excludedEnd = *r.End
+		}
+		if excludedEnd.After(excludedStart) {
+			duration -= d.overlap(&excludedStart, &excludedEnd)
+		}
+	}
+	d.Add(duration)
+}
+
 func (d *DurationSum) Add(duration time.Duration) {
 	d.SumExact += duration
 	d.SumRounded += RoundDuration(duration, d.rounding)
@@ -107,12 +138,12 @@ func (d *DurationSum) add(a *time.Time, b *time.Time) {
 		end = d.referenceTime
 	}
 
-	var overlap time.Duration
+	d.Add(d.overlap(start, end))
+}
+
+func (d *DurationSum) overlap(start *time.Time, end *time.Time) time.Duration {
 	if d.acceptedRange != nil {
-		overlap = d.acceptedRange.Intersection(start, end)
-	} else {
-		overlap = b.Sub(*a)
+		return d.acceptedRange.Intersection(start, end)
 	}
-
-	d.Add(overlap)
+	return end.Sub(*start)
 }
@@ -46,3 +46,29 @@ func TestAddRounding(t *testing.T) {
 	assert.EqualValues(t, 1*time.Hour+(12+18+6+6+6)*time.Minute, a.Get())
 	assert.EqualValues(t, 1*time.Hour+(10+15+1+0.5+0.5)*time.Minute, a.GetExact())
 }
+
+func TestAddStartEndExcluding(t *testing.T) {
+	start := time.Date(2018, time.January, 10, 10, 0, 0, 0, time.UTC)
+	end := time.Date(2018, time.January, 10, 14, 0, 0, 0, time.UTC)
+	breakStart := time.Date(2018, time.January, 10, 12, 0, 0, 0, time.UTC)
+	breakEnd := time.Date(2018, time.January, 10, 12, 20, 0, 0, time.UTC)
+	breaks := []DateRange{{Start: &breakStart, End: &breakEnd}}
+
+	// the duration is rounded once, not per range
+	a := NewDurationSumAll(RoundingUp(15*time.Minute), nil, nil)
+	a.AddStartEndExcludingP(&start, &end, breaks)
+	assert.EqualValues(t, 3*time.Hour+40*time.Minute, a.GetExact())
+	assert.EqualValues(t, 3*time.Hour+45*time.Minute, a.Get())
+
+	// only the part of the break in the accepted range is excluded
+	rangeStart := time.Date(2018, time.January, 10, 12, 10, 0, 0, time.UTC)
+	dateRange := NewDateRange(&rangeStart, &end, i18n.FindLocale(language.English, false))
+	a = NewDurationSumFiltered(&dateRange, nil)
+	a.AddStartEndExcludingP(&start, &end, breaks)
+	assert.EqualValues(t, 1*time.Hour+40*time.Minute, a.GetExact())
+
+	// a break without end lasts until the reference time
+	a = NewDurationSumWithRef(&end)
+	a.AddStartEndExcludingP(&start, nil, []DateRange{{Start: &breakStart}})
+	assert.EqualValues(t, 2*time.Hour, a.GetExact())
+}
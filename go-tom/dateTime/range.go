@@ -230,6 +230,18 @@ func (r DateRange) Months(loc *time.Location) []DateRange {
 	return result
 }
 
+func (r DateRange) Weeks(loc *time.Location) []DateRange {
+	end := r.End.In(loc)
+	week := NewWeekRange(*r.Start, r.locale, loc)
+
+	var result []DateRange
+	for !week.Start.After(end) {
+		result = append(result, week)
+		week = week.Shift(0, 0, 7)
+	}
+	return result
+}
+
 func (r DateRange) Days(loc *time.Location) []DateRange {
 	end := r.End.In(loc)
 	month := NewDayRange(*r.Start, r.locale, loc)
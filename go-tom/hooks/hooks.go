@@ -0,0 +1,131 @@
+// Package hooks runs user-defined executables when frames are started, stopped or updated.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/jansorg/tom/go-tom/model"
+)
+
+// Names of the events, which are passed to hooks
+const (
+	FrameStarted = "frame.started"
+	FrameStopped = "frame.stopped"
+	FrameUpdated = "frame.updated"
+)
+
+var Names = []string{FrameStarted, FrameStopped, FrameUpdated}
+
+// Config defines the executables of the hooks, mapped by the name of the event
+type Config struct {
+	Scripts map[string]string
+	// Timeout is the maximum time a single hook may run, 0 disables the timeout
+	Timeout time.Duration
+}
+
+// Payload is passed as JSON on stdin to a hook
+type Payload struct {
+	Event string       `json:"event"`
+	Frame *model.Frame `json:"frame"`
+	// Old is the frame before it was updated or stopped
+	Old         *model.Frame `json:"old,omitempty"`
+	ProjectName string       `json:"projectName,omitempty"`
+	TagNames    []string     `json:"tagNames,omitempty"`
+}
+
+// EventName returns the name of the hook event of a store event or an empty string if there's no hook event for it
+func EventName(event model.Event) string {
+	if event.Entity != model.EntityFrame {
+		return ""
+	}
+
+	oldFrame := event.OldFrame()
+	newFrame := event.NewFrame()
+	switch event.Type {
+	case model.EventAdded:
+		if newFrame.IsActive() {
+			return FrameStarted
+		}
+	case model.EventUpdated:
+		if oldFrame.IsActive() && newFrame.IsStopped() {
+			return FrameStopped
+		}
+		return FrameUpdated
+	}
+	return ""
+}
+
+// Hooks runs the configured executables for the changes of a store
+type Hooks struct {
+	store  model.Store
+	config Config
+	// Output receives the output of the hooks and the error messages of failed hooks
+	Output io.Writer
+}
+
+func New(store model.Store, config Config) *Hooks {
+	return &Hooks{store: store, config: config, Output: os.Stderr}
+}
+
+// Subscribe runs the hooks for the changes of the store until the returned function is called
+func (h *Hooks) Subscribe() func() {
+	return h.store.Subscribe(func(event model.Event) {
+		name := EventName(event)
+		if name == "" || h.config.Scripts[name] == "" {
+			return
+		}
+
+		// the data was already modified, a failed hook must not fail the command
+		if err := h.Run(name, h.newPayload(name, event)); err != nil {
+			_, _ = fmt.Fprintf(h.Output, "hook %s failed: %s\n", name, err.Error())
+		}
+	})
+}
+
+func (h *Hooks) newPayload(name string, event model.Event) Payload {
+	payload := Payload{Event: name, Frame: event.NewFrame(), Old: event.OldFrame()}
+	if project, err := h.store.ProjectByID(payload.Frame.ProjectId); err == nil {
+		payload.ProjectName = project.GetFullName("/")
+	}
+	for _, id := range payload.Frame.TagIDs {
+		if tag, err := h.store.FindFirstTag(func(t *model.Tag) bool { return t.ID == id }); err == nil {
+			payload.TagNames = append(payload.TagNames, tag.Name)
+		}
+	}
+	return payload
+}
+
+// Run executes the hook of the given event with the payload as JSON on stdin.
+// The output of the hook is written to Output, it's not mixed with the output of tom.
+func (h *Hooks) Run(name string, payload Payload) error {
+	path := h.config.Scripts[name]
+	if path == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	if h.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, h.config.Timeout)
+		defer cancel()
+	}
+
+	cmd := exec.CommandContext(ctx, path)
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Stdout = h.Output
+	cmd.Stderr = h.Output
+	cmd.Env = append(os.Environ(), "TOM_HOOK_EVENT="+name)
+	return cmd.Run()
+}
@@ -0,0 +1,102 @@
+package hooks
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+
+	"github.com/jansorg/tom/go-tom/activity"
+	"github.com/jansorg/tom/go-tom/model"
+	"github.com/jansorg/tom/go-tom/test_setup"
+)
+
+func Test_EventName(t *testing.T) {
+	start := time.Now()
+	end := start.Add(time.Hour)
+	active := &model.Frame{ID: "1", Start: &start}
+	stopped := &model.Frame{ID: "1", Start: &start, End: &end}
+
+	assert.EqualValues(t, FrameStarted, EventName(model.NewFrameEvent(model.EventAdded, nil, active)))
+	assert.EqualValues(t, "", EventName(model.NewFrameEvent(model.EventAdded, nil, stopped)), "adding a stopped frame doesn't start it")
+	assert.EqualValues(t, FrameStopped, EventName(model.NewFrameEvent(model.EventUpdated, active, stopped)))
+	assert.EqualValues(t, FrameUpdated, EventName(model.NewFrameEvent(model.EventUpdated, stopped, stopped)))
+	assert.EqualValues(t, FrameUpdated, EventName(model.NewFrameEvent(model.EventUpdated, active, active)))
+	assert.EqualValues(t, "", EventName(model.NewFrameEvent(model.EventRemoved, stopped, nil)))
+	assert.EqualValues(t, "", EventName(model.NewProjectEvent(model.EventAdded, nil, &model.Project{ID: "1"})))
+}
+
+func Test_Hooks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook scripts are shell scripts")
+	}
+
+	ctx, err := test_setup.CreateTestContext(language.English)
+	require.NoError(t, err)
+	defer test_setup.CleanupTestContext(ctx)
+
+	outDir, err := ioutil.TempDir("", "tom-hooks")
+	require.NoError(t, err)
+	defer os.RemoveAll(outDir)
+
+	// the script writes the payload into a file named like the event
+	script := filepath.Join(outDir, "hook.sh")
+	err = ioutil.WriteFile(script, []byte("#!/bin/sh\ncat > \""+outDir+"/$TOM_HOOK_EVENT.json\"\n"), 0700)
+	require.NoError(t, err)
+
+	var output bytes.Buffer
+	hooks := New(ctx.Store, Config{Scripts: map[string]string{FrameStarted: script, FrameStopped: script, FrameUpdated: filepath.Join(outDir, "missing")}})
+	hooks.Output = &output
+	unsubscribe := hooks.Subscribe()
+	defer unsubscribe()
+
+	project, err := ctx.Store.AddProject(model.Project{Name: "project"})
+	require.NoError(t, err)
+	tag, err := ctx.Store.AddTag(model.Tag{Name: "tag"})
+	require.NoError(t, err)
+
+	control := activity.NewActivityControl(ctx, false, false, time.Now())
+	frame, err := control.Start(project.ID, "notes", []*model.Tag{tag})
+	require.NoError(t, err)
+
+	payload := readPayload(t, filepath.Join(outDir, FrameStarted+".json"))
+	assert.EqualValues(t, FrameStarted, payload.Event)
+	assert.EqualValues(t, frame.ID, payload.Frame.ID)
+	assert.Nil(t, payload.Old)
+	assert.EqualValues(t, "project", payload.ProjectName)
+	assert.EqualValues(t, []string{"tag"}, payload.TagNames)
+
+	_, err = control.StopNewest("", nil)
+	require.NoError(t, err)
+
+	payload = readPayload(t, filepath.Join(outDir, FrameStopped+".json"))
+	assert.EqualValues(t, FrameStopped, payload.Event)
+	assert.True(t, payload.Frame.IsStopped())
+	require.NotNil(t, payload.Old)
+	assert.True(t, payload.Old.IsActive())
+
+	// a failing hook doesn't fail the update
+	frame, err = ctx.Store.FindFirstFrame(func(f *model.Frame) bool { return f.ID == frame.ID })
+	require.NoError(t, err)
+	frame.Notes = "updated"
+	_, err = ctx.Store.UpdateFrame(*frame)
+	require.NoError(t, err)
+	assert.Contains(t, output.String(), "hook frame.updated failed")
+}
+
+func readPayload(t *testing.T, path string) Payload {
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+
+	var payload Payload
+	require.NoError(t, json.Unmarshal(data, &payload))
+	return payload
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/jansorg/tom/go-tom"
 	"github.com/jansorg/tom/go-tom/context"
 	"github.com/jansorg/tom/go-tom/dateTime"
+	"github.com/jansorg/tom/go-tom/model"
 	"github.com/jansorg/tom/go-tom/money"
 	"github.com/jansorg/tom/go-tom/report"
 )
@@ -36,6 +37,8 @@ type Options struct {
 	ShowSales          bool             `json:"show_sales"`
 	ShowTracked        bool             `json:"show_tracked"`
 	ShowUnTracked      bool             `json:"show_untracked"`
+	ShowBudgets        bool             `json:"show_budgets"`
+	ShowBillable       bool             `json:"show_billable"`
 	TemplateName       *string          `json:"template_name"`
 	TemplateFilePath   *string          `json:"template_path"`
 	CustomCSS          htmlTemplate.CSS `json:"css"`
@@ -146,6 +149,21 @@ func (r *Report) Render(results *report.BucketReport) ([]byte, error) {
 
 			return sum
 		},
+		"frameTags": func(frame *model.Frame) []*model.Tag {
+			var tags []*model.Tag
+			for _, id := range frame.TagIDs {
+				if tag, err := r.ctx.Query.TagByID(id); err == nil {
+					tags = append(tags, tag)
+				}
+			}
+			return tags
+		},
+		"tagName": func(tag *model.Tag) string {
+			return r.ctx.Query.TagFullName(tag)
+		},
+		"budgets": func() []*report.BudgetBurnDown {
+			return report.NewBudgetBurnDowns(r.ctx, r.options.Report.ProjectIDs, r.options.Report.DateFilterRange, time.Now())
+		},
 		"safeHTML": func(html string) htmlTemplate.HTML {
 			return htmlTemplate.HTML(html)
 		},
@@ -14,6 +14,7 @@ func i18n_dummy() {
 	p.Printf("End")
 	p.Printf("Duration")
 	p.Printf("Notes")
+	p.Printf("Edited after it was stopped")
 	p.Printf("Total")
 	p.Printf("Rounded duration")
 	p.Printf("Project")
@@ -35,4 +36,17 @@ func i18n_dummy() {
 	p.Printf("Total")
 	p.Printf("Rounded duration")
 	p.Printf("Rounded duration")
+	p.Printf("Budgets")
+	p.Printf("Duration")
+	p.Printf("Remaining")
+	p.Printf("Amount")
+	p.Printf("Remaining")
+	p.Printf("Used")
+	p.Printf("Billable")
+	p.Printf("Non-billable")
+	p.Printf("Utilization")
+	p.Printf("Billable time:")
+	p.Printf("Non-billable time:")
+	p.Printf("Utilization:")
+	p.Printf("Breaks")
 }
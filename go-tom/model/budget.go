@@ -0,0 +1,78 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jansorg/tom/go-tom/money"
+)
+
+// BudgetPeriod is the period, in which the hours and the money of a budget are available
+type BudgetPeriod string
+
+const (
+	// BudgetTotal is available once for all frames of the project
+	BudgetTotal BudgetPeriod = "total"
+	// BudgetMonthly is available again in each calendar month
+	BudgetMonthly BudgetPeriod = "monthly"
+)
+
+func BudgetPeriodByName(name string) (BudgetPeriod, error) {
+	switch BudgetPeriod(name) {
+	case BudgetTotal, "":
+		return BudgetTotal, nil
+	case BudgetMonthly:
+		return BudgetMonthly, nil
+	default:
+		return "", fmt.Errorf("unknown budget period %s. Valid values are total, monthly", name)
+	}
+}
+
+// Budget limits the tracked hours and the sales of a project and of its subprojects without a budget.
+// Hours or Money may be undefined. An undefined period is a total budget.
+type Budget struct {
+	Hours  float64      `json:"hours,omitempty"`
+	Money  *money.Money `json:"money,omitempty"`
+	Period BudgetPeriod `json:"period,omitempty"`
+}
+
+func (b *Budget) Validate() error {
+	if b.Hours < 0 {
+		return fmt.Errorf("budget hours must not be negative")
+	}
+	if b.Money != nil && b.Money.Amount() < 0 {
+		return fmt.Errorf("budget money must not be negative")
+	}
+	_, err := BudgetPeriodByName(string(b.Period))
+	return err
+}
+
+// IsEmpty returns if neither hours nor money are defined
+func (b *Budget) IsEmpty() bool {
+	return b.Hours == 0 && b.Money == nil
+}
+
+func (b *Budget) IsMonthly() bool {
+	return b.Period == BudgetMonthly
+}
+
+// Duration returns the budget hours as duration, it's 0 if the budget doesn't define hours
+func (b *Budget) Duration() time.Duration {
+	return time.Duration(b.Hours * float64(time.Hour))
+}
+
+func (b *Budget) String() string {
+	var values []string
+	if b.Hours > 0 {
+		values = append(values, fmt.Sprintf("%gh", b.Hours))
+	}
+	if b.Money != nil {
+		values = append(values, b.Money.ParsableString())
+	}
+	period := b.Period
+	if period == "" {
+		period = BudgetTotal
+	}
+	return fmt.Sprintf("%s %s", strings.Join(values, ", "), period)
+}
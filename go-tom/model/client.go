@@ -0,0 +1,37 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jansorg/tom/go-tom/money"
+)
+
+// Client is the customer, who is billed for the projects assigned to it.
+// The hourly rate of the client applies to the projects, which don't define an hourly rate in the project hierarchy.
+type Client struct {
+	ID         string       `json:"id"`
+	Name       string       `json:"name"`
+	Address    string       `json:"address,omitempty"`
+	Currency   string       `json:"currency,omitempty"`
+	HourlyRate *money.Money `json:"hourlyRate,omitempty"`
+	// TaxID is the VAT ID or another tax number of the client, TaxRate is the tax rate in percent
+	TaxID   string  `json:"taxID,omitempty"`
+	TaxRate float64 `json:"taxRate,omitempty"`
+}
+
+func (c *Client) Validate() error {
+	if strings.TrimSpace(c.Name) == "" {
+		return fmt.Errorf("client name must not be empty")
+	}
+	if c.Currency != "" && !money.IsCurrencyCode(c.Currency) {
+		return fmt.Errorf("unknown currency %s", c.Currency)
+	}
+	if c.HourlyRate != nil && c.Currency != "" && c.HourlyRate.CurrencyCode() != c.Currency {
+		return fmt.Errorf("the hourly rate %s doesn't use the currency %s of the client", c.HourlyRate.ParsableString(), c.Currency)
+	}
+	if c.TaxRate < 0 {
+		return fmt.Errorf("tax rate must not be negative")
+	}
+	return nil
+}
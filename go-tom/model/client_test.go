@@ -0,0 +1,30 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jansorg/tom/go-tom/money"
+)
+
+func Test_ValidateClient(t *testing.T) {
+	v := &Client{ID: "id"}
+	assert.Error(t, v.Validate(), "the name must not be empty")
+
+	v.Name = "ACME"
+	assert.NoError(t, v.Validate())
+
+	v.Currency = "XYZ"
+	assert.Error(t, v.Validate(), "unknown currency")
+
+	v.Currency = "EUR"
+	v.HourlyRate = money.NewMoney(10000, "USD")
+	assert.Error(t, v.Validate(), "the rate must use the currency of the client")
+
+	v.HourlyRate = money.NewMoney(10000, "EUR")
+	assert.NoError(t, v.Validate())
+
+	v.TaxRate = -1
+	assert.Error(t, v.Validate())
+}
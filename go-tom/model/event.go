@@ -0,0 +1,95 @@
+package model
+
+// EventType defines how an entity of a store was changed
+type EventType string
+
+const (
+	EventAdded   EventType = "added"
+	EventUpdated EventType = "updated"
+	EventRemoved EventType = "removed"
+)
+
+// EntityType is the kind of entity of an event
+type EntityType string
+
+const (
+	EntityProject EntityType = "project"
+	EntityTag     EntityType = "tag"
+	EntityClient  EntityType = "client"
+	EntityFrame   EntityType = "frame"
+)
+
+// Event is a change of a project, tag, client or frame of a store.
+// Old and New are copies of the values before and after the change, i.e. *Project, *Tag, *Client or *Frame.
+// Old is nil if the entity was added, New is nil if it was removed.
+type Event struct {
+	Type   EventType   `json:"type"`
+	Entity EntityType  `json:"entity"`
+	Old    interface{} `json:"old,omitempty"`
+	New    interface{} `json:"new,omitempty"`
+}
+
+// Observer is called after a change was persisted.
+type Observer func(event Event)
+
+func NewProjectEvent(eventType EventType, old, new *Project) Event {
+	e := Event{Type: eventType, Entity: EntityProject}
+	if old != nil {
+		value := *old
+		e.Old = &value
+	}
+	if new != nil {
+		value := *new
+		e.New = &value
+	}
+	return e
+}
+
+func NewTagEvent(eventType EventType, old, new *Tag) Event {
+	e := Event{Type: eventType, Entity: EntityTag}
+	if old != nil {
+		value := *old
+		e.Old = &value
+	}
+	if new != nil {
+		value := *new
+		e.New = &value
+	}
+	return e
+}
+
+func NewClientEvent(eventType EventType, old, new *Client) Event {
+	e := Event{Type: eventType, Entity: EntityClient}
+	if old != nil {
+		value := *old
+		e.Old = &value
+	}
+	if new != nil {
+		value := *new
+		e.New = &value
+	}
+	return e
+}
+
+func NewFrameEvent(eventType EventType, old, new *Frame) Event {
+	e := Event{Type: eventType, Entity: EntityFrame}
+	if old != nil {
+		e.Old = old.copy()
+	}
+	if new != nil {
+		e.New = new.copy()
+	}
+	return e
+}
+
+// OldFrame returns the frame before the change or nil if it's not an event of a frame
+func (e Event) OldFrame() *Frame {
+	frame, _ := e.Old.(*Frame)
+	return frame
+}
+
+// NewFrame returns the frame after the change or nil if it's not an event of a frame
+func (e Event) NewFrame() *Frame {
+	frame, _ := e.New.(*Frame)
+	return frame
+}
@@ -27,6 +27,18 @@ type Frame struct {
 	Notes     string     `json:"notes,omitempty"`
 	TagIDs    []string   `json:"tags,omitempty"`
 	Archived  bool       `json:"archived,omitempty"`
+	// Fields contains user-defined values, e.g. ticket=PROJ-42
+	Fields map[string]string `json:"fields,omitempty"`
+	// Billable overrides the billable flag of the project. The value of the project applies if it's nil.
+	Billable *bool `json:"billable,omitempty"`
+	// Breaks contains the pauses of the frame, from oldest to newest. The time of the breaks isn't tracked.
+	Breaks []FrameBreak `json:"breaks,omitempty"`
+
+	// Created is the time when the frame was added, CreatedBy is the command line which added it
+	Created   *time.Time `json:"created,omitempty"`
+	CreatedBy string     `json:"createdBy,omitempty"`
+	// History contains the previous values of the frame, from oldest to newest
+	History []FrameRevision `json:"history,omitempty"`
 }
 
 func (f *Frame) copy() *Frame {
@@ -39,6 +51,12 @@ func (f *Frame) copy() *Frame {
 		Notes:     f.Notes,
 		TagIDs:    f.TagIDs,
 		Archived:  f.Archived,
+		Fields:    f.Fields,
+		Billable:  f.Billable,
+		Breaks:    f.Breaks,
+		Created:   f.Created,
+		CreatedBy: f.CreatedBy,
+		History:   f.History,
 	}
 }
 
@@ -100,52 +118,43 @@ func (f *Frame) Stop() {
 	f.StopAt(time.Now())
 }
 
+// StopAt stops the frame at the given time. A paused frame is stopped when its current break started.
 func (f *Frame) StopAt(time time.Time) {
+	if since := f.PausedSince(); since != nil {
+		if since.Before(time) {
+			time = *since
+		}
+		f.Breaks = append([]FrameBreak(nil), f.Breaks[:len(f.Breaks)-1]...)
+	}
+
 	f.End = &time
 	f.Updated = &time
 }
 
+// Duration returns the tracked time of a stopped frame, i.e. the time between start and end without the breaks
 func (f *Frame) Duration() time.Duration {
 	if f.IsStopped() {
-		return f.End.Sub(*f.Start)
+		return f.End.Sub(*f.Start) - f.BreakDuration(*f.End)
 	}
 	return time.Duration(0)
 }
 
+// ActiveDuration returns the tracked time of the frame. end is used as end of an active frame.
 func (f *Frame) ActiveDuration(end *time.Time) time.Duration {
 	if f.IsActive() && end != nil {
-		return end.Sub(*f.Start)
+		return end.Sub(*f.Start) - f.BreakDuration(*end)
 	}
 	return f.Duration()
 }
 
+// Intersection returns the tracked time of the frame in the time range, the breaks aren't counted.
+// activeEnd is used as end of an active frame.
 func (f *Frame) Intersection(activeEnd *time.Time, timeRange *dateTime.DateRange) time.Duration {
-	var frameEnd *time.Time
-	if f.IsActive() {
-		if activeEnd == nil {
-			return time.Duration(0)
-		}
-		frameEnd = activeEnd
-	} else {
-		frameEnd = f.End
-	}
-
-	if timeRange.ContainsP(f.Start) && timeRange.ContainsP(frameEnd) {
-		return f.ActiveDuration(activeEnd)
-	}
-
-	// intersection at start of time range
-	if !timeRange.ContainsP(f.Start) && timeRange.ContainsP(frameEnd) {
-		return frameEnd.Sub(*timeRange.Start)
+	var result time.Duration
+	for _, r := range f.WorkRanges(activeEnd) {
+		result += timeRange.Intersection(r.Start, r.End)
 	}
-
-	// intersection at end of time range
-	if timeRange.ContainsP(f.Start) && !timeRange.ContainsP(frameEnd) {
-		return timeRange.End.Sub(*f.Start)
-	}
-
-	// no intersection
-	return time.Duration(0)
+	return result
 }
 
 // Contains returns if this frame's time range contains this ref
@@ -182,5 +191,5 @@ func (f *Frame) Validate(requireID bool) error {
 	} else if f.ProjectId == "" {
 		return fmt.Errorf("project id undefined")
 	}
-	return nil
+	return f.validateBreaks()
 }
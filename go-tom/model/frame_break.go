@@ -0,0 +1,149 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jansorg/tom/go-tom/dateTime"
+)
+
+// FrameBreak is a pause of a frame. The time of a break isn't tracked. End is nil while the frame is paused.
+type FrameBreak struct {
+	Start *time.Time `json:"start"`
+	End   *time.Time `json:"end,omitempty"`
+}
+
+// IsPaused returns if the frame is active and its last break wasn't resumed yet
+func (f *Frame) IsPaused() bool {
+	return f.PausedSince() != nil
+}
+
+// PausedSince returns the start of the current break of the frame. It's nil if the frame isn't paused.
+func (f *Frame) PausedSince() *time.Time {
+	if !f.IsActive() || len(f.Breaks) == 0 {
+		return nil
+	}
+
+	last := f.Breaks[len(f.Breaks)-1]
+	if last.End != nil {
+		return nil
+	}
+	return last.Start
+}
+
+// Pause starts a break of the active frame at the given time
+func (f *Frame) Pause(at time.Time) error {
+	if !f.IsActive() {
+		return fmt.Errorf("frame %s is stopped", f.ID)
+	} else if f.IsPaused() {
+		return fmt.Errorf("frame %s is already paused", f.ID)
+	} else if f.Start != nil && at.Before(*f.Start) {
+		return fmt.Errorf("break of frame %s must not start before the frame", f.ID)
+	} else if len(f.Breaks) > 0 && at.Before(*f.Breaks[len(f.Breaks)-1].End) {
+		return fmt.Errorf("break of frame %s must not start before the end of the previous break", f.ID)
+	}
+
+	f.Breaks = append(append([]FrameBreak(nil), f.Breaks...), FrameBreak{Start: &at})
+	f.Updated = &at
+	return nil
+}
+
+// Resume ends the current break of the paused frame at the given time
+func (f *Frame) Resume(at time.Time) error {
+	since := f.PausedSince()
+	if since == nil {
+		return fmt.Errorf("frame %s isn't paused", f.ID)
+	} else if at.Before(*since) {
+		return fmt.Errorf("break of frame %s must not end before it started", f.ID)
+	}
+
+	f.Breaks = append([]FrameBreak(nil), f.Breaks...)
+	f.Breaks[len(f.Breaks)-1].End = &at
+	f.Updated = &at
+	return nil
+}
+
+// BreakRanges returns the date ranges of the breaks. The range of the current break has no end.
+func (f *Frame) BreakRanges() []dateTime.DateRange {
+	var result []dateTime.DateRange
+	for _, b := range f.Breaks {
+		result = append(result, dateTime.DateRange{Start: b.Start, End: b.End})
+	}
+	return result
+}
+
+// BreakDuration returns the duration of the breaks between the start of the frame and end. The current break lasts until end.
+func (f *Frame) BreakDuration(end time.Time) time.Duration {
+	var result time.Duration
+	for _, b := range f.Breaks {
+		start, breakEnd := f.clipBreak(b, end)
+		if breakEnd.After(start) {
+			result += breakEnd.Sub(start)
+		}
+	}
+	return result
+}
+
+// WorkRanges returns the date ranges of the tracked time of the frame, i.e. the time between start and end without the breaks.
+// activeEnd is used as end of an active frame, no ranges are returned for an active frame if it's nil.
+func (f *Frame) WorkRanges(activeEnd *time.Time) []dateTime.DateRange {
+	end := f.End
+	if f.IsActive() {
+		end = activeEnd
+	}
+	if f.Start == nil || end == nil {
+		return nil
+	}
+
+	var result []dateTime.DateRange
+	current := *f.Start
+	for _, b := range f.Breaks {
+		start, breakEnd := f.clipBreak(b, *end)
+		if start.After(current) {
+			rangeStart, rangeEnd := current, start
+			result = append(result, dateTime.DateRange{Start: &rangeStart, End: &rangeEnd})
+		}
+		if breakEnd.After(current) {
+			current = breakEnd
+		}
+	}
+
+	if end.After(current) {
+		result = append(result, dateTime.DateRange{Start: &current, End: end})
+	}
+	return result
+}
+
+// clipBreak returns the start and end of the break inside of the frame, which ends at end
+func (f *Frame) clipBreak(b FrameBreak, end time.Time) (time.Time, time.Time) {
+	start := *b.Start
+	if f.Start != nil && start.Before(*f.Start) {
+		start = *f.Start
+	}
+	if start.After(end) {
+		start = end
+	}
+
+	breakEnd := end
+	if b.End != nil && b.End.Before(end) {
+		breakEnd = *b.End
+	}
+	return start, breakEnd
+}
+
+func (f *Frame) validateBreaks() error {
+	for i, b := range f.Breaks {
+		if b.Start == nil || b.Start.IsZero() {
+			return fmt.Errorf("start time of break undefined")
+		} else if b.Start.Before(*f.Start) {
+			return fmt.Errorf("break must not start before the frame")
+		} else if b.End == nil && (i < len(f.Breaks)-1 || f.IsStopped()) {
+			return fmt.Errorf("end time of break undefined")
+		} else if b.End != nil && b.End.Before(*b.Start) {
+			return fmt.Errorf("break must not end before it started")
+		} else if i > 0 && b.Start.Before(*f.Breaks[i-1].End) {
+			return fmt.Errorf("breaks must not overlap")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jansorg/tom/go-tom/dateTime"
+)
+
+func TestFramePauseResume(t *testing.T) {
+	f := Frame{ProjectId: "p", Start: newDate(2019, time.January, 1, 10, 0)}
+
+	require.NoError(t, f.Pause(*newDate(2019, time.January, 1, 12, 0)))
+	assert.True(t, f.IsPaused())
+	assert.EqualValues(t, newDate(2019, time.January, 1, 12, 0), f.PausedSince())
+	assert.Error(t, f.Pause(*newDate(2019, time.January, 1, 12, 30)), "a paused frame can't be paused")
+
+	// the current break isn't counted
+	assert.EqualValues(t, 2*time.Hour, f.ActiveDuration(newDate(2019, time.January, 1, 14, 0)))
+
+	assert.Error(t, f.Resume(*newDate(2019, time.January, 1, 11, 0)), "a break must not end before it started")
+	require.NoError(t, f.Resume(*newDate(2019, time.January, 1, 13, 0)))
+	assert.False(t, f.IsPaused())
+	assert.Nil(t, f.PausedSince())
+	assert.Error(t, f.Resume(*newDate(2019, time.January, 1, 13, 30)), "a running frame can't be resumed")
+
+	f.StopAt(*newDate(2019, time.January, 1, 15, 0))
+	assert.EqualValues(t, 4*time.Hour, f.Duration())
+	assert.NoError(t, f.Validate(false))
+
+	ranges := f.WorkRanges(nil)
+	require.Len(t, ranges, 2)
+	assert.EqualValues(t, newDate(2019, time.January, 1, 10, 0), ranges[0].Start)
+	assert.EqualValues(t, newDate(2019, time.January, 1, 12, 0), ranges[0].End)
+	assert.EqualValues(t, newDate(2019, time.January, 1, 13, 0), ranges[1].Start)
+	assert.EqualValues(t, newDate(2019, time.January, 1, 15, 0), ranges[1].End)
+}
+
+func TestFrameStopPaused(t *testing.T) {
+	f := Frame{Start: newDate(2019, time.January, 1, 10, 0)}
+	require.NoError(t, f.Pause(*newDate(2019, time.January, 1, 12, 0)))
+
+	// a paused frame ends when the break started
+	f.StopAt(*newDate(2019, time.January, 1, 18, 0))
+	assert.EqualValues(t, newDate(2019, time.January, 1, 12, 0), f.End)
+	assert.Empty(t, f.Breaks)
+	assert.EqualValues(t, 2*time.Hour, f.Duration())
+}
+
+func TestFrameBreakIntersection(t *testing.T) {
+	f := Frame{
+		Start: newDate(2019, time.January, 1, 10, 0),
+		End:   newDate(2019, time.January, 1, 16, 0),
+		Breaks: []FrameBreak{
+			{Start: newDate(2019, time.January, 1, 12, 0), End: newDate(2019, time.January, 1, 13, 0)},
+		},
+	}
+
+	timeRange := dateTime.DateRange{Start: newDate(2019, time.January, 1, 11, 0), End: newDate(2019, time.January, 1, 14, 0)}
+	assert.EqualValues(t, 2*time.Hour, f.Intersection(nil, &timeRange))
+	assert.EqualValues(t, 5*time.Hour, f.Duration())
+}
+
+func TestFrameValidateBreaks(t *testing.T) {
+	f := Frame{
+		ID:        "1",
+		ProjectId: "p",
+		Start:     newDate(2019, time.January, 1, 10, 0),
+		End:       newDate(2019, time.January, 1, 16, 0),
+		Breaks: []FrameBreak{
+			{Start: newDate(2019, time.January, 1, 12, 0), End: newDate(2019, time.January, 1, 13, 0)},
+			{Start: newDate(2019, time.January, 1, 12, 30), End: newDate(2019, time.January, 1, 14, 0)},
+		},
+	}
+	assert.Error(t, f.Validate(true), "overlapping breaks")
+
+	f.Breaks = []FrameBreak{{Start: newDate(2019, time.January, 1, 9, 0), End: newDate(2019, time.January, 1, 11, 0)}}
+	assert.Error(t, f.Validate(true), "break before the start of the frame")
+
+	f.Breaks = []FrameBreak{{Start: newDate(2019, time.January, 1, 12, 0)}}
+	assert.Error(t, f.Validate(true), "break without end of a stopped frame")
+
+	f.End = nil
+	assert.NoError(t, f.Validate(true), "the current break of an active frame has no end")
+}
@@ -0,0 +1,125 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// the name of a custom field, e.g. ticket or cost-center
+var fieldNamePattern = regexp.MustCompile(`^[a-zA-Z0-9_.-]+$`)
+
+// ParseField parses a field assignment like ticket=PROJ-42. An empty value, e.g. ticket=, removes the field.
+func ParseField(assignment string) (string, string, error) {
+	parts := strings.SplitN(assignment, "=", 2)
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("%s is not matching name=value", assignment)
+	}
+
+	name := strings.TrimSpace(parts[0])
+	if !fieldNamePattern.MatchString(name) {
+		return "", "", fmt.Errorf("invalid field name %s, only letters, digits, '.', '_' and '-' are allowed", name)
+	}
+	return name, strings.TrimSpace(parts[1]), nil
+}
+
+// ParseFields parses the field assignments into a map of names and values
+func ParseFields(assignments []string) (map[string]string, error) {
+	result := map[string]string{}
+	for _, assignment := range assignments {
+		name, value, err := ParseField(assignment)
+		if err != nil {
+			return nil, err
+		}
+		result[name] = value
+	}
+	return result, nil
+}
+
+// FieldFilter matches frames by their custom fields. An empty value matches any value of the field.
+type FieldFilter map[string]string
+
+// NewFieldFilter parses expressions like ticket=PROJ-42, which matches the value, or ticket, which matches any value of the field
+func NewFieldFilter(expressions []string) (FieldFilter, error) {
+	filter := FieldFilter{}
+	for _, expression := range expressions {
+		if !strings.Contains(expression, "=") {
+			expression += "="
+		}
+
+		name, value, err := ParseField(expression)
+		if err != nil {
+			return nil, err
+		}
+		filter[name] = value
+	}
+	return filter, nil
+}
+
+// Matches returns if the frame has all fields of the filter
+func (f FieldFilter) Matches(frame *Frame) bool {
+	for name, value := range f {
+		if actual, ok := frame.Fields[name]; !ok || value != "" && actual != value {
+			return false
+		}
+	}
+	return true
+}
+
+// Field returns the value of the custom field, it returns an empty string if the frame doesn't have the field
+func (f *Frame) Field(name string) string {
+	return f.Fields[name]
+}
+
+// SetField sets the value of a custom field, an empty value removes the field.
+// The map of fields is replaced to keep copies of the frame unchanged.
+func (f *Frame) SetField(name, value string) {
+	f.SetFields(map[string]string{name: value})
+}
+
+// SetFields sets the values of the custom fields, empty values remove the fields
+func (f *Frame) SetFields(fields map[string]string) {
+	if len(fields) == 0 {
+		return
+	}
+
+	updated := make(map[string]string, len(f.Fields)+len(fields))
+	for name, value := range f.Fields {
+		updated[name] = value
+	}
+	for name, value := range fields {
+		if value == "" {
+			delete(updated, name)
+		} else {
+			updated[name] = value
+		}
+	}
+
+	f.Fields = updated
+	if len(updated) == 0 {
+		f.Fields = nil
+	}
+}
+
+// FieldsString returns the fields as a sorted, comma separated list of name=value pairs
+func FieldsString(fields map[string]string) string {
+	var pairs []string
+	for name, value := range fields {
+		pairs = append(pairs, name+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func sameFields(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, value := range a {
+		if other, ok := b[name]; !ok || other != value {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,50 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFields(t *testing.T) {
+	fields, err := ParseFields([]string{"ticket=PROJ-42", "location = onsite", "cost.center="})
+	require.NoError(t, err)
+	assert.EqualValues(t, map[string]string{"ticket": "PROJ-42", "location": "onsite", "cost.center": ""}, fields)
+
+	_, err = ParseFields([]string{"ticket"})
+	assert.Error(t, err, "a value is required")
+
+	_, err = ParseFields([]string{"my ticket=1"})
+	assert.Error(t, err, "whitespace is not allowed in names")
+
+	_, err = ParseFields([]string{"=1"})
+	assert.Error(t, err, "empty names are not allowed")
+}
+
+func TestSetFields(t *testing.T) {
+	frame := &Frame{}
+	frame.SetField("ticket", "PROJ-42")
+	assert.EqualValues(t, "PROJ-42", frame.Field("ticket"))
+
+	copied := *frame
+	frame.SetFields(map[string]string{"ticket": "", "location": "onsite"})
+	assert.EqualValues(t, map[string]string{"location": "onsite"}, frame.Fields)
+	assert.EqualValues(t, map[string]string{"ticket": "PROJ-42"}, copied.Fields, "copies of the frame must not be modified")
+	assert.EqualValues(t, "location=onsite", FieldsString(frame.Fields))
+
+	frame.SetField("location", "")
+	assert.Nil(t, frame.Fields)
+}
+
+func TestRecordFieldChange(t *testing.T) {
+	old := &Frame{ID: "1"}
+	old.SetField("ticket", "PROJ-1")
+
+	updated := *old
+	updated.SetField("ticket", "PROJ-2")
+	updated.RecordChange(old, time.Now(), "tom edit frame")
+	require.Len(t, updated.History, 1)
+	assert.EqualValues(t, map[string]string{"ticket": "PROJ-1"}, updated.History[0].Fields)
+}
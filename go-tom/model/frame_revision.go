@@ -0,0 +1,107 @@
+package model
+
+import (
+	"sort"
+	"time"
+)
+
+// FrameRevision contains the values of a frame before it was changed
+type FrameRevision struct {
+	// Changed is the time of the change, Command is the command line which changed the frame
+	Changed   time.Time         `json:"changed"`
+	Command   string            `json:"command,omitempty"`
+	ProjectId string            `json:"project"`
+	Start     *time.Time        `json:"start,omitempty"`
+	End       *time.Time        `json:"end,omitempty"`
+	Notes     string            `json:"notes,omitempty"`
+	TagIDs    []string          `json:"tags,omitempty"`
+	Fields    map[string]string `json:"fields,omitempty"`
+	Billable  *bool             `json:"billable,omitempty"`
+	Breaks    []FrameBreak      `json:"breaks,omitempty"`
+}
+
+// NewFrameRevision returns the revision, which records the current values of the frame
+func NewFrameRevision(f *Frame, changed time.Time, command string) FrameRevision {
+	return FrameRevision{
+		Changed:   changed,
+		Command:   command,
+		ProjectId: f.ProjectId,
+		Start:     f.Start,
+		End:       f.End,
+		Notes:     f.Notes,
+		TagIDs:    append([]string(nil), f.TagIDs...),
+		Fields:    f.Fields,
+		Billable:  f.Billable,
+		Breaks:    append([]FrameBreak(nil), f.Breaks...),
+	}
+}
+
+// RecordChange is called when the frame replaces old.
+// The history and the creation values of old are kept and a revision of old is added to the history,
+// if the project, start, end, notes, tags, fields, the billable flag or the breaks were changed.
+func (f *Frame) RecordChange(old *Frame, changed time.Time, command string) {
+	f.Created = old.Created
+	f.CreatedBy = old.CreatedBy
+	f.History = old.History
+	if !f.sameValues(old) {
+		f.History = append(append([]FrameRevision(nil), old.History...), NewFrameRevision(old, changed, command))
+	}
+}
+
+func (f *Frame) sameValues(other *Frame) bool {
+	return f.ProjectId == other.ProjectId &&
+		sameTime(f.Start, other.Start) &&
+		sameTime(f.End, other.End) &&
+		f.Notes == other.Notes &&
+		sameStrings(f.TagIDs, other.TagIDs) &&
+		sameFields(f.Fields, other.Fields) &&
+		sameBool(f.Billable, other.Billable) &&
+		sameBreaks(f.Breaks, other.Breaks)
+}
+
+// EditedAfterStop returns if the frame was changed after it had been stopped
+func (f *Frame) EditedAfterStop() bool {
+	for _, revision := range f.History {
+		if revision.End != nil && revision.Changed.After(*revision.End) {
+			return true
+		}
+	}
+	return false
+}
+
+func sameTime(a, b *time.Time) bool {
+	return a == nil && b == nil || a != nil && b != nil && a.Equal(*b)
+}
+
+func sameBool(a, b *bool) bool {
+	return a == nil && b == nil || a != nil && b != nil && *a == *b
+}
+
+func sameBreaks(a, b []FrameBreak) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !sameTime(a[i].Start, b[i].Start) || !sameTime(a[i].End, b[i].End) {
+			return false
+		}
+	}
+	return true
+}
+
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	sortedA := append([]string(nil), a...)
+	sortedB := append([]string(nil), b...)
+	sort.Strings(sortedA)
+	sort.Strings(sortedB)
+	for i := range sortedA {
+		if sortedA[i] != sortedB[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,48 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordChange(t *testing.T) {
+	created := newDate(2019, time.January, 1, 9, 0)
+	start := newDate(2019, time.January, 1, 10, 0)
+	end := newDate(2019, time.January, 1, 12, 0)
+
+	old := &Frame{ProjectId: "a", Start: start, End: end, TagIDs: []string{"t1", "t2"}, Created: created, CreatedBy: "tom start a"}
+	f := *old
+	f.TagIDs = []string{"t2", "t1"}
+	f.Archived = true
+	f.RecordChange(old, end.Add(time.Minute), "tom edit")
+	assert.Empty(t, f.History, "the order of tags and the archived flag must not be recorded")
+	assert.EqualValues(t, created, f.Created)
+	assert.EqualValues(t, "tom start a", f.CreatedBy)
+	assert.False(t, f.EditedAfterStop())
+
+	changed := f
+	changed.ProjectId = "b"
+	changed.RecordChange(&f, end.Add(time.Hour), "tom edit frame")
+	assert.EqualValues(t, []FrameRevision{{Changed: end.Add(time.Hour), Command: "tom edit frame", ProjectId: "a", Start: start, End: end, TagIDs: []string{"t2", "t1"}}}, changed.History)
+	assert.Empty(t, f.History, "the history of the old value must not be modified")
+	assert.True(t, changed.EditedAfterStop())
+}
+
+func TestRecordChangeBreaks(t *testing.T) {
+	start := newDate(2019, time.January, 1, 10, 0)
+	old := &Frame{ProjectId: "a", Start: start}
+	paused := *old
+	assert.NoError(t, paused.Pause(start.Add(time.Hour)))
+	paused.RecordChange(old, start.Add(time.Hour), "tom pause")
+	assert.Len(t, paused.History, 1, "a new break must be recorded")
+
+	stopped := paused
+	stopped.StopAt(start.Add(2 * time.Hour))
+	stopped.RecordChange(&paused, start.Add(2*time.Hour), "tom stop")
+	assert.Empty(t, stopped.Breaks)
+	if assert.Len(t, stopped.History, 2) {
+		assert.EqualValues(t, paused.Breaks, stopped.History[1].Breaks, "the dropped break must be recorded")
+	}
+}
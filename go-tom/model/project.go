@@ -11,12 +11,16 @@ import (
 type ProjectProperties struct {
 	HourlyRate   *money.Money `json:"hourlyRate,omitempty"`
 	NoteRequired *bool        `json:"noteRequired,omitempty"`
+	Budget       *Budget      `json:"budget,omitempty"`
+	Billable     *bool        `json:"billable,omitempty"`
 }
 
 type Project struct {
-	ID         string             `json:"id"`
-	ParentID   string             `json:"parent"`
-	Name       string             `json:"name"`
+	ID       string `json:"id"`
+	ParentID string `json:"parent"`
+	Name     string `json:"name"`
+	// ClientID is the client of the project. Projects without a client belong to the client of their parent project.
+	ClientID   string             `json:"client,omitempty"`
 	Properties *ProjectProperties `json:"properties,omitempty"`
 
 	Store    Store    `json:"-"`
@@ -43,6 +47,9 @@ func (p *Project) Validate() error {
 	if strings.TrimSpace(p.Name) == "" {
 		return fmt.Errorf("project name must not be empty")
 	}
+	if budget := p.Budget(); budget != nil {
+		return budget.Validate()
+	}
 	return nil
 }
 
@@ -60,6 +67,22 @@ func (p *Project) IsNoteRequired() *bool {
 	return p.Properties.NoteRequired
 }
 
+// IsBillable returns if the time of the project is billable. It returns nil if the value is inherited from the parent project.
+func (p *Project) IsBillable() *bool {
+	if p.Properties == nil || p.Properties.Billable == nil {
+		return nil
+	}
+	return p.Properties.Billable
+}
+
+// Budget returns the budget defined by the project. Budgets of parent projects aren't returned.
+func (p *Project) Budget() *Budget {
+	if p.Properties == nil {
+		return nil
+	}
+	return p.Properties.Budget
+}
+
 func (p *Project) SetHourlyRate(value *money.Money) {
 	defer p.cleanupProperties()
 	if p.Properties == nil {
@@ -76,9 +99,29 @@ func (p *Project) SetNoteRequired(required *bool) {
 	p.Properties.NoteRequired = required
 }
 
+func (p *Project) SetBillable(billable *bool) {
+	defer p.cleanupProperties()
+	if p.Properties == nil {
+		p.Properties = &ProjectProperties{}
+	}
+	p.Properties.Billable = billable
+}
+
+// SetBudget updates the budget of the project, a nil or empty budget removes it
+func (p *Project) SetBudget(budget *Budget) {
+	defer p.cleanupProperties()
+	if p.Properties == nil {
+		p.Properties = &ProjectProperties{}
+	}
+	if budget != nil && budget.IsEmpty() {
+		budget = nil
+	}
+	p.Properties.Budget = budget
+}
+
 func (p *Project) cleanupProperties() {
 	if p.Properties != nil {
-		if p.Properties.HourlyRate == nil && (p.Properties.NoteRequired == nil) {
+		if p.Properties.HourlyRate == nil && (p.Properties.NoteRequired == nil) && p.Properties.Budget == nil && p.Properties.Billable == nil {
 			p.Properties = nil
 		}
 	}
@@ -47,3 +47,28 @@ func TestJsonEmpty(t *testing.T) {
 
 	assert.Nil(t, p2.HourlyRate())
 }
+
+func TestBudgetJson(t *testing.T) {
+	p := &Project{ID: "id", Name: "name"}
+	p.SetBudget(&Budget{Hours: 40, Money: money.NewMoney(500000, "EUR"), Period: BudgetMonthly})
+	require.NoError(t, p.Validate())
+
+	bytes, err := json.Marshal(p)
+	require.NoError(t, err)
+
+	var p2 Project
+	err = json.Unmarshal(bytes, &p2)
+	require.NoError(t, err)
+
+	require.NotNil(t, p2.Budget())
+	assert.EqualValues(t, 40, p2.Budget().Hours)
+	assert.EqualValues(t, 500000, p2.Budget().Money.Amount())
+	assert.True(t, p2.Budget().IsMonthly())
+	assert.EqualValues(t, "40h, 5000.00 EUR monthly", p2.Budget().String())
+
+	p.Budget().Period = "weekly"
+	assert.Error(t, p.Validate(), "unknown periods must be rejected")
+
+	p.SetBudget(&Budget{})
+	assert.Nil(t, p.Properties, "an empty budget must remove the budget")
+}
@@ -1,11 +1,17 @@
 package model
 
+import "time"
+
 type Store interface {
 	DirPath() string
 	BackupDirPath() string
 	MaxBackups() int
-	StartBatch()
-	StopBatch()
+	// Update runs fn in a transaction. All changes are persisted at once when fn returns nil,
+	// they're discarded when it returns an error.
+	Update(fn func(tx Store) error) error
+	// Subscribe adds an observer, which is called for each change after it was persisted.
+	// Changes of a transaction are passed after the transaction was committed. The returned function removes the observer.
+	Subscribe(observer Observer) func()
 
 	Reset(projects, tags, frames bool) (int, int, int, error)
 
@@ -25,10 +31,28 @@ type Store interface {
 	FindFirstTag(func(*Tag) bool) (*Tag, error)
 	FindTags(func(*Tag) bool) []*Tag
 
+	Clients() []*Client
+	AddClient(client Client) (*Client, error)
+	UpdateClient(client Client) (*Client, error)
+	RemoveClient(id string) error
+	FindFirstClient(func(*Client) bool) (*Client, error)
+	FindClients(func(*Client) bool) []*Client
+
 	Frames() FrameList
 	AddFrame(frame Frame) (*Frame, error)
 	UpdateFrame(frame Frame) (*Frame, error)
 	RemoveFrame(id string) error
+	// FrameByID returns the frame with the given ID, it's looked up without decoding or copying all frames
+	FrameByID(id string) (*Frame, error)
+	// FindFirstFrame and FindFrames pass the frames to the filter without holding a lock of the store,
+	// the filter may query the store
 	FindFirstFrame(func(*Frame) bool) (*Frame, error)
 	FindFrames(func(*Frame) (bool, error)) ([]*Frame, error)
+	FramesByProject(projectIDs ...string) FrameList
+	// FramesByTag returns the frames, which have at least one of the tags
+	FramesByTag(tagIDs ...string) FrameList
+	// FramesInRange returns the frames intersecting the range, active frames are treated as frames without end.
+	// A nil value of start or end is unbounded.
+	FramesInRange(start, end *time.Time) FrameList
+	ActiveFrames() FrameList
 }
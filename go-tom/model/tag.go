@@ -2,17 +2,36 @@ package model
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 )
 
+// TagDelimiter separates the names of a tag and of its parent tags in the full name of a tag, e.g. meeting/client
+const TagDelimiter = "/"
+
+// a color is either a hex value like #f80 or #ff8800 or the name of a CSS color
+var tagColorPattern = regexp.MustCompile(`^(#[0-9a-fA-F]{3}|#[0-9a-fA-F]{6}|[a-zA-Z]+)$`)
+
 type Tag struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	ParentID    string `json:"parent,omitempty"`
+	Color       string `json:"color,omitempty"`
+	Description string `json:"description,omitempty"`
 }
 
 func (t *Tag) Validate() error {
 	if strings.TrimSpace(t.Name) == "" {
 		return fmt.Errorf("tag name must not be empty")
 	}
+	if strings.Contains(t.Name, TagDelimiter) {
+		return fmt.Errorf("tag name must not contain %s, use a parent tag instead", TagDelimiter)
+	}
+	if t.ParentID != "" && t.ParentID == t.ID {
+		return fmt.Errorf("tag must not be its own parent")
+	}
+	if t.Color != "" && !tagColorPattern.MatchString(t.Color) {
+		return fmt.Errorf("invalid tag color %s, use a value like #ff8800 or a color name", t.Color)
+	}
 	return nil
 }
@@ -15,6 +15,24 @@ func Test_ValidateTag(t *testing.T) {
 
 	v.Name = "name"
 	assert.NoError(t, v.Validate())
+
+	v.Name = "meeting/client"
+	assert.Error(t, v.Validate(), "the name must not contain the delimiter of the full name")
 }
 
+func Test_ValidateTagParentAndColor(t *testing.T) {
+	v := &Tag{ID: "id", Name: "name", ParentID: "id"}
+	assert.Error(t, v.Validate(), "a tag must not be its own parent")
+
+	v.ParentID = "parent"
+	assert.NoError(t, v.Validate())
 
+	for _, color := range []string{"#f80", "#FF8800", "red"} {
+		v.Color = color
+		assert.NoError(t, v.Validate(), color)
+	}
+	for _, color := range []string{"#ff88", "ff8800", "red;", "url(x)"} {
+		v.Color = color
+		assert.Error(t, v.Validate(), color)
+	}
+}
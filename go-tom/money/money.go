@@ -28,6 +28,11 @@ func Parse(value string) (*Money, error) {
 	return NewMoney(int64(amount*math.Pow10(curr.Fraction)), code), err
 }
 
+// IsCurrencyCode returns if code is a known ISO 4217 currency code, e.g. EUR
+func IsCurrencyCode(code string) bool {
+	return code != "" && money.GetCurrency(code) != nil
+}
+
 func parseNumber(s string) (float64, error) {
 	if f, err := strconv.ParseFloat(s, 64); err == nil {
 		return f, nil
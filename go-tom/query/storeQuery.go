@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/jansorg/tom/go-tom/model"
 	"github.com/jansorg/tom/go-tom/money"
@@ -27,16 +28,28 @@ type StoreQuery interface {
 
 	TagByID(id string) (*model.Tag, error)
 	TagByName(name string) (*model.Tag, error)
+	TagByNameOrID(nameOrID string) (*model.Tag, error)
 	TagsByName(names ...string) ([]*model.Tag, error)
+	TagFullName(tag *model.Tag) string
+	CollectSubtagIDs(id string) []string
+
+	ClientByID(id string) (*model.Client, error)
+	ClientByName(name string) (*model.Client, error)
+	ClientByNameOrID(nameOrID string) (*model.Client, error)
+	ProjectClient(projectID string) (*model.Client, error)
 
 	FrameByID(id string) (*model.Frame, error)
 	FramesByID(id ...string) ([]*model.Frame, error)
 	FramesByProject(id string, includeSubprojects bool) model.FrameList
 	FramesByTag(id string) []*model.Frame
+	FramesInRange(start, end *time.Time) model.FrameList
 	ActiveFrames() []*model.Frame
 
 	HourlyRate(projectID string) (*money.Money, error)
 	IsNoteRequired(projectID string) (*bool, error)
+	IsBillable(projectID string) (*bool, error)
+	IsFrameBillable(frame *model.Frame) bool
+	Budget(projectID string) (*model.Budget, *model.Project, error)
 
 	IsToplevelProject(id string) bool
 }
@@ -205,23 +218,39 @@ func (q *defaultStoreQuery) TagByID(id string) (*model.Tag, error) {
 	return tag, nil
 }
 
+// TagByName returns the tag with the full name, e.g. meeting/client. The full name of a top-level tag is its name.
 func (q *defaultStoreQuery) TagByName(name string) (*model.Tag, error) {
-	tag, err := q.store.FindFirstTag(func(t *model.Tag) bool {
-		return t.Name == name
-	})
+	tags := q.store.Tags()
+	byID := tagsByID(tags)
+	for _, t := range tags {
+		if tagFullName(t, byID) == name {
+			return t, nil
+		}
+	}
+	return nil, fmt.Errorf("no tag found for name %s", name)
+}
 
-	if err != nil {
-		return nil, fmt.Errorf("no tag found for name %s", name)
+func (q *defaultStoreQuery) TagByNameOrID(nameOrID string) (*model.Tag, error) {
+	if tag, err := q.TagByID(nameOrID); err == nil {
+		return tag, nil
 	}
-	return tag, nil
+	return q.TagByName(nameOrID)
 }
 
+// TagsByName returns the tags with the full names
 func (q *defaultStoreQuery) TagsByName(names ...string) ([]*model.Tag, error) {
 	sort.Strings(names)
-	matching := q.store.FindTags(func(t *model.Tag) bool {
-		i := sort.SearchStrings(names, t.Name)
-		return i < len(names) && names[i] == t.Name
-	})
+
+	// the full names are computed from a snapshot of the tags, the store must not be called while it's iterating its tags
+	tags := q.store.Tags()
+	byID := tagsByID(tags)
+	var matching []*model.Tag
+	for _, t := range tags {
+		name := tagFullName(t, byID)
+		if i := sort.SearchStrings(names, name); i < len(names) && names[i] == name {
+			matching = append(matching, t)
+		}
+	}
 
 	if len(matching) != len(names) {
 		return nil, fmt.Errorf("unable to find all tags for %s", names)
@@ -229,10 +258,61 @@ func (q *defaultStoreQuery) TagsByName(names ...string) ([]*model.Tag, error) {
 	return matching, nil
 }
 
+// TagFullName returns the names of the tag and of its parents, separated by model.TagDelimiter
+func (q *defaultStoreQuery) TagFullName(tag *model.Tag) string {
+	return tagFullName(tag, tagsByID(q.store.Tags()))
+}
+
+func tagsByID(tags []*model.Tag) map[string]*model.Tag {
+	result := make(map[string]*model.Tag, len(tags))
+	for _, t := range tags {
+		result[t.ID] = t
+	}
+	return result
+}
+
+func tagFullName(tag *model.Tag, byID map[string]*model.Tag) string {
+	names := []string{tag.Name}
+	visited := map[string]bool{tag.ID: true}
+	for id := tag.ParentID; id != "" && !visited[id]; {
+		parent, ok := byID[id]
+		if !ok {
+			break
+		}
+		names = append([]string{parent.Name}, names...)
+		visited[id] = true
+		id = parent.ParentID
+	}
+	return strings.Join(names, model.TagDelimiter)
+}
+
+// CollectSubtagIDs returns the IDs of all tags below the tag
+func (q *defaultStoreQuery) CollectSubtagIDs(id string) []string {
+	children := map[string][]string{}
+	for _, t := range q.store.Tags() {
+		if t.ParentID != "" {
+			children[t.ParentID] = append(children[t.ParentID], t.ID)
+		}
+	}
+
+	var result []string
+	visited := map[string]bool{id: true}
+	pending := children[id]
+	for len(pending) > 0 {
+		current := pending[0]
+		pending = pending[1:]
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+		result = append(result, current)
+		pending = append(pending, children[current]...)
+	}
+	return result
+}
+
 func (q *defaultStoreQuery) FrameByID(id string) (*model.Frame, error) {
-	return q.store.FindFirstFrame(func(f *model.Frame) bool {
-		return f.ID == id
-	})
+	return q.store.FrameByID(id)
 }
 
 func (q *defaultStoreQuery) FramesByID(ids ...string) ([]*model.Frame, error) {
@@ -253,26 +333,69 @@ func (q *defaultStoreQuery) FramesByID(ids ...string) ([]*model.Frame, error) {
 }
 
 func (q *defaultStoreQuery) FramesByProject(id string, includeSubprojects bool) model.FrameList {
-	frames, _ := q.store.FindFrames(func(f *model.Frame) (bool, error) {
-		return f.ProjectId == id || includeSubprojects && q.store.ProjectIsSameOrChild(id, f.ProjectId), nil
-	})
-	return frames
+	if includeSubprojects {
+		return q.store.FramesByProject(append([]string{id}, q.CollectSubprojectIDs(id)...)...)
+	}
+	return q.store.FramesByProject(id)
 }
 
+// FramesByTag returns the frames with the tag or with one of its subtags
 func (q *defaultStoreQuery) FramesByTag(id string) []*model.Frame {
-	frames, _ := q.store.FindFrames(func(f *model.Frame) (bool, error) {
-		return false, nil
-	})
-	return frames
+	return q.store.FramesByTag(append([]string{id}, q.CollectSubtagIDs(id)...)...)
+}
+
+func (q *defaultStoreQuery) FramesInRange(start, end *time.Time) model.FrameList {
+	return q.store.FramesInRange(start, end)
 }
 
 func (q *defaultStoreQuery) ActiveFrames() []*model.Frame {
-	frames, _ := q.store.FindFrames(func(f *model.Frame) (bool, error) {
-		return f.IsActive(), nil
+	return q.store.ActiveFrames()
+}
+
+func (q *defaultStoreQuery) ClientByID(id string) (*model.Client, error) {
+	client, err := q.store.FindFirstClient(func(c *model.Client) bool {
+		return c.ID == id
 	})
-	return frames
+	if err != nil {
+		return nil, fmt.Errorf("no client found for id %s", id)
+	}
+	return client, nil
 }
 
+func (q *defaultStoreQuery) ClientByName(name string) (*model.Client, error) {
+	client, err := q.store.FindFirstClient(func(c *model.Client) bool {
+		return c.Name == name
+	})
+	if err != nil {
+		return nil, fmt.Errorf("no client found for name %s", name)
+	}
+	return client, nil
+}
+
+func (q *defaultStoreQuery) ClientByNameOrID(nameOrID string) (*model.Client, error) {
+	if client, err := q.ClientByID(nameOrID); err == nil {
+		return client, nil
+	}
+	return q.ClientByName(nameOrID)
+}
+
+// ProjectClient returns the client of the project or of its nearest parent project with a client
+func (q *defaultStoreQuery) ProjectClient(projectID string) (*model.Client, error) {
+	var clientID string
+
+	q.WithProjectAndParents(projectID, func(project *model.Project) bool {
+		clientID = project.ClientID
+		return clientID == ""
+	})
+
+	if clientID == "" {
+		return nil, fmt.Errorf("no client available")
+	}
+	return q.ClientByID(clientID)
+}
+
+// HourlyRate returns the hourly rate of the project or of its nearest parent project with a rate.
+// The rate of the client of the project is used if no project defines a rate.
 func (q *defaultStoreQuery) HourlyRate(projectID string) (*money.Money, error) {
 	var result *money.Money
 
@@ -281,6 +404,12 @@ func (q *defaultStoreQuery) HourlyRate(projectID string) (*money.Money, error) {
 		return result == nil
 	})
 
+	if result == nil {
+		if client, err := q.ProjectClient(projectID); err == nil {
+			result = client.HourlyRate
+		}
+	}
+
 	if result == nil {
 		return nil, fmt.Errorf("no hourly rate available")
 	}
@@ -300,3 +429,47 @@ func (q *defaultStoreQuery) IsNoteRequired(projectID string) (*bool, error) {
 	}
 	return result, nil
 }
+
+// IsBillable returns the billable flag of the project or of its nearest parent project with a billable flag
+func (q *defaultStoreQuery) IsBillable(projectID string) (*bool, error) {
+	var result *bool
+
+	q.WithProjectAndParents(projectID, func(project *model.Project) bool {
+		result = project.IsBillable()
+		return result == nil
+	})
+
+	if result == nil {
+		return nil, fmt.Errorf("no billable property available")
+	}
+	return result, nil
+}
+
+// IsFrameBillable returns if the time of the frame is billable. The flag of the frame overrides the flag of the project hierarchy.
+// Time is billable if neither the frame nor a project defines the flag.
+func (q *defaultStoreQuery) IsFrameBillable(frame *model.Frame) bool {
+	if frame.Billable != nil {
+		return *frame.Billable
+	}
+	if billable, err := q.IsBillable(frame.ProjectId); err == nil {
+		return *billable
+	}
+	return true
+}
+
+// Budget returns the budget of the project or of its nearest parent project with a budget. The project, which defines the budget, is returned as well.
+func (q *defaultStoreQuery) Budget(projectID string) (*model.Budget, *model.Project, error) {
+	var result *model.Budget
+	var owner *model.Project
+
+	q.WithProjectAndParents(projectID, func(project *model.Project) bool {
+		result = project.Budget()
+		owner = project
+		return result == nil
+	})
+
+	if result == nil {
+		return nil, nil, fmt.Errorf("no budget available")
+	}
+	return result, owner, nil
+}
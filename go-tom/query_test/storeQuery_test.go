@@ -51,3 +51,26 @@ func Test_RecentlyTracked(t *testing.T) {
 	assert.EqualValues(t, p2.ID, recent[1].ID, "expected the only project")
 	assert.EqualValues(t, p1.ID, recent[2].ID, "expected the only project")
 }
+
+func Test_TagsByName(t *testing.T) {
+	ctx, err := test_setup.CreateTestContext(language.English)
+	require.NoError(t, err)
+	defer test_setup.CleanupTestContext(ctx)
+
+	meeting, err := ctx.Store.AddTag(model.Tag{Name: "meeting"})
+	require.NoError(t, err)
+	client, err := ctx.Store.AddTag(model.Tag{Name: "client", ParentID: meeting.ID})
+	require.NoError(t, err)
+
+	tags, err := ctx.Query.TagsByName("meeting/client", "meeting")
+	require.NoError(t, err)
+	assert.Len(t, tags, 2)
+
+	tag, err := ctx.Query.TagByName("meeting/client")
+	require.NoError(t, err)
+	assert.EqualValues(t, client.ID, tag.ID)
+	assert.EqualValues(t, "meeting/client", ctx.Query.TagFullName(client))
+
+	_, err = ctx.Query.TagsByName("client")
+	assert.Error(t, err, "subtags are referenced by the full name")
+}
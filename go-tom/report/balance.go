@@ -0,0 +1,198 @@
+package report
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jansorg/tom/go-tom/context"
+	"github.com/jansorg/tom/go-tom/dateTime"
+	"github.com/jansorg/tom/go-tom/model"
+	"github.com/jansorg/tom/go-tom/worktime"
+)
+
+// BalanceEntry compares the tracked time of a date range with the target time
+type BalanceEntry struct {
+	DateRange dateTime.DateRange `json:"date_range"`
+	Tracked   time.Duration      `json:"tracked"`
+	Target    time.Duration      `json:"target"`
+	// Balance is the overtime account at the end of the date range,
+	// i.e. the difference of the tracked and the target time since the first day of the targets
+	Balance time.Duration `json:"balance"`
+}
+
+// Difference returns the overtime of the date range, it's negative if less time than the target was tracked
+func (e *BalanceEntry) Difference() time.Duration {
+	return e.Tracked - e.Target
+}
+
+// Balance is the overtime account in a date range
+type Balance struct {
+	// Start is the first day of the overtime account
+	Start   time.Time       `json:"start"`
+	Entries []*BalanceEntry `json:"entries"`
+	// Initial is the overtime account at the start of the date range, Final is the account at its end
+	Initial time.Duration `json:"initial"`
+	Final   time.Duration `json:"final"`
+}
+
+// NewBalance compares the tracked time of the frames with the targets in the date range, one entry is returned for each day, week or month.
+// The overtime account starts at the first day of the targets or at the first day of the frames, if a target has no first day.
+// Days after refTime aren't counted and active frames are counted until refTime. The frames must be sorted by start.
+func NewBalance(ctx *context.TomContext, targets *worktime.Targets, frames model.FrameList, dateRange dateTime.DateRange, split SplitOperation, refTime time.Time) (*Balance, error) {
+	if targets.Empty() {
+		return nil, fmt.Errorf("no targets defined")
+	}
+
+	lastDay := dateTime.NewDayRange(refTime, ctx.Locale, time.Local)
+	if dateRange.End == nil || dateRange.End.After(*lastDay.End) {
+		dateRange.End = lastDay.End
+	}
+
+	var start time.Time
+	if first := targets.Start(); first != nil {
+		start = *first
+	} else if !frames.Empty() {
+		start = *frames.First().Start
+	} else if dateRange.Start != nil {
+		start = *dateRange.Start
+	} else {
+		start = refTime
+	}
+	start = *dateTime.NewDayRange(start, ctx.Locale, time.Local).Start
+	if dateRange.Start == nil {
+		dateRange.Start = &start
+	} else {
+		dateRange.Start = dateTime.NewDayRange(*dateRange.Start, ctx.Locale, time.Local).Start
+	}
+
+	balance := &Balance{Start: start}
+	if !dateRange.Start.Before(*dateRange.End) {
+		return balance, nil
+	}
+
+	entries, err := balanceEntries(ctx, dateRange, split)
+	if err != nil {
+		return nil, err
+	}
+	balance.Entries = entries
+
+	tracked := dailyTracked(ctx, frames, refTime)
+	accountRange := dateTime.NewDateRange(&start, dateRange.End, ctx.Locale)
+	if dateRange.Start.Before(start) {
+		accountRange.Start = dateRange.Start
+	}
+
+	var account time.Duration
+	next := 0
+	for _, day := range accountRange.Days(time.Local) {
+		if !day.Start.Before(*accountRange.End) {
+			// the end of a date range is exclusive
+			break
+		}
+
+		var dayTracked, dayTarget time.Duration
+		if sum, ok := tracked[*day.Start]; ok {
+			dayTracked = sum.GetExact()
+		}
+		if !day.Start.Before(start) {
+			dayTarget = targets.Duration(*day.Start)
+			account += dayTracked - dayTarget
+		}
+
+		if day.Start.Before(*dateRange.Start) {
+			balance.Initial = account
+			continue
+		}
+
+		for next < len(entries) && !day.Start.Before(*entries[next].DateRange.End) {
+			next++
+		}
+		if next < len(entries) {
+			entries[next].Tracked += dayTracked
+			entries[next].Target += dayTarget
+			entries[next].Balance = account
+		}
+	}
+	balance.Final = account
+	return balance, nil
+}
+
+// Tracked returns the tracked time of all entries
+func (b *Balance) Tracked() time.Duration {
+	var result time.Duration
+	for _, e := range b.Entries {
+		result += e.Tracked
+	}
+	return result
+}
+
+// Target returns the target time of all entries
+func (b *Balance) Target() time.Duration {
+	var result time.Duration
+	for _, e := range b.Entries {
+		result += e.Target
+	}
+	return result
+}
+
+// balanceEntries returns the empty entries of the date range. The first and the last entry are cut to the date range.
+func balanceEntries(ctx *context.TomContext, dateRange dateTime.DateRange, split SplitOperation) ([]*BalanceEntry, error) {
+	var ranges []dateTime.DateRange
+	switch split {
+	case SplitByDay:
+		ranges = dateRange.Days(time.Local)
+	case SplitByWeek:
+		ranges = dateRange.Weeks(time.Local)
+	case SplitByMonth:
+		ranges = dateRange.Months(time.Local)
+	default:
+		return nil, fmt.Errorf("unsupported split of a balance. Possible values: day,week,month")
+	}
+
+	var result []*BalanceEntry
+	for _, r := range ranges {
+		if !r.Start.Before(*dateRange.End) {
+			break
+		}
+
+		start, end := *r.Start, *r.End
+		if start.Before(*dateRange.Start) {
+			start = *dateRange.Start
+		}
+		if end.After(*dateRange.End) {
+			end = *dateRange.End
+		}
+		result = append(result, &BalanceEntry{DateRange: dateTime.NewDateRange(&start, &end, ctx.Locale)})
+	}
+	return result, nil
+}
+
+// dailyTracked returns the tracked time of each day, the key is the start of a day. The breaks of the frames aren't counted.
+func dailyTracked(ctx *context.TomContext, frames model.FrameList, refTime time.Time) map[time.Time]*dateTime.DurationSum {
+	result := map[time.Time]*dateTime.DurationSum{}
+	for _, frame := range frames {
+		if frame.Start == nil || frame.Start.After(refTime) {
+			continue
+		}
+
+		end := refTime
+		if frame.End != nil && frame.End.Before(refTime) {
+			end = *frame.End
+		}
+
+		for _, day := range dateTime.NewDateRange(frame.Start, &end, ctx.Locale).Days(time.Local) {
+			if !day.Start.Before(end) {
+				break
+			}
+
+			sum, ok := result[*day.Start]
+			if !ok {
+				day := day
+				sum = dateTime.NewDurationSumFiltered(&day, &refTime)
+				result[*day.Start] = sum
+			}
+			sum.AddStartEndExcludingP(frame.Start, &end, frame.BreakRanges())
+		}
+	}
+	return result
+}
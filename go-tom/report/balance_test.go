@@ -0,0 +1,67 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+
+	"github.com/jansorg/tom/go-tom/dateTime"
+	"github.com/jansorg/tom/go-tom/model"
+	"github.com/jansorg/tom/go-tom/test_setup"
+	"github.com/jansorg/tom/go-tom/worktime"
+)
+
+func TestBalance(t *testing.T) {
+	ctx, err := test_setup.CreateTestContext(language.German)
+	require.NoError(t, err)
+	defer test_setup.CleanupTestContext(ctx)
+
+	// 40h per week, 8h on each day from Monday to Friday, since Monday, 4th March 2019
+	targets := &worktime.Targets{Targets: []worktime.Target{{WeeklyHours: 40, From: newLocalDate(2019, time.March, 4, 0, 0)}}}
+
+	frames := model.NewEmptyFrameList()
+	// 9h on Monday, 7h on Tuesday, 8h on Wednesday with a break of 1h, 2h on Saturday
+	frames.Append(&model.Frame{Start: newLocalDate(2019, time.March, 4, 8, 0), End: newLocalDate(2019, time.March, 4, 17, 0)})
+	frames.Append(&model.Frame{Start: newLocalDate(2019, time.March, 5, 8, 0), End: newLocalDate(2019, time.March, 5, 15, 0)})
+	frames.Append(&model.Frame{Start: newLocalDate(2019, time.March, 6, 8, 0), End: newLocalDate(2019, time.March, 6, 17, 0),
+		Breaks: []model.FrameBreak{{Start: newLocalDate(2019, time.March, 6, 12, 0), End: newLocalDate(2019, time.March, 6, 13, 0)}}})
+	frames.Append(&model.Frame{Start: newLocalDate(2019, time.March, 9, 10, 0), End: newLocalDate(2019, time.March, 9, 12, 0)})
+
+	// Thursday is the last counted day
+	refTime := *newLocalDate(2019, time.March, 7, 12, 0)
+
+	dateRange := dateTime.NewDateRange(newLocalDate(2019, time.March, 5, 0, 0), newLocalDate(2019, time.March, 10, 0, 0), ctx.Locale)
+	balance, err := NewBalance(ctx, targets, *frames, dateRange, SplitByDay, refTime)
+	require.NoError(t, err)
+
+	// the overtime of Monday is carried forward
+	assert.EqualValues(t, 1*time.Hour, balance.Initial)
+	require.Len(t, balance.Entries, 3)
+	assert.EqualValues(t, 7*time.Hour, balance.Entries[0].Tracked)
+	assert.EqualValues(t, 8*time.Hour, balance.Entries[0].Target)
+	assert.EqualValues(t, -1*time.Hour, balance.Entries[0].Difference())
+	assert.EqualValues(t, 0, balance.Entries[0].Balance)
+	assert.EqualValues(t, 8*time.Hour, balance.Entries[1].Tracked, "the break isn't counted")
+	assert.EqualValues(t, 0, balance.Entries[1].Balance)
+	assert.EqualValues(t, 0, balance.Entries[2].Tracked)
+	assert.EqualValues(t, -8*time.Hour, balance.Entries[2].Balance)
+	assert.EqualValues(t, -8*time.Hour, balance.Final)
+
+	// the whole week, the time of Saturday is overtime
+	refTime = *newLocalDate(2019, time.March, 10, 12, 0)
+	dateRange = dateTime.NewDateRange(nil, nil, ctx.Locale)
+	balance, err = NewBalance(ctx, targets, *frames, dateRange, SplitByWeek, refTime)
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, balance.Initial)
+	require.Len(t, balance.Entries, 2)
+	// the account starts at the first day of the targets, weeks start on Sunday
+	assert.EqualValues(t, *newLocalDate(2019, time.March, 4, 0, 0), *balance.Entries[0].DateRange.Start)
+	assert.EqualValues(t, 26*time.Hour, balance.Entries[0].Tracked)
+	assert.EqualValues(t, 40*time.Hour, balance.Entries[0].Target)
+	assert.EqualValues(t, -14*time.Hour, balance.Entries[0].Balance)
+	assert.EqualValues(t, 0, balance.Entries[1].Target)
+	assert.EqualValues(t, -14*time.Hour, balance.Final)
+}
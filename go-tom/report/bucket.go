@@ -19,13 +19,20 @@ type ResultBucket struct {
 	dateRange        dateTime.DateRange
 	trackedDateRange dateTime.DateRange
 
-	Frames       *model.FrameList      `json:"-"`
-	FrameCount   int                   `json:"frameCount"`
-	Duration     *dateTime.DurationSum `json:"duration"`
-	Sales        *Sales                `json:"sales"`
-	SplitByType  SplitOperation        `json:"split_type,omitempty"`
-	SplitBy      interface{}           `json:"split_by,omitempty"`
-	ChildBuckets []*ResultBucket       `json:"results,omitempty"`
+	Frames     *model.FrameList `json:"-"`
+	FrameCount int              `json:"frameCount"`
+	// EditedFrameCount is the number of frames, which were changed after they had been stopped. It's only counted if MarkEdited is enabled.
+	EditedFrameCount int                   `json:"editedFrameCount,omitempty"`
+	Duration         *dateTime.DurationSum `json:"duration"`
+	Sales            *Sales                `json:"sales"`
+	// BillableDuration and NonBillableDuration split Duration by the billable flag of the frames.
+	// Utilization is the share of the billable duration in percent.
+	BillableDuration    *dateTime.DurationSum `json:"billable_duration"`
+	NonBillableDuration *dateTime.DurationSum `json:"non_billable_duration"`
+	Utilization         float64               `json:"utilization"`
+	SplitByType         SplitOperation        `json:"split_type,omitempty"`
+	SplitBy             interface{}           `json:"split_by,omitempty"`
+	ChildBuckets        []*ResultBucket       `json:"results,omitempty"`
 
 	DailyTracked   dateTime.TimeEntrySeries `json:"daily_tracked"`
 	DailyUnTracked dateTime.TimeEntrySeries `json:"daily_untracked"`
@@ -34,20 +41,41 @@ type ResultBucket struct {
 func (b *ResultBucket) Update() {
 	b.Sales = NewSales(b.ctx, b.config.EntryRounding)
 
+	b.BillableDuration = dateTime.NewEmptyCopy(b.Duration)
+	b.NonBillableDuration = dateTime.NewEmptyCopy(b.Duration)
+
 	b.FrameCount = b.Frames.Size()
+	b.EditedFrameCount = 0
 	for _, f := range b.Frames.Frames() {
-		b.Duration.AddStartEndP(f.Start, f.End)
+		breaks := f.BreakRanges()
+		b.Duration.AddStartEndExcludingP(f.Start, f.End, breaks)
+		if b.ctx.Query.IsFrameBillable(f) {
+			b.BillableDuration.AddStartEndExcludingP(f.Start, f.End, breaks)
+		} else {
+			b.NonBillableDuration.AddStartEndExcludingP(f.Start, f.End, breaks)
+		}
+		if b.config.MarkEdited && f.EditedAfterStop() {
+			b.EditedFrameCount++
+		}
 
 		if !f.IsActive() {
-			if b.DailyTracked != nil {
-				b.DailyTracked.Add(*f.Start, *f.End)
-			}
-			if b.DailyUnTracked != nil {
-				b.DailyUnTracked.Add(*f.Start, *f.End)
+			// the breaks split a frame into several tracked ranges
+			for _, r := range f.WorkRanges(nil) {
+				if b.DailyTracked != nil {
+					b.DailyTracked.Add(*r.Start, *r.End)
+				}
+				if b.DailyUnTracked != nil {
+					b.DailyUnTracked.Add(*r.Start, *r.End)
+				}
 			}
 		}
 	}
 
+	b.Utilization = 0
+	if total := b.Duration.Get(); total > 0 {
+		b.Utilization = float64(b.BillableDuration.Get()) / float64(total) * 100
+	}
+
 	if b.dateRange.Empty() {
 		if !b.Empty() && !b.IsDateBucket() {
 			childBuckets := b.ChildBuckets
@@ -254,7 +282,7 @@ func (b *ResultBucket) Title() string {
 
 			// Title of a Tag
 			if t, ok := value.(*model.Tag); ok {
-				return fmt.Sprintf("#%s", t.Name)
+				return fmt.Sprintf("#%s", b.ctx.Query.TagFullName(t))
 			}
 		}
 	}
@@ -273,6 +301,16 @@ func (b *ResultBucket) Title() string {
 	return ""
 }
 
+// Tag returns the tag, which was used to split the bucket. It returns nil if the bucket wasn't split by a tag.
+func (b *ResultBucket) Tag() *model.Tag {
+	if id, ok := b.SplitBy.(string); ok {
+		if t, err := b.ctx.Query.TagByID(id); err == nil {
+			return t
+		}
+	}
+	return nil
+}
+
 func (b *ResultBucket) MatrixTitle() string {
 	if b.SplitByType == SplitByMonth && b.IsDateBucket() && b.parent == nil || b.parent.dateRange.IsYearRange() {
 		return b.ctx.Locale.MonthWide(b.dateRange.Start.Month())
@@ -326,6 +364,71 @@ func (b *ResultBucket) SplitByProjectID(splitType SplitOperation, splitValue fun
 	}
 }
 
+// FieldValue is the value of a custom field, which was used to split a bucket. Value is empty for frames without the field.
+type FieldValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func (v FieldValue) String() string {
+	if v.Value == "" {
+		return fmt.Sprintf("%s: –", v.Name)
+	}
+	return fmt.Sprintf("%s: %s", v.Name, v.Value)
+}
+
+// SplitByField splits the frames by the value of the custom field, frames without the field are collected in a separate bucket
+func (b *ResultBucket) SplitByField(splitType SplitOperation, name string) {
+	splitValue := func(frame *model.Frame) interface{} {
+		return FieldValue{Name: name, Value: frame.Field(name)}
+	}
+
+	for _, frameSubset := range b.Frames.Split(splitValue) {
+		b.AddChild(&ResultBucket{
+			Frames:         frameSubset,
+			Duration:       dateTime.NewEmptyCopy(b.Duration),
+			DailyTracked:   dateTime.NewTrackedDaily(nil),
+			DailyUnTracked: dateTime.NewUntrackedDaily(nil),
+			SplitByType:    splitType,
+			SplitBy:        splitValue(frameSubset.First()),
+		})
+	}
+}
+
+// ClientValue is the client, which was used to split a bucket. ID and Name are empty for frames of projects without a client.
+type ClientValue struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func (v ClientValue) String() string {
+	if v.ID == "" {
+		return "–"
+	}
+	return v.Name
+}
+
+// SplitByClient splits the frames by the client of their projects, frames of projects without a client are collected in a separate bucket
+func (b *ResultBucket) SplitByClient(splitType SplitOperation) {
+	splitValue := func(frame *model.Frame) interface{} {
+		if client, err := b.ctx.Query.ProjectClient(frame.ProjectId); err == nil {
+			return ClientValue{ID: client.ID, Name: client.Name}
+		}
+		return ClientValue{}
+	}
+
+	for _, frameSubset := range b.Frames.Split(splitValue) {
+		b.AddChild(&ResultBucket{
+			Frames:         frameSubset,
+			Duration:       dateTime.NewEmptyCopy(b.Duration),
+			DailyTracked:   dateTime.NewTrackedDaily(nil),
+			DailyUnTracked: dateTime.NewUntrackedDaily(nil),
+			SplitByType:    splitType,
+			SplitBy:        splitValue(frameSubset.First()),
+		})
+	}
+}
+
 func (b *ResultBucket) SplitByDateRange(splitType SplitOperation) {
 	b.ChildBuckets = []*ResultBucket{}
 
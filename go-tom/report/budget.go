@@ -0,0 +1,234 @@
+package report
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jansorg/tom/go-tom/context"
+	"github.com/jansorg/tom/go-tom/dateTime"
+	"github.com/jansorg/tom/go-tom/model"
+	"github.com/jansorg/tom/go-tom/money"
+)
+
+// BudgetStatus is the consumption of a project budget. The frames of the project, which defines the budget,
+// and of its subprojects without a budget are counted. Active frames are counted until the reference time.
+type BudgetStatus struct {
+	Project *model.Project `json:"-"`
+	Budget  *model.Budget  `json:"budget"`
+	// Period is the month of a monthly budget. It's nil for a total budget.
+	Period *dateTime.DateRange `json:"period,omitempty"`
+	// Tracked is the tracked time, Sales are the sales in the currency of the budget money.
+	// Sales in other currencies aren't counted. Sales is nil if the budget doesn't define money.
+	Tracked time.Duration `json:"tracked"`
+	Sales   *money.Money  `json:"sales,omitempty"`
+}
+
+// NewBudgetStatus returns the status of the budget, which applies to the project. A budget is inherited from the parent projects.
+// The status of a monthly budget is the status of the month of refTime.
+func NewBudgetStatus(ctx *context.TomContext, projectID string, refTime time.Time) (*BudgetStatus, error) {
+	budget, owner, err := ctx.Query.Budget(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	var period *dateTime.DateRange
+	if budget.IsMonthly() {
+		month := dateTime.NewMonthRange(refTime, ctx.Locale, time.Local)
+		period = &month
+	}
+
+	status := newBudgetStatus(owner, budget, period)
+	status.add(ctx, budgetFrames(ctx, owner), refTime)
+	return status, nil
+}
+
+// BudgetBurnDown is the consumption of a project budget in each month of a date range
+type BudgetBurnDown struct {
+	Project *model.Project `json:"-"`
+	Budget  *model.Budget  `json:"budget"`
+	// Months contains the status at the end of each month. The values of a total budget are accumulated since the first frame of the project,
+	// the values of a monthly budget are the values of each month.
+	Months []*BudgetStatus `json:"months"`
+}
+
+// NewBudgetBurnDowns returns the burn-downs of the budgets, which apply to the projects. The burn-down of a budget is only returned once,
+// even if it applies to more than one of the projects. All projects, which define a budget, are used if projectIDs is empty.
+// The date range of the tracked frames is used if the date range is open.
+func NewBudgetBurnDowns(ctx *context.TomContext, projectIDs []string, dateRange dateTime.DateRange, refTime time.Time) []*BudgetBurnDown {
+	if len(projectIDs) == 0 {
+		for _, p := range ctx.Store.Projects() {
+			if p.Budget() != nil {
+				projectIDs = append(projectIDs, p.ID)
+			}
+		}
+	}
+
+	var result []*BudgetBurnDown
+	added := map[string]bool{}
+	for _, id := range projectIDs {
+		burnDown, err := NewBudgetBurnDown(ctx, id, dateRange, refTime)
+		if err != nil || burnDown == nil || added[burnDown.Project.ID] {
+			continue
+		}
+		added[burnDown.Project.ID] = true
+		result = append(result, burnDown)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return strings.Compare(result[i].Project.GetFullName("/"), result[j].Project.GetFullName("/")) < 0
+	})
+	return result
+}
+
+// NewBudgetBurnDown returns the burn-down of the budget, which applies to the project.
+// The date range of the tracked frames is used if the date range is open. Nil is returned if no frames were tracked for an open date range.
+func NewBudgetBurnDown(ctx *context.TomContext, projectID string, dateRange dateTime.DateRange, refTime time.Time) (*BudgetBurnDown, error) {
+	budget, owner, err := ctx.Query.Budget(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	frames := budgetFrames(ctx, owner)
+	if dateRange.Start == nil || dateRange.End == nil {
+		if frames.Empty() {
+			return nil, nil
+		}
+		trackedRange := frames.DateRange(ctx.Locale)
+		if dateRange.Start == nil {
+			dateRange.Start = trackedRange.Start
+		}
+		if dateRange.End == nil {
+			dateRange.End = trackedRange.End
+		}
+	}
+
+	result := &BudgetBurnDown{Project: owner, Budget: budget}
+	var total *BudgetStatus
+	for _, month := range dateRange.Months(time.Local) {
+		if !month.Start.Before(*dateRange.End) {
+			// the end of a date range is exclusive
+			break
+		}
+
+		month := month
+		status := newBudgetStatus(owner, budget, &month)
+		status.add(ctx, frames, refTime)
+
+		if !budget.IsMonthly() {
+			if total == nil {
+				// the values before the first month of the date range
+				total = newBudgetStatus(owner, budget, nil)
+				total.add(ctx, frames, month.Start.Add(-time.Nanosecond))
+			}
+			total.Tracked += status.Tracked
+			if total.Sales != nil {
+				_ = total.Sales.Add(status.Sales)
+			}
+
+			accumulated := *total
+			accumulated.Period = &month
+			if total.Sales != nil {
+				accumulated.Sales = money.NewMoney(total.Sales.Amount(), total.Sales.CurrencyCode())
+			}
+			status = &accumulated
+		}
+		result.Months = append(result.Months, status)
+	}
+	return result, nil
+}
+
+// budgetFrames returns the frames counted by the budget of owner.
+// Subprojects with their own budget and their subprojects are counted by their own budget.
+func budgetFrames(ctx *context.TomContext, owner *model.Project) model.FrameList {
+	owners := map[string]string{}
+	frames := ctx.Query.FramesByProject(owner.ID, true)
+	frames.Filter(func(frame *model.Frame) bool {
+		id, ok := owners[frame.ProjectId]
+		if !ok {
+			if _, budgetOwner, err := ctx.Query.Budget(frame.ProjectId); err == nil {
+				id = budgetOwner.ID
+			}
+			owners[frame.ProjectId] = id
+		}
+		return id == owner.ID
+	})
+	return frames
+}
+
+func newBudgetStatus(project *model.Project, budget *model.Budget, period *dateTime.DateRange) *BudgetStatus {
+	status := &BudgetStatus{Project: project, Budget: budget, Period: period}
+	if budget.Money != nil {
+		status.Sales = money.NewMoney(0, budget.Money.CurrencyCode())
+	}
+	return status
+}
+
+// add adds the tracked time and the sales of the frames in the period, which started before refTime. The breaks of the frames aren't counted.
+func (s *BudgetStatus) add(ctx *context.TomContext, frames model.FrameList, refTime time.Time) {
+	for _, frame := range frames {
+		if frame.Start == nil || frame.Start.After(refTime) {
+			continue
+		}
+
+		var duration time.Duration
+		for _, r := range frame.WorkRanges(&refTime) {
+			if r.Start.After(refTime) {
+				continue
+			}
+
+			end := refTime
+			if r.End.Before(refTime) {
+				end = *r.End
+			}
+
+			if s.Period != nil {
+				duration += s.Period.Intersection(r.Start, &end)
+			} else {
+				duration += end.Sub(*r.Start)
+			}
+		}
+		if duration <= 0 {
+			continue
+		}
+
+		s.Tracked += duration
+		if s.Sales != nil && ctx.Query.IsFrameBillable(frame) {
+			if rate, err := ctx.Query.HourlyRate(frame.ProjectId); err == nil && rate.CurrencyCode() == s.Sales.CurrencyCode() {
+				_ = s.Sales.Add(rate.Multiple(duration.Hours()))
+			}
+		}
+	}
+}
+
+// RemainingDuration returns the remaining hours of the budget, it's negative if the budget is exceeded
+func (s *BudgetStatus) RemainingDuration() time.Duration {
+	return s.Budget.Duration() - s.Tracked
+}
+
+// RemainingMoney returns the remaining money of the budget, it's nil if the budget doesn't define money
+func (s *BudgetStatus) RemainingMoney() *money.Money {
+	if s.Budget.Money == nil {
+		return nil
+	}
+	return money.NewMoney(s.Budget.Money.Amount()-s.Sales.Amount(), s.Budget.Money.CurrencyCode())
+}
+
+// UsedPercentage returns the consumed percentage of the budget. It's the higher value of hours and money if both are defined.
+func (s *BudgetStatus) UsedPercentage() float64 {
+	var result float64
+	if s.Budget.Hours > 0 {
+		result = float64(s.Tracked) / float64(s.Budget.Duration()) * 100
+	}
+	if s.Budget.Money != nil && s.Budget.Money.Amount() > 0 {
+		result = math.Max(result, float64(s.Sales.Amount())/float64(s.Budget.Money.Amount())*100)
+	}
+	return result
+}
+
+// IsExceeded returns if more hours or more money than defined by the budget were used
+func (s *BudgetStatus) IsExceeded() bool {
+	return s.Budget.Hours > 0 && s.RemainingDuration() < 0 ||
+		s.Budget.Money != nil && s.RemainingMoney().Amount() < 0
+}
@@ -0,0 +1,134 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+
+	"github.com/jansorg/tom/go-tom/dateTime"
+	"github.com/jansorg/tom/go-tom/model"
+	"github.com/jansorg/tom/go-tom/money"
+	"github.com/jansorg/tom/go-tom/test_setup"
+)
+
+func TestBudgetStatus(t *testing.T) {
+	ctx, err := test_setup.CreateTestContext(language.German)
+	require.NoError(t, err)
+	defer test_setup.CleanupTestContext(ctx)
+
+	top, _, err := ctx.StoreHelper.GetOrCreateNestedProjectNames("top")
+	require.NoError(t, err)
+	child, _, err := ctx.StoreHelper.GetOrCreateNestedProjectNames("top", "child")
+	require.NoError(t, err)
+
+	top.SetHourlyRate(money.NewMoney(10000, "EUR"))
+	top.SetBudget(&model.Budget{Hours: 10, Money: money.NewMoney(50000, "EUR")})
+	_, err = ctx.Store.UpdateProject(*top)
+	require.NoError(t, err)
+
+	_, err = ctx.Store.AddFrame(model.Frame{Start: newLocalDate(2019, time.March, 10, 10, 0), End: newLocalDate(2019, time.March, 10, 12, 0), ProjectId: top.ID})
+	require.NoError(t, err)
+	_, err = ctx.Store.AddFrame(model.Frame{Start: newLocalDate(2019, time.April, 10, 10, 0), End: newLocalDate(2019, time.April, 10, 14, 0), ProjectId: child.ID})
+	require.NoError(t, err)
+
+	// the budget of the parent project applies to the subproject and counts the frames of both projects
+	status, err := NewBudgetStatus(ctx, child.ID, *newLocalDate(2019, time.May, 1, 0, 0))
+	require.NoError(t, err)
+	assert.EqualValues(t, top.ID, status.Project.ID)
+	assert.Nil(t, status.Period)
+	assert.EqualValues(t, 6*time.Hour, status.Tracked)
+	assert.EqualValues(t, 4*time.Hour, status.RemainingDuration())
+	assert.EqualValues(t, money.NewMoney(60000, "EUR"), status.Sales)
+	assert.EqualValues(t, -10000, status.RemainingMoney().Amount())
+	assert.EqualValues(t, 120.0, status.UsedPercentage())
+	assert.True(t, status.IsExceeded(), "the money of the budget is exceeded")
+
+	// frames after the reference time aren't counted
+	status, err = NewBudgetStatus(ctx, child.ID, *newLocalDate(2019, time.April, 10, 11, 0))
+	require.NoError(t, err)
+	assert.EqualValues(t, 3*time.Hour, status.Tracked)
+	assert.False(t, status.IsExceeded())
+
+	// a monthly budget only counts the frames of the month of the reference time
+	top.SetBudget(&model.Budget{Hours: 3, Period: model.BudgetMonthly})
+	_, err = ctx.Store.UpdateProject(*top)
+	require.NoError(t, err)
+
+	status, err = NewBudgetStatus(ctx, top.ID, *newLocalDate(2019, time.April, 20, 0, 0))
+	require.NoError(t, err)
+	require.NotNil(t, status.Period)
+	assert.EqualValues(t, 4*time.Hour, status.Tracked)
+	assert.Nil(t, status.Sales)
+	assert.Nil(t, status.RemainingMoney())
+	assert.True(t, status.IsExceeded())
+
+	// a budget of the subproject overrides the budget of the parent
+	child.SetBudget(&model.Budget{Hours: 5})
+	_, err = ctx.Store.UpdateProject(*child)
+	require.NoError(t, err)
+
+	status, err = NewBudgetStatus(ctx, child.ID, *newLocalDate(2019, time.May, 1, 0, 0))
+	require.NoError(t, err)
+	assert.EqualValues(t, child.ID, status.Project.ID)
+	assert.EqualValues(t, 4*time.Hour, status.Tracked)
+	assert.False(t, status.IsExceeded())
+
+	// the frames of a subproject with its own budget aren't counted by the budget of the parent
+	status, err = NewBudgetStatus(ctx, top.ID, *newLocalDate(2019, time.April, 20, 0, 0))
+	require.NoError(t, err)
+	assert.EqualValues(t, top.ID, status.Project.ID)
+	assert.EqualValues(t, 0, status.Tracked)
+
+	status, err = NewBudgetStatus(ctx, top.ID, *newLocalDate(2019, time.March, 20, 0, 0))
+	require.NoError(t, err)
+	assert.EqualValues(t, 2*time.Hour, status.Tracked)
+}
+
+func TestBudgetBurnDown(t *testing.T) {
+	ctx, err := test_setup.CreateTestContext(language.German)
+	require.NoError(t, err)
+	defer test_setup.CleanupTestContext(ctx)
+
+	top, _, err := ctx.StoreHelper.GetOrCreateNestedProjectNames("top")
+	require.NoError(t, err)
+	_, _, err = ctx.StoreHelper.GetOrCreateNestedProjectNames("other")
+	require.NoError(t, err)
+
+	top.SetBudget(&model.Budget{Hours: 10})
+	_, err = ctx.Store.UpdateProject(*top)
+	require.NoError(t, err)
+
+	for _, month := range []time.Month{time.February, time.March, time.March, time.May} {
+		_, err = ctx.Store.AddFrame(model.Frame{Start: newLocalDate(2019, month, 10, 10, 0), End: newLocalDate(2019, month, 10, 12, 0), ProjectId: top.ID})
+		require.NoError(t, err)
+	}
+
+	refTime := *newLocalDate(2020, time.January, 1, 0, 0)
+	dateRange := dateTime.NewDateRange(newLocalDate(2019, time.March, 1, 0, 0), newLocalDate(2019, time.June, 1, 0, 0), ctx.Locale)
+
+	burnDowns := NewBudgetBurnDowns(ctx, nil, dateRange, refTime)
+	require.Len(t, burnDowns, 1, "only projects with a budget must be returned")
+
+	// the values of a total budget are accumulated, including the frames before the date range
+	months := burnDowns[0].Months
+	require.Len(t, months, 3)
+	assert.EqualValues(t, 6*time.Hour, months[0].Tracked)
+	assert.EqualValues(t, 6*time.Hour, months[1].Tracked)
+	assert.EqualValues(t, 8*time.Hour, months[2].Tracked)
+	assert.EqualValues(t, 2*time.Hour, months[2].RemainingDuration())
+
+	top.SetBudget(&model.Budget{Hours: 3, Period: model.BudgetMonthly})
+	_, err = ctx.Store.UpdateProject(*top)
+	require.NoError(t, err)
+
+	burnDown, err := NewBudgetBurnDown(ctx, top.ID, dateRange, refTime)
+	require.NoError(t, err)
+	require.Len(t, burnDown.Months, 3)
+	assert.EqualValues(t, 4*time.Hour, burnDown.Months[0].Tracked)
+	assert.True(t, burnDown.Months[0].IsExceeded())
+	assert.EqualValues(t, 0, burnDown.Months[1].Tracked)
+	assert.EqualValues(t, 2*time.Hour, burnDown.Months[2].Tracked)
+}
@@ -68,14 +68,14 @@ type ProjectSummary struct {
 
 func (p *ProjectSummary) add(frame *model.Frame) {
 	for _, r := range p.trackedAll {
-		r.AddStartEndP(frame.Start, frame.End)
+		r.AddStartEndExcludingP(frame.Start, frame.End, frame.BreakRanges())
 	}
 	p.addTotal(frame)
 }
 
 func (p *ProjectSummary) addTotal(frame *model.Frame) {
 	for _, r := range p.totalTrackedAll {
-		r.AddStartEndP(frame.Start, frame.End)
+		r.AddStartEndExcludingP(frame.Start, frame.End, frame.BreakRanges())
 	}
 }
 
@@ -60,6 +60,10 @@ func (b *BucketReport) Update() *ResultBucket {
 		}
 	}
 
+	if len(b.config.Fields) > 0 {
+		b.source.Filter(b.config.Fields.Matches)
+	}
+
 	// we need to filter our source by project ID
 	if len(projectIDs) > 0 {
 		b.source.Filter(func(frame *model.Frame) bool {
@@ -89,6 +93,7 @@ func (b *BucketReport) Update() *ResultBucket {
 		DailyUnTracked: dateTime.NewUntrackedDaily(nil),
 	}
 
+	fieldSplits := 0
 	for _, op := range b.config.Splitting {
 		if op.IsDateSplit() {
 			b.result.WithLeafBuckets(func(leaf *ResultBucket) {
@@ -110,6 +115,20 @@ func (b *BucketReport) Update() *ResultBucket {
 					}
 				}, projectIDs)
 			})
+		} else if op == SplitByField {
+			if fieldSplits >= len(b.config.SplitFields) {
+				util.Fatal(fmt.Errorf("no field name defined for split operation %d", fieldSplits+1))
+			}
+			name := b.config.SplitFields[fieldSplits]
+			fieldSplits++
+
+			b.result.WithLeafBuckets(func(leaf *ResultBucket) {
+				leaf.SplitByField(op, name)
+			})
+		} else if op == SplitByClient {
+			b.result.WithLeafBuckets(func(leaf *ResultBucket) {
+				leaf.SplitByClient(op)
+			})
 		} else {
 			util.Fatal(fmt.Errorf("unknown split operation %d", op))
 		}
@@ -2,17 +2,23 @@ package report
 
 import (
 	"github.com/jansorg/tom/go-tom/dateTime"
+	"github.com/jansorg/tom/go-tom/model"
 )
 
+// Config defines the frames of a report and how they're split into buckets.
+// SplitFields contains the names of the custom fields, which are used by the SplitByField operations of Splitting in the same order.
 type Config struct {
 	Splitting          []SplitOperation        `json:"split"`
+	SplitFields        []string                `json:"split_fields,omitempty"`
 	TimezoneName       TimezoneName            `json:"timezone"`
 	ProjectIDs         []string                `json:"projects"`
+	Fields             model.FieldFilter       `json:"fields,omitempty"`
 	ProjectDelimiter   string                  `json:"project_delimiter"`
 	IncludeSubprojects bool                    `json:"show_subprojects"`
 	DateFilterRange    dateTime.DateRange      `json:"date_range"`
 	ShowEmpty          bool                    `json:"show_empty"`
 	ShowStopTime       bool                    `json:"show_stop_time"`
+	MarkEdited         bool                    `json:"mark_edited"`
 	IncludeArchived    bool                    `json:"include_archived"`
 	ShortTitles        bool                    `json:"short_titles"`
 	EntryRounding      dateTime.RoundingConfig `json:"rounding_entry"`
@@ -13,6 +13,7 @@ import (
 	"github.com/jansorg/tom/go-tom/model"
 	"github.com/jansorg/tom/go-tom/money"
 	"github.com/jansorg/tom/go-tom/test_setup"
+	"github.com/jansorg/tom/go-tom/util"
 )
 
 func TestSplitEmptyReport(t *testing.T) {
@@ -479,6 +480,159 @@ func TestReportWithoutArchived(t *testing.T) {
 	assert.EqualValues(t, 10*time.Hour, report.result.Duration.SumExact)
 }
 
+func TestReportSplitByField(t *testing.T) {
+	ctx, err := test_setup.CreateTestContext(language.German)
+	require.NoError(t, err)
+	defer test_setup.CleanupTestContext(ctx)
+
+	p1, _, err := ctx.StoreHelper.GetOrCreateNestedProjectNames("project1")
+	require.NoError(t, err)
+
+	start := newLocalDate(2017, time.May, 10, 10, 0)
+	end := newLocalDate(2017, time.May, 10, 12, 0)
+
+	frames := model.NewEmptyFrameList()
+	for _, ticket := range []string{"PROJ-1", "PROJ-1", "PROJ-2", ""} {
+		frame := &model.Frame{Start: start, End: end, ProjectId: p1.ID}
+		frame.SetField("ticket", ticket)
+		frame.SetField("location", "onsite")
+		frames.Append(frame)
+	}
+
+	report := NewBucketReport(frames.Copy(), Config{
+		Splitting:   []SplitOperation{SplitByProject, SplitByField},
+		SplitFields: []string{"ticket"},
+	}, ctx)
+	report.Update()
+	require.Len(t, report.result.ChildBuckets, 1)
+
+	tickets := report.result.ChildBuckets[0].ChildBuckets
+	require.Len(t, tickets, 3)
+	assert.EqualValues(t, "ticket: PROJ-1", tickets[0].Title())
+	assert.EqualValues(t, 4*time.Hour, tickets[0].Duration.SumExact)
+	assert.EqualValues(t, "ticket: PROJ-2", tickets[1].Title())
+	assert.EqualValues(t, "ticket: –", tickets[2].Title(), "frames without the field must be kept")
+
+	report = NewBucketReport(frames.Copy(), Config{Fields: model.FieldFilter{"ticket": "PROJ-1", "location": ""}}, ctx)
+	report.Update()
+	assert.EqualValues(t, 2, report.result.FrameCount)
+}
+
+func TestReportSplitByClient(t *testing.T) {
+	ctx, err := test_setup.CreateTestContext(language.German)
+	require.NoError(t, err)
+	defer test_setup.CleanupTestContext(ctx)
+
+	acme, err := ctx.Store.AddClient(model.Client{Name: "ACME", HourlyRate: money.NewMoney(80*100, "EUR")})
+	require.NoError(t, err)
+
+	// the subproject inherits the client, its own rate takes precedence over the rate of the client
+	pTop, _, err := ctx.StoreHelper.GetOrCreateNestedProjectNames("acme")
+	require.NoError(t, err)
+	pTop.ClientID = acme.ID
+	_, err = ctx.Store.UpdateProject(*pTop)
+	require.NoError(t, err)
+	pChild, _, err := ctx.StoreHelper.GetOrCreateNestedProjectNames("acme", "web")
+	require.NoError(t, err)
+	pChild.SetHourlyRate(money.NewMoney(100*100, "EUR"))
+	pOther, _, err := ctx.StoreHelper.GetOrCreateNestedProjectNames("internal")
+	require.NoError(t, err)
+
+	start := newLocalDate(2017, time.May, 10, 10, 0)
+	end := newLocalDate(2017, time.May, 10, 12, 0)
+
+	frames := model.NewEmptyFrameList()
+	for _, id := range []string{pTop.ID, pChild.ID, pOther.ID} {
+		frames.Append(&model.Frame{Start: start, End: end, ProjectId: id})
+	}
+
+	report := NewBucketReport(frames.Copy(), Config{Splitting: []SplitOperation{SplitByClient}}, ctx)
+	report.Update()
+
+	require.Len(t, report.result.ChildBuckets, 2)
+	report.result.SortChildBuckets()
+	clients := report.result.ChildBuckets
+
+	assert.EqualValues(t, "ACME", clients[0].Title())
+	assert.EqualValues(t, 4*time.Hour, clients[0].Duration.SumExact)
+	// 2 hours * 80 EUR of the client + 2 hours * 100 EUR of the subproject
+	assert.EqualValues(t, "€360.00", clients[0].Sales.values["EUR"].String())
+
+	assert.EqualValues(t, "–", clients[1].Title(), "frames without a client must be kept")
+	assert.EqualValues(t, 2*time.Hour, clients[1].Duration.SumExact)
+	assert.Empty(t, clients[1].Sales.values)
+}
+
+func TestReportBillable(t *testing.T) {
+	ctx, err := test_setup.CreateTestContext(language.German)
+	require.NoError(t, err)
+	defer test_setup.CleanupTestContext(ctx)
+
+	// the internal subproject isn't billable, but a single frame of it is
+	pTop, _, err := ctx.StoreHelper.GetOrCreateNestedProjectNames("acme")
+	require.NoError(t, err)
+	pTop.SetHourlyRate(money.NewMoney(100*100, "EUR"))
+	pInternal, _, err := ctx.StoreHelper.GetOrCreateNestedProjectNames("acme", "internal")
+	require.NoError(t, err)
+	pInternal.SetBillable(util.FalseP())
+
+	start := newLocalDate(2017, time.May, 10, 10, 0)
+	end := newLocalDate(2017, time.May, 10, 12, 0)
+
+	frames := model.NewEmptyFrameList()
+	frames.Append(&model.Frame{Start: start, End: end, ProjectId: pTop.ID})
+	frames.Append(&model.Frame{Start: start, End: end, ProjectId: pTop.ID, Billable: util.FalseP()})
+	frames.Append(&model.Frame{Start: start, End: end, ProjectId: pInternal.ID})
+	frames.Append(&model.Frame{Start: start, End: end, ProjectId: pInternal.ID, Billable: util.TrueP()})
+
+	report := NewBucketReport(frames.Copy(), Config{Splitting: []SplitOperation{SplitByProject}}, ctx)
+	report.Update()
+
+	result := report.result
+	assert.EqualValues(t, 8*time.Hour, result.Duration.SumExact)
+	assert.EqualValues(t, 4*time.Hour, result.BillableDuration.SumExact)
+	assert.EqualValues(t, 4*time.Hour, result.NonBillableDuration.SumExact)
+	assert.EqualValues(t, 50, result.Utilization)
+	// only the billable frames are counted as sales
+	assert.EqualValues(t, "€400.00", result.Sales.values["EUR"].String())
+
+	// the frames of subprojects are reported in the bucket of the top-level project
+	require.Len(t, result.ChildBuckets, 1)
+	assert.EqualValues(t, 4*time.Hour, result.ChildBuckets[0].NonBillableDuration.SumExact)
+	assert.EqualValues(t, 50, result.ChildBuckets[0].Utilization)
+}
+
+func TestReportBreaks(t *testing.T) {
+	ctx, err := test_setup.CreateTestContext(language.German)
+	require.NoError(t, err)
+	defer test_setup.CleanupTestContext(ctx)
+
+	p, _, err := ctx.StoreHelper.GetOrCreateNestedProjectNames("acme")
+	require.NoError(t, err)
+	p.SetHourlyRate(money.NewMoney(100*100, "EUR"))
+
+	// 4h with a break of 1h, the break is untracked time
+	breakStart := newLocalDate(2017, time.May, 10, 12, 0)
+	breakEnd := newLocalDate(2017, time.May, 10, 13, 0)
+	frames := model.NewEmptyFrameList()
+	frames.Append(&model.Frame{
+		Start:     newLocalDate(2017, time.May, 10, 10, 0),
+		End:       newLocalDate(2017, time.May, 10, 14, 0),
+		ProjectId: p.ID,
+		Breaks:    []model.FrameBreak{{Start: breakStart, End: breakEnd}},
+	})
+
+	report := NewBucketReport(frames.Copy(), Config{}, ctx)
+	report.Update()
+
+	result := report.result
+	assert.EqualValues(t, 3*time.Hour, result.Duration.SumExact)
+	assert.EqualValues(t, 3*time.Hour, result.BillableDuration.SumExact)
+	assert.EqualValues(t, "€300.00", result.Sales.values["EUR"].String())
+	assert.EqualValues(t, 3*time.Hour, result.DailyTracked.Total())
+	assert.EqualValues(t, 1*time.Hour, result.DailyUnTracked.Total())
+}
+
 func TestSalesStats(t *testing.T) {
 	ctx, err := test_setup.CreateTestContext(language.German)
 	require.NoError(t, err)
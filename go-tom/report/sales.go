@@ -24,7 +24,12 @@ type Sales struct {
 	sumRounding   dateTime.RoundingConfig
 }
 
+// Add adds the sales of the frame. Frames, which aren't billable, are ignored.
 func (s *Sales) Add(frame *model.Frame) error {
+	if !s.ctx.Query.IsFrameBillable(frame) {
+		return nil
+	}
+
 	hourlyRate, err := s.ctx.Query.HourlyRate(frame.ProjectId)
 	if err == nil {
 		duration := frame.Duration()
@@ -13,6 +13,8 @@ const (
 	SplitByWeek
 	SplitByDay
 	SplitByProject
+	SplitByField
+	SplitByClient
 )
 
 func SplitOperationByName(name string) (SplitOperation, error) {
@@ -27,6 +29,10 @@ func SplitOperationByName(name string) (SplitOperation, error) {
 		return SplitByDay, nil
 	case "project":
 		return SplitByProject, nil
+	case "field":
+		return SplitByField, nil
+	case "client":
+		return SplitByClient, nil
 	default:
 		return 0, fmt.Errorf("unknown split operation %s", name)
 	}
@@ -49,6 +55,10 @@ func (s SplitOperation) String() string {
 		name = "day"
 	case SplitByProject:
 		name = "project"
+	case SplitByField:
+		name = "field"
+	case SplitByClient:
+		name = "client"
 	}
 	return name
 }
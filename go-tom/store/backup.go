@@ -0,0 +1,299 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jansorg/tom/go-tom/model"
+	"github.com/jansorg/tom/go-tom/util"
+)
+
+const backupInfoFile = "backup.json"
+const backupDirPrefix = "tom-"
+
+// snapshots of the states undone by Undo are kept in this subdirectory of the backup directory
+const redoDirName = "redo"
+
+// BackupInfo describes a backup of the data directory. It's stored in the backup directory.
+type BackupInfo struct {
+	Created time.Time `json:"created"`
+	// Command is the command line which modified the data after the backup was created
+	Command string `json:"command,omitempty"`
+	// Session identifies the store which created the backup, all backups of one invocation of tom share the same session
+	Session string `json:"session,omitempty"`
+	// Label is the name of a backup created by CreateBackup. Labeled backups are never removed automatically.
+	Label string `json:"label,omitempty"`
+	// Origin identifies the data directory, which created the backup. Backups of synced directories are told apart by it.
+	Origin string `json:"origin,omitempty"`
+
+	Dir string `json:"-"`
+}
+
+// Name returns the name of the backup directory
+func (b BackupInfo) Name() string {
+	return filepath.Base(b.Dir)
+}
+
+// snapshotter is implemented by the stores which support backups
+type snapshotter interface {
+	replacer
+	createSnapshot(parentDir string, info BackupInfo) (string, error)
+	lockExclusive() (func(), error)
+}
+
+// fileStager is implemented by the stores, which write additional files of the data directory with their data, e.g. the cold archive.
+// The file is written with the next commit of Update, a nil value of data removes the file.
+type fileStager interface {
+	stageFile(name string, data []byte)
+}
+
+func newBackupDir(parentDir string, created time.Time) (string, error) {
+	dir := filepath.Join(parentDir, fmt.Sprintf("%s%s", backupDirPrefix, created.Format(time.RFC3339Nano)))
+	if _, err := os.Stat(dir); err == nil {
+		return "", fmt.Errorf("backup directory already exists: %s", dir)
+	}
+	return dir, os.MkdirAll(dir, 0700)
+}
+
+// backupOrigin returns the origin of the backups of the data directory dir, the host name and the path of the directory.
+// The ID of a data directory can't be stored in the directory itself because a file-sync tool would copy it.
+func backupOrigin(dir string) string {
+	host, _ := os.Hostname()
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	return host + ":" + dir
+}
+
+// writeBackupInfo writes the info file of a backup, it's encrypted if c isn't nil
+func writeBackupInfo(dir string, info BackupInfo, c *dataCipher) error {
+	data, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+	return c.writeFile(filepath.Join(dir, backupInfoFile), data)
+}
+
+func readBackupInfo(dir string, c *dataCipher) BackupInfo {
+	info := BackupInfo{Dir: dir}
+	if c, err := c.forDir(dir); err == nil {
+		if data, err := c.readFile(filepath.Join(dir, backupInfoFile)); err == nil {
+			_ = json.Unmarshal(data, &info)
+		}
+	}
+
+	// backups of older versions of tom don't contain the info file
+	if info.Created.IsZero() {
+		info.Created, _ = time.Parse(time.RFC3339Nano, strings.TrimPrefix(filepath.Base(dir), backupDirPrefix))
+	}
+	return info
+}
+
+// ListBackups returns the backups of s, sorted from oldest to newest
+func ListBackups(s model.Store) ([]BackupInfo, error) {
+	return listBackups(s.BackupDirPath(), storeCipher(s))
+}
+
+// listBackups returns the backups in dir, the info files of encrypted backups are decrypted with c
+func listBackups(dir string, c *dataCipher) ([]BackupInfo, error) {
+	if dir == "" {
+		return nil, nil
+	}
+
+	infos, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var result []BackupInfo
+	for _, info := range infos {
+		if info.IsDir() && strings.HasPrefix(info.Name(), backupDirPrefix) {
+			result = append(result, readBackupInfo(filepath.Join(dir, info.Name()), c))
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Created.Before(result[j].Created)
+	})
+	return result, nil
+}
+
+// FindBackup returns the backup of s with the given directory name or label
+func FindBackup(s model.Store, nameOrLabel string) (BackupInfo, error) {
+	backups, err := ListBackups(s)
+	if err != nil {
+		return BackupInfo{}, err
+	}
+
+	for _, backup := range backups {
+		if backup.Name() == nameOrLabel || backup.Label != "" && backup.Label == nameOrLabel {
+			return backup, nil
+		}
+	}
+	return BackupInfo{}, fmt.Errorf("backup %s not found", nameOrLabel)
+}
+
+// automaticBackups returns the backups without label
+func automaticBackups(backups []BackupInfo) []BackupInfo {
+	var result []BackupInfo
+	for _, backup := range backups {
+		if backup.Label == "" {
+			result = append(result, backup)
+		}
+	}
+	return result
+}
+
+// sessions groups the backups by the session, which created them. Backups of older versions without session are a group of their own.
+func sessions(backups []BackupInfo) [][]BackupInfo {
+	var result [][]BackupInfo
+	for i, backup := range backups {
+		if i > 0 && backup.Session != "" && backup.Session == backups[i-1].Session {
+			result[len(result)-1] = append(result[len(result)-1], backup)
+		} else {
+			result = append(result, []BackupInfo{backup})
+		}
+	}
+	return result
+}
+
+// removeOldBackups removes the oldest backups without label in backupPath until at most maxBackups are left.
+// The backups of a session are removed together, Undo restores the oldest backup of a session. The newest session is always kept.
+func removeOldBackups(backupPath string, maxBackups int, c *dataCipher) {
+	if maxBackups <= 0 {
+		return
+	}
+
+	backups, err := listBackups(backupPath, c)
+	if err != nil {
+		return
+	}
+
+	backups = automaticBackups(backups)
+	if len(backups) <= maxBackups {
+		return
+	}
+
+	count := len(backups)
+	groups := sessions(backups)
+	for _, group := range groups[:len(groups)-1] {
+		if count <= maxBackups {
+			break
+		}
+		for _, backup := range group {
+			_ = os.RemoveAll(backup.Dir)
+		}
+		count -= len(group)
+	}
+}
+
+// CreateBackup creates a backup of the current data of s with the given label
+func CreateBackup(s model.Store, label string) (BackupInfo, error) {
+	target, ok := s.(snapshotter)
+	if !ok {
+		return BackupInfo{}, fmt.Errorf("the store doesn't support backups")
+	}
+	if s.BackupDirPath() == "" {
+		return BackupInfo{}, fmt.Errorf("no backup directory is configured")
+	}
+	if strings.TrimSpace(label) == "" {
+		return BackupInfo{}, fmt.Errorf("the label must not be empty")
+	}
+	if _, err := FindBackup(s, label); err == nil {
+		return BackupInfo{}, fmt.Errorf("a backup with label %s already exists", label)
+	}
+
+	if err := os.MkdirAll(s.BackupDirPath(), 0700); err != nil {
+		return BackupInfo{}, err
+	}
+	dir, err := target.createSnapshot(s.BackupDirPath(), BackupInfo{Label: label})
+	if err != nil {
+		return BackupInfo{}, err
+	}
+	return readBackupInfo(dir, storeCipher(s)), nil
+}
+
+// RestoreBackup replaces the data of s with the data of the backup in dir.
+// A backup of the current data is created first, the restore can be reverted by Undo.
+func RestoreBackup(s model.Store, dir string) error {
+	backup, err := OpenBackup(s, dir)
+	if err != nil {
+		return err
+	}
+	defer CloseStore(backup)
+
+	if err := copyData(backup, s, true); err != nil {
+		return err
+	}
+	return restoreDataFiles(s, dir)
+}
+
+// OpenBackup returns a store with the data of the backup of s in dir.
+// The passphrase of s is used to decrypt an encrypted backup.
+// Stores which keep resources open implement io.Closer.
+func OpenBackup(s model.Store, dir string) (model.Store, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return nil, err
+	}
+
+	if fileExists(filepath.Join(dir, sqliteFileName)) {
+		return newSQLiteStore(Options{Dir: dir})
+	}
+	// backups are never used by other processes
+	var passphrase string
+	if c := storeCipher(s); c != nil {
+		passphrase = c.passphrase
+	}
+	return newDataStore(Options{Dir: dir, Passphrase: passphrase, noLock: true})
+}
+
+// CloseStore releases the resources of s, if there are any
+func CloseStore(s model.Store) {
+	if closer, ok := s.(io.Closer); ok {
+		_ = closer.Close()
+	}
+}
+
+// restoreBackup replaces the data of target with the data of the backup in dir.
+// No backup of the current data of target is created.
+func restoreBackup(target model.Store, dir string) error {
+	backup, err := OpenBackup(target, dir)
+	if err != nil {
+		return err
+	}
+	defer CloseStore(backup)
+
+	if err := copyData(backup, target, false); err != nil {
+		return err
+	}
+	return restoreDataFiles(target, dir)
+}
+
+func copyFiles(files []string, targetDir string, link bool) error {
+	for _, sourceFile := range files {
+		if !fileExists(sourceFile) {
+			continue
+		}
+
+		target := filepath.Join(targetDir, filepath.Base(sourceFile))
+		var err error
+		if link {
+			err = util.LinkOrCopyFile(sourceFile, target)
+		} else {
+			err = util.CopyFile(sourceFile, target, false)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
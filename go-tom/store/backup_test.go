@@ -0,0 +1,75 @@
+package store_test
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jansorg/tom/go-tom/model"
+	"github.com/jansorg/tom/go-tom/store"
+)
+
+func Test_LabeledBackups(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tom")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	backupDir, err := ioutil.TempDir("", "tom-backup")
+	require.NoError(t, err)
+	defer os.RemoveAll(backupDir)
+
+	s, err := store.NewStore(dir, backupDir, 2)
+	require.NoError(t, err)
+
+	_, err = s.AddProject(model.Project{Name: "first"})
+	require.NoError(t, err)
+
+	labeled, err := store.CreateBackup(s, "before")
+	require.NoError(t, err)
+	assert.EqualValues(t, "before", labeled.Label)
+
+	_, err = store.CreateBackup(s, "before")
+	assert.Error(t, err, "labels must be unique")
+
+	// each project is added by another command
+	for _, name := range []string{"a", "b", "c", "d"} {
+		s, err = store.NewStore(dir, backupDir, 2)
+		require.NoError(t, err)
+		_, err = s.AddProject(model.Project{Name: name})
+		require.NoError(t, err)
+	}
+
+	backups, err := store.ListBackups(s)
+	require.NoError(t, err)
+	assert.Len(t, backups, 3, "the labeled backup must not be removed")
+
+	found, err := store.FindBackup(s, "before")
+	require.NoError(t, err)
+	assert.EqualValues(t, labeled.Dir, found.Dir)
+
+	found, err = store.FindBackup(s, labeled.Name())
+	require.NoError(t, err)
+	assert.EqualValues(t, labeled.Dir, found.Dir)
+
+	backup, err := store.OpenBackup(s, found.Dir)
+	require.NoError(t, err)
+	changes := store.Diff(backup, s)
+	assert.Len(t, changes, 4)
+	for _, change := range changes {
+		assert.EqualValues(t, store.ChangeAdded, change.Type)
+	}
+
+	// a new command
+	s, err = store.NewStore(dir, backupDir, 2)
+	require.NoError(t, err)
+	require.NoError(t, store.RestoreBackup(s, found.Dir))
+	assert.Len(t, s.Projects(), 1)
+
+	// the restore can be reverted
+	_, _, err = store.Undo(s)
+	require.NoError(t, err)
+	assert.Len(t, s.Projects(), 5)
+}
@@ -0,0 +1,254 @@
+package store
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jansorg/tom/go-tom/model"
+)
+
+// archived frames, which were moved out of the store, are kept in this compressed file of the data directory
+const coldArchiveFileName = "archive.json.gz"
+
+// MoveToColdArchive moves the archived frames into the cold archive and removes them from s.
+// Frames, which aren't archived, are skipped. The cold archive is written with the commit of the removal,
+// the backup of the commit contains the previous cold archive and the removal is reverted by Undo.
+// It returns the number of moved frames.
+func MoveToColdArchive(s model.Store, frames []*model.Frame) (int, error) {
+	stager, err := coldArchiveStager(s)
+	if err != nil {
+		return 0, err
+	}
+
+	var moved []*model.Frame
+	for _, f := range frames {
+		if f.Archived {
+			moved = append(moved, copyFrame(f))
+		}
+	}
+	if len(moved) == 0 {
+		return 0, nil
+	}
+
+	err = s.Update(func(tx model.Store) error {
+		cold, err := readColdArchive(tx)
+		if err != nil {
+			return err
+		}
+		existing := map[string]bool{}
+		for _, f := range cold {
+			existing[f.ID] = true
+		}
+		for _, f := range moved {
+			if !existing[f.ID] {
+				cold = append(cold, f)
+			}
+		}
+
+		data, err := encodeColdArchive(cold)
+		if err != nil {
+			return err
+		}
+		stager.stageFile(coldArchiveFileName, data)
+
+		ids := make([]string, len(moved))
+		for i, f := range moved {
+			ids[i] = f.ID
+		}
+		if removesAll(ids, frameIDSet(tx)) {
+			_, _, _, err := tx.Reset(false, false, true)
+			return err
+		}
+
+		for _, id := range ids {
+			if err := tx.RemoveFrame(id); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(moved), nil
+}
+
+// ColdArchiveFrames returns the frames of the cold archive intersecting the range. A nil value of start or end is unbounded.
+// Frames, which are also stored in s, e.g. after an interrupted move into the cold archive, are skipped.
+// A store without data directory doesn't have a cold archive.
+func ColdArchiveFrames(s model.Store, start, end *time.Time) (model.FrameList, error) {
+	if s.DirPath() == "" {
+		return nil, nil
+	}
+
+	frames, err := readColdArchive(s)
+	if err != nil {
+		return nil, err
+	}
+
+	result := model.FrameList{}
+	for _, f := range frames {
+		if frameInRange(f, start, end) {
+			result = append(result, f)
+		}
+	}
+	if len(result) == 0 {
+		return result, nil
+	}
+
+	stored := map[string]bool{}
+	for _, f := range s.Frames() {
+		stored[f.ID] = true
+	}
+	filtered := model.FrameList{}
+	for _, f := range result {
+		if !stored[f.ID] {
+			filtered = append(filtered, f)
+		}
+	}
+	return filtered, nil
+}
+
+// RestoreFromColdArchive moves the frames with the given IDs from the cold archive back into s.
+// The IDs and the archived flag of the frames are kept. Frames, which exist in s, e.g. after an undo, are only removed from the cold archive.
+// Like MoveToColdArchive, the cold archive is written with the commit of the restored frames.
+// It returns the number of restored frames.
+func RestoreFromColdArchive(s model.Store, ids []string) (int, error) {
+	stager, err := coldArchiveStager(s)
+	if err != nil {
+		return 0, err
+	}
+	target, ok := s.(restorer)
+	if !ok {
+		return 0, fmt.Errorf("the store doesn't support to restore values")
+	}
+
+	added := 0
+	err = s.Update(func(tx model.Store) error {
+		cold, err := readColdArchive(tx)
+		if err != nil {
+			return err
+		}
+
+		selected := map[string]bool{}
+		for _, id := range ids {
+			selected[id] = true
+		}
+
+		var restored, remaining []*model.Frame
+		for _, f := range cold {
+			if selected[f.ID] {
+				restored = append(restored, f)
+				delete(selected, f.ID)
+			} else {
+				remaining = append(remaining, f)
+			}
+		}
+		for id := range selected {
+			return fmt.Errorf("frame %s not found in the cold archive", id)
+		}
+
+		for _, f := range restored {
+			if _, err := tx.FrameByID(f.ID); err == nil {
+				continue
+			}
+			if _, err := tx.ProjectByID(f.ProjectId); err != nil {
+				return fmt.Errorf("project %s of frame %s not found", f.ProjectId, f.ID)
+			}
+			if _, err := target.addFrame(*f); err != nil {
+				return err
+			}
+			added++
+		}
+
+		// an empty cold archive is removed
+		var data []byte
+		if len(remaining) > 0 {
+			if data, err = encodeColdArchive(remaining); err != nil {
+				return err
+			}
+		}
+		stager.stageFile(coldArchiveFileName, data)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return added, nil
+}
+
+// coldArchiveStager returns the store, which writes the cold archive with its next commit
+func coldArchiveStager(s model.Store) (fileStager, error) {
+	stager, ok := s.(fileStager)
+	if !ok || s.DirPath() == "" {
+		return nil, fmt.Errorf("the store doesn't support a cold archive")
+	}
+	return stager, nil
+}
+
+func coldArchivePath(s model.Store) (string, error) {
+	if s.DirPath() == "" {
+		return "", fmt.Errorf("the store doesn't support a cold archive")
+	}
+	return filepath.Join(s.DirPath(), coldArchiveFileName), nil
+}
+
+func readColdArchive(s model.Store) ([]*model.Frame, error) {
+	path, err := coldArchivePath(s)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := storeCipher(s).readFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	frames, err := decodeColdArchive(data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read cold archive %s: %s", path, err.Error())
+	}
+	return frames, nil
+}
+
+func encodeColdArchive(frames []*model.Frame) ([]byte, error) {
+	data, err := json.Marshal(frames)
+	if err != nil {
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+	writer := gzip.NewWriter(&buffer)
+	if _, err := writer.Write(data); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func decodeColdArchive(data []byte) ([]*model.Frame, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	if data, err = ioutil.ReadAll(reader); err != nil {
+		return nil, err
+	}
+	var frames []*model.Frame
+	if err := json.Unmarshal(data, &frames); err != nil {
+		return nil, err
+	}
+	return frames, nil
+}
@@ -0,0 +1,134 @@
+package store_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jansorg/tom/go-tom/model"
+	"github.com/jansorg/tom/go-tom/store"
+)
+
+func Test_ColdArchive(t *testing.T) {
+	for _, options := range []store.Options{{Format: store.FormatJSON}, {Format: store.FormatJSON, Journal: true}, {Format: store.FormatSQLite}} {
+		t.Run(options.Format, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "tom-cold")
+			require.NoError(t, err)
+			defer os.RemoveAll(dir)
+
+			options.Dir = dir
+			options.BackupDir = filepath.Join(dir, "backup")
+			s, err := store.Open(options)
+			require.NoError(t, err)
+			defer store.CloseStore(s)
+
+			p, err := s.AddProject(model.Project{Name: "acme"})
+			require.NoError(t, err)
+			start := time.Date(2019, 1, 1, 10, 0, 0, 0, time.UTC)
+			end := start.Add(time.Hour)
+			archived, err := s.AddFrame(model.Frame{ProjectId: p.ID, Start: &start, End: &end, Archived: true, Notes: "archived"})
+			require.NoError(t, err)
+			later := end.Add(24 * time.Hour)
+			live, err := s.AddFrame(model.Frame{ProjectId: p.ID, Start: &end, End: &later})
+			require.NoError(t, err)
+
+			moved, err := store.MoveToColdArchive(s, s.Frames())
+			require.NoError(t, err)
+			assert.EqualValues(t, 1, moved, "only archived frames must be moved")
+			require.Len(t, s.Frames(), 1)
+			assert.EqualValues(t, live.ID, s.Frames()[0].ID)
+			assert.FileExists(t, filepath.Join(dir, "archive.json.gz"))
+
+			cold, err := store.ColdArchiveFrames(s, nil, nil)
+			require.NoError(t, err)
+			require.Len(t, cold, 1)
+			assert.EqualValues(t, "archived", cold[0].Notes)
+			cold, err = store.ColdArchiveFrames(s, &later, nil)
+			require.NoError(t, err)
+			assert.Empty(t, cold)
+
+			_, err = store.RestoreFromColdArchive(s, []string{"unknown"})
+			assert.Error(t, err)
+			restored, err := store.RestoreFromColdArchive(s, []string{archived.ID})
+			require.NoError(t, err)
+			assert.EqualValues(t, 1, restored)
+			frame := findFrame(t, s, archived.ID)
+			assert.True(t, frame.Archived, "the ID and the archived flag must be kept")
+			assert.NoFileExists(t, filepath.Join(dir, "archive.json.gz"))
+		})
+	}
+}
+
+func Test_ColdArchiveUndo(t *testing.T) {
+	for _, options := range []store.Options{{Format: store.FormatJSON}, {Format: store.FormatJSON, Journal: true}, {Format: store.FormatSQLite}} {
+		t.Run(options.Format, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "tom-cold")
+			require.NoError(t, err)
+			defer os.RemoveAll(dir)
+
+			options.Dir = dir
+			options.BackupDir = filepath.Join(dir, "backup")
+			s, err := store.Open(options)
+			require.NoError(t, err)
+			defer store.CloseStore(s)
+
+			p, err := s.AddProject(model.Project{Name: "acme"})
+			require.NoError(t, err)
+			start := time.Date(2019, 1, 1, 10, 0, 0, 0, time.UTC)
+			end := start.Add(time.Hour)
+			archived, err := s.AddFrame(model.Frame{ProjectId: p.ID, Start: &start, End: &end, Archived: true})
+			require.NoError(t, err)
+
+			// the move is a new command, undo must not revert the setup
+			store.CloseStore(s)
+			s, err = store.Open(options)
+			require.NoError(t, err)
+			defer store.CloseStore(s)
+
+			moved, err := store.MoveToColdArchive(s, s.Frames())
+			require.NoError(t, err)
+			require.EqualValues(t, 1, moved)
+			require.Empty(t, s.Frames())
+
+			_, _, err = store.Undo(s)
+			require.NoError(t, err)
+			require.Len(t, s.Frames(), 1)
+			assert.EqualValues(t, archived.ID, s.Frames()[0].ID)
+			cold, err := store.ColdArchiveFrames(s, nil, nil)
+			require.NoError(t, err)
+			assert.Empty(t, cold, "undo must revert the cold archive")
+			assert.NoFileExists(t, filepath.Join(dir, "archive.json.gz"))
+
+			_, _, err = store.Redo(s)
+			require.NoError(t, err)
+			assert.Empty(t, s.Frames())
+			cold, err = store.ColdArchiveFrames(s, nil, nil)
+			require.NoError(t, err)
+			assert.Len(t, cold, 1, "redo must restore the cold archive")
+
+			// the restore is committed with the cold archive and reverted at once
+			store.CloseStore(s)
+			s, err = store.Open(options)
+			require.NoError(t, err)
+			defer store.CloseStore(s)
+
+			restored, err := store.RestoreFromColdArchive(s, []string{archived.ID})
+			require.NoError(t, err)
+			require.EqualValues(t, 1, restored)
+			require.Len(t, s.Frames(), 1)
+			assert.NoFileExists(t, filepath.Join(dir, "archive.json.gz"))
+
+			_, _, err = store.Undo(s)
+			require.NoError(t, err)
+			assert.Empty(t, s.Frames())
+			cold, err = store.ColdArchiveFrames(s, nil, nil)
+			require.NoError(t, err)
+			assert.Len(t, cold, 1, "undo must revert the restore from the cold archive")
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/jansorg/tom/go-tom/model"
+)
+
+// ErrConflict is returned when a frame was updated or removed, which was modified by another process in the meantime
+var ErrConflict = fmt.Errorf("conflicting modification")
+
+// fileState is used to detect modifications of the data files by other processes
+type fileState struct {
+	exists  bool
+	size    int64
+	modTime time.Time
+}
+
+func (d *DataStore) dataFiles() []string {
+	files := []string{d.ProjectFile, d.TagFile, d.ClientFile, d.FrameFile, d.FrameIndexFile, d.JournalFile, d.VersionFile}
+	return append(files, d.shardFiles()...)
+}
+
+func (d *DataStore) statDataFiles() map[string]fileState {
+	result := map[string]fileState{}
+	for _, file := range d.dataFiles() {
+		if info, err := os.Stat(file); err == nil {
+			result[file] = fileState{exists: true, size: info.Size(), modTime: info.ModTime()}
+		} else {
+			result[file] = fileState{}
+		}
+	}
+	return result
+}
+
+// changedOnDiskLocked returns if the data files were modified since they were read or written by this store
+func (d *DataStore) changedOnDiskLocked() bool {
+	current := d.statDataFiles()
+	if len(current) != len(d.fileStates) {
+		// a file of a year was added or removed
+		return true
+	}
+
+	for file, state := range current {
+		previous, ok := d.fileStates[file]
+		if !ok {
+			return true
+		}
+
+		if state.exists != previous.exists || state.size != previous.size || !state.modTime.Equal(previous.modTime) {
+			return true
+		}
+	}
+	return false
+}
+
+func frameVersions(frames []*model.Frame) map[string]*time.Time {
+	result := make(map[string]*time.Time, len(frames))
+	for _, f := range frames {
+		result[f.ID] = f.Updated
+	}
+	return result
+}
+
+// updateFrameVersionsLocked updates the known versions of the frames after the entries were persisted
+func (d *DataStore) updateFrameVersionsLocked(entries []journalEntry) {
+	for _, e := range entries {
+		switch {
+		case e.Action == journalReset && e.Type == journalFrame:
+			d.frameVersions = map[string]*time.Time{}
+		case e.Action == journalRemove && e.Type == journalFrame:
+			delete(d.frameVersions, e.ID)
+		case e.Action == journalPut && e.Type == journalFrame:
+			d.frameVersions[e.ID] = entryVersion(e)
+		}
+	}
+}
+
+// checkConflicts returns an error if one of the entries modifies a frame, which was modified or removed on disk.
+// base contains the versions of the frames when they were read by this process, current the versions on disk.
+func checkConflicts(entries []journalEntry, base, current map[string]*time.Time) error {
+	for _, e := range entries {
+		if e.Type != journalFrame || e.Action == journalReset {
+			continue
+		}
+
+		baseVersion, known := base[e.ID]
+		if !known {
+			// added by this process
+			continue
+		}
+
+		currentVersion, exists := current[e.ID]
+		if !exists {
+			if e.Action == journalRemove {
+				continue
+			}
+			return fmt.Errorf("%w: frame %s was removed by another process", ErrConflict, e.ID)
+		}
+
+		if !sameVersion(baseVersion, currentVersion) {
+			return fmt.Errorf("%w: frame %s was modified by another process", ErrConflict, e.ID)
+		}
+	}
+	return nil
+}
+
+func sameVersion(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Equal(*b)
+}
+
+func entryVersion(e journalEntry) *time.Time {
+	var value struct {
+		Updated *time.Time `json:"updated"`
+	}
+	if err := json.Unmarshal(e.Data, &value); err != nil {
+		return nil
+	}
+	return value.Updated
+}
@@ -0,0 +1,97 @@
+package store_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jansorg/tom/go-tom/model"
+	"github.com/jansorg/tom/go-tom/store"
+)
+
+// two stores of the same directory simulate two processes
+func testConcurrentStores(t *testing.T, options store.Options) {
+	first, err := store.Open(options)
+	require.NoError(t, err)
+
+	p, err := first.AddProject(model.Project{Name: "project"})
+	require.NoError(t, err)
+	start := time.Now().Add(-time.Hour)
+	frame, err := first.AddFrame(model.Frame{ProjectId: p.ID, Start: &start})
+	require.NoError(t, err)
+	other, err := first.AddFrame(model.Frame{ProjectId: p.ID, Start: &start})
+	require.NoError(t, err)
+
+	second, err := store.Open(options)
+	require.NoError(t, err)
+
+	// changes of different entities are both kept
+	_, err = first.AddTag(model.Tag{Name: "first"})
+	require.NoError(t, err)
+	_, err = second.AddTag(model.Tag{Name: "second"})
+	require.NoError(t, err)
+
+	stale, err := second.FindFirstFrame(func(f *model.Frame) bool { return f.ID == frame.ID })
+	require.NoError(t, err)
+	staleOther, err := second.FindFirstFrame(func(f *model.Frame) bool { return f.ID == other.ID })
+	require.NoError(t, err)
+
+	// the first update wins, the stale update of the same frame fails
+	updated := *frame
+	updated.Stop()
+	_, err = first.UpdateFrame(updated)
+	require.NoError(t, err)
+
+	staleCopy := *stale
+	staleCopy.Notes = "stale"
+	_, err = second.UpdateFrame(staleCopy)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, store.ErrConflict), err.Error())
+
+	// the stale frame can't be removed
+	_, err = first.UpdateFrame(*other)
+	require.NoError(t, err)
+	err = second.RemoveFrame(staleOther.ID)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, store.ErrConflict), err.Error())
+
+	reopened, err := store.Open(options)
+	require.NoError(t, err)
+	assert.Len(t, reopened.Tags(), 2, "the tags of both processes must be stored")
+	require.Len(t, reopened.Frames(), 2)
+	for _, f := range reopened.Frames() {
+		assert.Empty(t, f.Notes, "the stale update must not be stored")
+		if f.ID == frame.ID {
+			assert.True(t, f.IsStopped())
+		}
+	}
+}
+
+func Test_ConcurrentDataStores(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tom")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	testConcurrentStores(t, store.Options{Dir: dir, LockTimeout: time.Second})
+}
+
+func Test_ConcurrentJournalStores(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tom")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	testConcurrentStores(t, store.Options{Dir: dir, Journal: true, JournalCompactSize: 100, LockTimeout: time.Second})
+}
+
+func Test_ConcurrentSQLiteStores(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tom")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	testConcurrentStores(t, store.Options{Format: store.FormatSQLite, Dir: dir, LockTimeout: time.Second})
+}
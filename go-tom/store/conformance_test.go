@@ -0,0 +1,524 @@
+package store_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jansorg/tom/go-tom/model"
+	"github.com/jansorg/tom/go-tom/money"
+	"github.com/jansorg/tom/go-tom/store"
+)
+
+// Test_Conformance verifies that all implementations of model.Store share the same behaviour
+func Test_Conformance(t *testing.T) {
+	for _, options := range []store.Options{{Format: store.FormatJSON}, {Format: store.FormatJSON, Journal: true}, {Format: store.FormatSQLite}} {
+		options := options
+		t.Run(fmt.Sprintf("%s journal=%v", options.Format, options.Journal), func(t *testing.T) {
+			testConformance(t, func(t *testing.T) model.Store {
+				dir, err := ioutil.TempDir("", "tom")
+				require.NoError(t, err)
+
+				options.Dir = dir
+				s, err := store.Open(options)
+				require.NoError(t, err)
+				t.Cleanup(func() {
+					store.CloseStore(s)
+					_ = os.RemoveAll(dir)
+				})
+				return s
+			})
+		})
+	}
+
+	t.Run("memory", func(t *testing.T) {
+		testConformance(t, func(t *testing.T) model.Store {
+			return store.NewMemoryStore()
+		})
+	})
+}
+
+func testConformance(t *testing.T, newStore func(t *testing.T) model.Store) {
+	t.Run("projects", func(t *testing.T) {
+		s := newStore(t)
+		assert.Empty(t, s.Projects())
+
+		top, err := s.AddProject(model.Project{Name: "top"})
+		require.NoError(t, err)
+		assert.NotEmpty(t, top.ID, "a new ID must be added to the project")
+		child, err := s.AddProject(model.Project{Name: "child", ParentID: top.ID})
+		require.NoError(t, err)
+		assert.EqualValues(t, "top/child", child.GetFullName("/"))
+		assert.EqualValues(t, top.ID, child.Parent().ID)
+		_, err = s.AddProject(model.Project{Name: "another"})
+		require.NoError(t, err)
+
+		assert.EqualValues(t, []string{"another", "top", "top/child"}, projectNames(s), "projects must be sorted by full name")
+
+		// renaming the parent must update the full name of the child
+		top.Name = "renamed"
+		_, err = s.UpdateProject(*top)
+		require.NoError(t, err)
+		assert.EqualValues(t, []string{"another", "renamed", "renamed/child"}, projectNames(s))
+		child, err = s.ProjectByID(child.ID)
+		require.NoError(t, err)
+		assert.EqualValues(t, "renamed/child", child.GetFullName("/"))
+
+		assert.True(t, s.ProjectIsSameOrChild(top.ID, child.ID))
+		assert.True(t, s.ProjectIsSameOrChild(top.ID, top.ID))
+		assert.False(t, s.ProjectIsSameOrChild(child.ID, top.ID))
+
+		found, err := s.FindFirstProject(func(p *model.Project) bool {
+			return p.Name == "child"
+		})
+		require.NoError(t, err)
+		assert.EqualValues(t, child.ID, found.ID)
+		_, err = s.FindFirstProject(func(p *model.Project) bool {
+			return false
+		})
+		assert.Error(t, err)
+		assert.Len(t, s.FindProjects(func(p *model.Project) bool {
+			return p.ParentID == ""
+		}), 2)
+
+		_, err = s.UpdateProject(model.Project{ID: "unknown", Name: "unknown"})
+		assert.Error(t, err)
+		_, err = s.UpdateProject(model.Project{ID: top.ID})
+		assert.Error(t, err, "a project without name is invalid")
+		_, err = s.ProjectByID("unknown")
+		assert.Error(t, err)
+
+		require.NoError(t, s.RemoveProject(child.ID))
+		assert.Error(t, s.RemoveProject(child.ID))
+		assert.Len(t, s.Projects(), 2)
+	})
+
+	t.Run("tags", func(t *testing.T) {
+		s := newStore(t)
+		assert.Empty(t, s.Tags())
+
+		b, err := s.AddTag(model.Tag{Name: "b"})
+		require.NoError(t, err)
+		assert.NotEmpty(t, b.ID, "a new ID must be added to the tag")
+		_, err = s.AddTag(model.Tag{Name: "a"})
+		require.NoError(t, err)
+		_, err = s.AddTag(model.Tag{Name: " "})
+		assert.Error(t, err, "a tag without name is invalid")
+		assert.EqualValues(t, []string{"a", "b"}, tagNames(s), "tags must be sorted by name")
+
+		b.Name = "0"
+		_, err = s.UpdateTag(*b)
+		require.NoError(t, err)
+		assert.EqualValues(t, []string{"0", "a"}, tagNames(s))
+		_, err = s.UpdateTag(model.Tag{ID: "unknown", Name: "unknown"})
+		assert.Error(t, err)
+
+		found, err := s.FindFirstTag(func(t *model.Tag) bool {
+			return t.Name == "0"
+		})
+		require.NoError(t, err)
+		assert.EqualValues(t, b.ID, found.ID)
+		_, err = s.FindFirstTag(func(t *model.Tag) bool {
+			return false
+		})
+		assert.EqualValues(t, store.ErrTagNotFound, err)
+		assert.Len(t, s.FindTags(func(t *model.Tag) bool {
+			return true
+		}), 2)
+
+		require.NoError(t, s.RemoveTag(b.ID))
+		assert.Error(t, s.RemoveTag(b.ID))
+		assert.EqualValues(t, []string{"a"}, tagNames(s))
+	})
+
+	t.Run("clients", func(t *testing.T) {
+		s := newStore(t)
+		assert.Empty(t, s.Clients())
+
+		b, err := s.AddClient(model.Client{Name: "b", Currency: "EUR", HourlyRate: money.NewMoney(8000, "EUR")})
+		require.NoError(t, err)
+		assert.NotEmpty(t, b.ID, "a new ID must be added to the client")
+		_, err = s.AddClient(model.Client{Name: "a"})
+		require.NoError(t, err)
+		_, err = s.AddClient(model.Client{Name: " "})
+		assert.Error(t, err, "a client without name is invalid")
+		_, err = s.AddClient(model.Client{Name: "c", Currency: "USD", HourlyRate: money.NewMoney(8000, "EUR")})
+		assert.Error(t, err, "the rate must use the currency of the client")
+		assert.EqualValues(t, []string{"a", "b"}, clientNames(s), "clients must be sorted by name")
+
+		b.Name = "0"
+		b.TaxRate = 19
+		_, err = s.UpdateClient(*b)
+		require.NoError(t, err)
+		assert.EqualValues(t, []string{"0", "a"}, clientNames(s))
+		_, err = s.UpdateClient(model.Client{ID: "unknown", Name: "unknown"})
+		assert.Error(t, err)
+
+		found, err := s.FindFirstClient(func(c *model.Client) bool {
+			return c.Name == "0"
+		})
+		require.NoError(t, err)
+		assert.EqualValues(t, b.ID, found.ID)
+		assert.EqualValues(t, 19, found.TaxRate)
+		assert.EqualValues(t, money.NewMoney(8000, "EUR"), found.HourlyRate)
+		_, err = s.FindFirstClient(func(c *model.Client) bool {
+			return false
+		})
+		assert.EqualValues(t, store.ErrClientNotFound, err)
+		assert.Len(t, s.FindClients(func(c *model.Client) bool {
+			return true
+		}), 2)
+
+		require.NoError(t, s.RemoveClient(b.ID))
+		assert.Error(t, s.RemoveClient(b.ID))
+		assert.EqualValues(t, []string{"a"}, clientNames(s))
+	})
+
+	t.Run("frames", func(t *testing.T) {
+		s := newStore(t)
+		assert.Empty(t, s.Frames())
+
+		p, err := s.AddProject(model.Project{Name: "p"})
+		require.NoError(t, err)
+		other, err := s.AddProject(model.Project{Name: "other"})
+		require.NoError(t, err)
+
+		_, err = s.AddFrame(model.Frame{ProjectId: p.ID})
+		assert.Error(t, err, "a frame without start is invalid")
+
+		start := time.Date(2019, 1, 2, 10, 0, 0, 0, time.UTC)
+		end := start.Add(time.Hour)
+		second, err := s.AddFrame(model.Frame{ProjectId: p.ID, Start: &start, End: &end, Notes: "second"})
+		require.NoError(t, err)
+		assert.NotEmpty(t, second.ID, "a new ID must be added to the frame")
+		assert.NotNil(t, second.Updated)
+
+		earlier := start.Add(-24 * time.Hour)
+		earlierEnd := earlier.Add(time.Hour)
+		first, err := s.AddFrame(model.Frame{ProjectId: other.ID, Start: &earlier, End: &earlierEnd, Notes: "first"})
+		require.NoError(t, err)
+		activeStart := time.Date(2019, 2, 1, 10, 0, 0, 0, time.UTC)
+		active, err := s.AddFrame(model.Frame{ProjectId: p.ID, Start: &activeStart, Notes: "active"})
+		require.NoError(t, err)
+
+		assert.EqualValues(t, []string{"first", "second", "active"}, frameNotes(s.Frames()), "frames must be sorted by start")
+		assert.EqualValues(t, []string{"second", "active"}, frameNotes(s.FramesByProject(p.ID)))
+		assert.Len(t, s.FramesByProject(p.ID, other.ID), 3)
+		assert.Empty(t, s.FramesByProject())
+		assert.EqualValues(t, []string{"active"}, frameNotes(s.ActiveFrames()))
+
+		rangeStart := start.Add(30 * time.Minute)
+		assert.EqualValues(t, []string{"second", "active"}, frameNotes(s.FramesInRange(&rangeStart, nil)))
+		assert.EqualValues(t, []string{"first"}, frameNotes(s.FramesInRange(nil, &earlierEnd)))
+
+		found, err := s.FindFirstFrame(func(f *model.Frame) bool {
+			return f.ProjectId == other.ID
+		})
+		require.NoError(t, err)
+		assert.EqualValues(t, first.ID, found.ID)
+		_, err = s.FindFirstFrame(func(f *model.Frame) bool {
+			return false
+		})
+		assert.Error(t, err)
+		frames, err := s.FindFrames(func(f *model.Frame) (bool, error) {
+			return f.IsStopped(), nil
+		})
+		require.NoError(t, err)
+		assert.Len(t, frames, 2)
+		_, err = s.FindFrames(func(f *model.Frame) (bool, error) {
+			return false, fmt.Errorf("failure")
+		})
+		assert.Error(t, err, "the error of the filter must be returned")
+
+		// updating the start must keep the frames sorted
+		updated := *active
+		previousUpdate := *active.Updated
+		later := end.Add(-48 * time.Hour)
+		updated.Start = &later
+		updated.StopAt(later.Add(time.Minute))
+		result, err := s.UpdateFrame(updated)
+		require.NoError(t, err)
+		assert.False(t, result.Updated.Before(previousUpdate), "the update time must be set")
+		assert.EqualValues(t, []string{"active", "first", "second"}, frameNotes(s.Frames()))
+		assert.Empty(t, s.ActiveFrames())
+
+		_, err = s.UpdateFrame(model.Frame{ID: "unknown", ProjectId: p.ID, Start: &start})
+		assert.Error(t, err)
+		_, err = s.UpdateFrame(model.Frame{ProjectId: p.ID, Start: &start})
+		assert.Error(t, err, "a frame without ID is invalid")
+
+		require.NoError(t, s.RemoveFrame(second.ID))
+		assert.Error(t, s.RemoveFrame(second.ID))
+		assert.EqualValues(t, []string{"active", "first"}, frameNotes(s.Frames()))
+	})
+
+	t.Run("frame queries", func(t *testing.T) {
+		s := newStore(t)
+		p, err := s.AddProject(model.Project{Name: "p"})
+		require.NoError(t, err)
+		other, err := s.AddProject(model.Project{Name: "other"})
+		require.NoError(t, err)
+		tag, err := s.AddTag(model.Tag{Name: "tag"})
+		require.NoError(t, err)
+		otherTag, err := s.AddTag(model.Tag{Name: "other"})
+		require.NoError(t, err)
+
+		day := func(d int) *time.Time {
+			t := time.Date(2019, 1, d, 10, 0, 0, 0, time.UTC)
+			return &t
+		}
+		long, err := s.AddFrame(model.Frame{ProjectId: p.ID, Start: day(1), End: day(20), Notes: "long", TagIDs: []string{tag.ID}})
+		require.NoError(t, err)
+		short, err := s.AddFrame(model.Frame{ProjectId: other.ID, Start: day(10), End: day(11), Notes: "short", TagIDs: []string{tag.ID, otherTag.ID}})
+		require.NoError(t, err)
+		_, err = s.AddFrame(model.Frame{ProjectId: p.ID, Start: day(25), Notes: "active"})
+		require.NoError(t, err)
+
+		assert.EqualValues(t, []string{"long", "short"}, frameNotes(s.FramesByTag(tag.ID)))
+		assert.EqualValues(t, []string{"long", "short"}, frameNotes(s.FramesByTag(tag.ID, otherTag.ID)), "frames must be returned once")
+		assert.Empty(t, s.FramesByTag())
+		assert.EqualValues(t, []string{"long", "active"}, frameNotes(s.FramesInRange(day(15), nil)), "frames starting before the range must be found")
+		assert.EqualValues(t, []string{"long", "short"}, frameNotes(s.FramesInRange(day(11), day(11))))
+		assert.EqualValues(t, []string{"active"}, frameNotes(s.FramesInRange(day(28), day(29))), "active frames have no end")
+		assert.Empty(t, s.FramesInRange(day(21), day(24)))
+
+		// callers modify the returned values in place
+		short.ProjectId = p.ID
+		short.TagIDs = []string{otherTag.ID}
+		short.Start = day(21)
+		short.End = day(22)
+		_, err = s.UpdateFrame(*short)
+		require.NoError(t, err)
+		assert.EqualValues(t, []string{"long"}, frameNotes(s.FramesByTag(tag.ID)))
+		assert.EqualValues(t, []string{"long", "short", "active"}, frameNotes(s.FramesByProject(p.ID)))
+		assert.Empty(t, s.FramesByProject(other.ID))
+		assert.EqualValues(t, []string{"short"}, frameNotes(s.FramesInRange(day(21), day(24))))
+
+		found, err := s.FrameByID(short.ID)
+		require.NoError(t, err)
+		assert.EqualValues(t, "short", found.Notes)
+		_, err = s.FrameByID("unknown")
+		assert.Error(t, err)
+
+		// the filters may query the store
+		found, err = s.FindFirstFrame(func(f *model.Frame) bool {
+			return len(s.ActiveFrames()) == 1 && len(s.FramesByProject(f.ProjectId)) == 3 && f.Notes == "active"
+		})
+		require.NoError(t, err)
+		assert.EqualValues(t, "active", found.Notes)
+		frames, err := s.FindFrames(func(f *model.Frame) (bool, error) {
+			_, err := s.FrameByID(f.ID)
+			return f.IsStopped(), err
+		})
+		require.NoError(t, err)
+		assert.EqualValues(t, []string{"long", "short"}, frameNotes(frames))
+
+		require.NoError(t, s.RemoveFrame(long.ID))
+		assert.Empty(t, s.FramesByTag(tag.ID))
+		assert.EqualValues(t, []string{"short", "active"}, frameNotes(s.FramesInRange(nil, nil)))
+	})
+
+	t.Run("history", func(t *testing.T) {
+		s := newStore(t)
+		p, err := s.AddProject(model.Project{Name: "p"})
+		require.NoError(t, err)
+
+		start := time.Now().Add(-time.Hour)
+		frame, err := s.AddFrame(model.Frame{ProjectId: p.ID, Start: &start})
+		require.NoError(t, err)
+		require.NotNil(t, frame.Created)
+		assert.Empty(t, frame.History)
+
+		// callers modify the returned values in place
+		frame.StopAt(time.Now())
+		frame, err = s.UpdateFrame(*frame)
+		require.NoError(t, err)
+		frame.Archived = true
+		frame, err = s.UpdateFrame(*frame)
+		require.NoError(t, err)
+		require.Len(t, frame.History, 1, "only changes of the tracked values must be recorded")
+		assert.Nil(t, frame.History[0].End, "the old values must be recorded")
+		assert.False(t, frame.EditedAfterStop())
+
+		frame.Notes = "edited"
+		_, err = s.UpdateFrame(*frame)
+		require.NoError(t, err)
+		frame = findFrame(t, s, frame.ID)
+		require.Len(t, frame.History, 2)
+		assert.Empty(t, frame.History[1].Notes)
+		assert.NotNil(t, frame.Created, "the creation time must be kept")
+		assert.True(t, frame.EditedAfterStop())
+
+		frame.SetField("ticket", "PROJ-42")
+		_, err = s.UpdateFrame(*frame)
+		require.NoError(t, err)
+		frame = findFrame(t, s, frame.ID)
+		assert.EqualValues(t, "PROJ-42", frame.Field("ticket"), "custom fields must be stored")
+		require.Len(t, frame.History, 3)
+		assert.Empty(t, frame.History[2].Fields)
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		s := newStore(t)
+		p, err := s.AddProject(model.Project{Name: "p"})
+		require.NoError(t, err)
+		_, err = s.AddTag(model.Tag{Name: "tag"})
+		require.NoError(t, err)
+		start := time.Now()
+		_, err = s.AddFrame(model.Frame{ProjectId: p.ID, Start: &start})
+		require.NoError(t, err)
+
+		projects, tags, frames, err := s.Reset(false, true, true)
+		require.NoError(t, err)
+		assert.EqualValues(t, []int{0, 1, 1}, []int{projects, tags, frames})
+		assert.Len(t, s.Projects(), 1)
+		assert.Empty(t, s.Tags())
+		assert.Empty(t, s.Frames())
+	})
+
+	t.Run("transactions", func(t *testing.T) {
+		s := newStore(t)
+		project, err := s.AddProject(model.Project{Name: "project"})
+		require.NoError(t, err)
+
+		var events []model.Event
+		defer s.Subscribe(func(e model.Event) {
+			events = append(events, e)
+		})()
+
+		failure := fmt.Errorf("failure")
+		err = s.Update(func(tx model.Store) error {
+			if _, err := tx.AddProject(model.Project{Name: "discarded"}); err != nil {
+				return err
+			}
+			renamed := *project
+			renamed.Name = "renamed"
+			if _, err := tx.UpdateProject(renamed); err != nil {
+				return err
+			}
+			start := time.Now()
+			if _, err := tx.AddFrame(model.Frame{ProjectId: project.ID, Start: &start}); err != nil {
+				return err
+			}
+			return failure
+		})
+		require.EqualValues(t, failure, err)
+		assert.EqualValues(t, []string{"project"}, projectNames(s), "the changes of a failed transaction must be discarded")
+		assert.Empty(t, s.Frames())
+		assert.Empty(t, events, "the events of a failed transaction must be discarded")
+
+		err = s.Update(func(tx model.Store) error {
+			return tx.Update(func(model.Store) error { return nil })
+		})
+		assert.EqualValues(t, store.ErrNestedTransaction, err)
+
+		err = s.Update(func(tx model.Store) error {
+			for _, name := range []string{"b", "a"} {
+				if _, err := tx.AddProject(model.Project{Name: name}); err != nil {
+					return err
+				}
+			}
+			assert.Empty(t, events, "the events must be passed after the transaction was committed")
+			return nil
+		})
+		require.NoError(t, err)
+		assert.EqualValues(t, []string{"a", "b", "project"}, projectNames(s))
+		assert.Len(t, events, 2)
+	})
+
+	t.Run("observers", func(t *testing.T) {
+		s := newStore(t)
+
+		var events []model.Event
+		unsubscribe := s.Subscribe(func(e model.Event) {
+			events = append(events, e)
+		})
+
+		project, err := s.AddProject(model.Project{Name: "project"})
+		require.NoError(t, err)
+		start := time.Now().Add(-time.Hour)
+		frame, err := s.AddFrame(model.Frame{ProjectId: project.ID, Start: &start})
+		require.NoError(t, err)
+		frame.StopAt(time.Now())
+		_, err = s.UpdateFrame(*frame)
+		require.NoError(t, err)
+		require.NoError(t, s.RemoveFrame(frame.ID))
+
+		require.Len(t, events, 4)
+		assert.EqualValues(t, model.EventAdded, events[0].Type)
+		assert.EqualValues(t, model.EntityProject, events[0].Entity)
+		assert.EqualValues(t, model.EventUpdated, events[2].Type)
+		assert.True(t, events[2].OldFrame().IsActive(), "the old value must be passed to observers")
+		assert.False(t, events[2].NewFrame().IsActive())
+		assert.EqualValues(t, model.EventRemoved, events[3].Type)
+		assert.Nil(t, events[3].New)
+
+		unsubscribe()
+		_, err = s.AddTag(model.Tag{Name: "tag"})
+		require.NoError(t, err)
+		assert.Len(t, events, 4)
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		s := newStore(t)
+		_, err := s.AddProject(model.Project{Name: "removed"})
+		require.NoError(t, err)
+
+		start := time.Date(2019, 1, 1, 10, 0, 0, 0, time.UTC)
+		later := start.Add(time.Hour)
+		projects := []*model.Project{{ID: "p2", ParentID: "p1", Name: "child"}, {ID: "p1", Name: "top"}}
+		frames := []*model.Frame{{ID: "f2", ProjectId: "p2", Start: &later}, {ID: "f1", ProjectId: "p1", Start: &start}}
+		require.NoError(t, store.Replace(s, projects, []*model.Tag{{ID: "t1", Name: "tag"}}, frames))
+
+		assert.EqualValues(t, []string{"top", "top/child"}, projectNames(s), "the IDs must be kept")
+		assert.EqualValues(t, []string{"tag"}, tagNames(s))
+		require.Len(t, s.Frames(), 2)
+		assert.EqualValues(t, "f1", s.Frames()[0].ID)
+
+		// the clients are only replaced by ReplaceWithClients
+		_, err = s.AddClient(model.Client{Name: "kept"})
+		require.NoError(t, err)
+		require.NoError(t, store.Replace(s, projects, nil, frames))
+		assert.EqualValues(t, []string{"kept"}, clientNames(s))
+		require.NoError(t, store.ReplaceWithClients(s, projects, nil, []*model.Client{{ID: "c1", Name: "client"}}, frames))
+		assert.EqualValues(t, []string{"client"}, clientNames(s))
+		assert.Empty(t, s.Tags())
+	})
+}
+
+func projectNames(s model.Store) []string {
+	var result []string
+	for _, p := range s.Projects() {
+		result = append(result, p.GetFullName("/"))
+	}
+	return result
+}
+
+func tagNames(s model.Store) []string {
+	var result []string
+	for _, t := range s.Tags() {
+		result = append(result, t.Name)
+	}
+	return result
+}
+
+func clientNames(s model.Store) []string {
+	var result []string
+	for _, c := range s.Clients() {
+		result = append(result, c.Name)
+	}
+	return result
+}
+
+func frameNotes(frames []*model.Frame) []string {
+	var result []string
+	for _, f := range frames {
+		result = append(result, f.Notes)
+	}
+	return result
+}
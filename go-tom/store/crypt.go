@@ -0,0 +1,391 @@
+package store
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/jansorg/tom/go-tom/model"
+	"github.com/jansorg/tom/go-tom/util"
+)
+
+// ErrPassphraseRequired is returned when encrypted data is read without passphrase
+var ErrPassphraseRequired = fmt.Errorf("the data is encrypted, but no passphrase was provided")
+
+// ErrWrongPassphrase is returned when the passphrase doesn't match the passphrase used to encrypt the data
+var ErrWrongPassphrase = fmt.Errorf("the passphrase doesn't match the passphrase of the encrypted data")
+
+// encryptionFileName is the name of the file, which marks an encrypted data or backup directory.
+// It contains the salt of the key, but not the key itself.
+const encryptionFileName = "encryption.json"
+
+const keyDerivation = "pbkdf2-sha256"
+
+// keyIterations is the number of iterations of PBKDF2 to derive the key of a new encrypted directory
+var keyIterations = 200000
+
+// the files, which are never encrypted. The version is read before the data files.
+var plainFiles = map[string]bool{encryptionFileName: true, versionFileName: true, lockFileName: true}
+
+// encrypted files start with this header, followed by the nonce and the sealed data
+var encryptedHeader = []byte("tom-encrypted-v1\n")
+
+// sealed with the key to verify the passphrase
+var passphraseCheck = []byte("tom")
+
+type encryptionInfo struct {
+	KeyDerivation string `json:"kdf"`
+	Iterations    int    `json:"iterations"`
+	Salt          []byte `json:"salt"`
+	Check         []byte `json:"check"`
+}
+
+// dataCipher encrypts and decrypts the files of a data directory with AES-256-GCM.
+// The key is derived from the passphrase with PBKDF2. The name of a file is authenticated with its data.
+// A nil dataCipher reads and writes plain files.
+type dataCipher struct {
+	passphrase string
+	info       encryptionInfo
+	aead       cipher.AEAD
+}
+
+// pbkdf2Key derives a key from password as defined by RFC 8018, using HMAC-SHA256
+func pbkdf2Key(password, salt []byte, iterations, keyLen int) []byte {
+	prf := hmac.New(sha256.New, password)
+	var key []byte
+	for block := uint32(1); len(key) < keyLen; block++ {
+		prf.Reset()
+		prf.Write(salt)
+		var index [4]byte
+		binary.BigEndian.PutUint32(index[:], block)
+		prf.Write(index[:])
+		u := prf.Sum(nil)
+
+		t := make([]byte, len(u))
+		copy(t, u)
+		for i := 1; i < iterations; i++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for j := range t {
+				t[j] ^= u[j]
+			}
+		}
+		key = append(key, t...)
+	}
+	return key[:keyLen]
+}
+
+func newDataCipher(passphrase string, info encryptionInfo) (*dataCipher, error) {
+	if info.KeyDerivation != keyDerivation {
+		return nil, fmt.Errorf("unsupported key derivation %s", info.KeyDerivation)
+	}
+
+	block, err := aes.NewCipher(pbkdf2Key([]byte(passphrase), info.Salt, info.Iterations, 32))
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &dataCipher{passphrase: passphrase, info: info, aead: aead}, nil
+}
+
+// createDataCipher returns a cipher with a new random salt
+func createDataCipher(passphrase string) (*dataCipher, error) {
+	if passphrase == "" {
+		return nil, fmt.Errorf("the passphrase must not be empty")
+	}
+
+	info := encryptionInfo{KeyDerivation: keyDerivation, Iterations: keyIterations, Salt: make([]byte, 32)}
+	if _, err := rand.Read(info.Salt); err != nil {
+		return nil, err
+	}
+
+	c, err := newDataCipher(passphrase, info)
+	if err != nil {
+		return nil, err
+	}
+	if c.info.Check, err = c.seal(encryptionFileName, passphraseCheck); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// readEncryptionInfo returns the encryption of the directory or nil if it isn't encrypted
+func readEncryptionInfo(dir string) (*encryptionInfo, error) {
+	data, err := ioutil.ReadFile(filepath.Join(dir, encryptionFileName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var info encryptionInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, fmt.Errorf("invalid encryption file in %s: %s", dir, err.Error())
+	}
+	return &info, nil
+}
+
+// openDataCipher returns the cipher of the directory or nil if the directory isn't encrypted
+func openDataCipher(dir string, passphrase string) (*dataCipher, error) {
+	info, err := readEncryptionInfo(dir)
+	if err != nil || info == nil {
+		return nil, err
+	}
+	if passphrase == "" {
+		return nil, ErrPassphraseRequired
+	}
+
+	c, err := newDataCipher(passphrase, *info)
+	if err != nil {
+		return nil, err
+	}
+	if check, err := c.open(encryptionFileName, info.Check); err != nil || !bytes.Equal(check, passphraseCheck) {
+		return nil, ErrWrongPassphrase
+	}
+	return c, nil
+}
+
+// forDir returns the cipher of another directory, e.g. of a backup, which may use a different salt
+func (c *dataCipher) forDir(dir string) (*dataCipher, error) {
+	if c == nil {
+		return openDataCipher(dir, "")
+	}
+
+	if info, err := readEncryptionInfo(dir); err == nil && info != nil && bytes.Equal(info.Salt, c.info.Salt) {
+		return c, nil
+	}
+	return openDataCipher(dir, c.passphrase)
+}
+
+// writeInfo writes the encryption file into dir
+func (c *dataCipher) writeInfo(dir string) error {
+	data, err := json.Marshal(c.info)
+	if err != nil {
+		return err
+	}
+	return util.WriteFileAtomic(filepath.Join(dir, encryptionFileName), data, 0600)
+}
+
+func (c *dataCipher) seal(name string, data []byte) ([]byte, error) {
+	if c == nil {
+		return data, nil
+	}
+
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	result := append([]byte{}, encryptedHeader...)
+	result = append(result, nonce...)
+	return c.aead.Seal(result, nonce, data, []byte(name)), nil
+}
+
+// open decrypts data. Plain data is returned as it is, e.g. files of an interrupted encryption of a directory.
+func (c *dataCipher) open(name string, data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, encryptedHeader) {
+		return data, nil
+	}
+	if c == nil {
+		return nil, ErrPassphraseRequired
+	}
+
+	data = data[len(encryptedHeader):]
+	if len(data) < c.aead.NonceSize() {
+		return nil, fmt.Errorf("invalid encrypted data of %s", name)
+	}
+	nonce := data[:c.aead.NonceSize()]
+	plain, err := c.aead.Open(nil, nonce, data[c.aead.NonceSize():], []byte(name))
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt %s: %s", name, err.Error())
+	}
+	return plain, nil
+}
+
+func (c *dataCipher) readFile(path string) ([]byte, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return c.open(filepath.Base(path), data)
+}
+
+func (c *dataCipher) writeFile(path string, data []byte) error {
+	sealed, err := c.seal(filepath.Base(path), data)
+	if err != nil {
+		return err
+	}
+	return util.WriteFileAtomic(path, sealed, 0600)
+}
+
+// storeCipher returns the cipher of the store or nil if the store isn't encrypted
+func storeCipher(s model.Store) *dataCipher {
+	if d, ok := s.(*DataStore); ok {
+		return d.cipher
+	}
+	return nil
+}
+
+// Encrypted returns if the data files of s are encrypted
+func Encrypted(s model.Store) bool {
+	return storeCipher(s) != nil
+}
+
+// Encrypt encrypts the data files, the trash and the backups of s with a key derived from passphrase
+func Encrypt(s model.Store, passphrase string) error {
+	d, ok := s.(*DataStore)
+	if !ok {
+		return fmt.Errorf("encryption is only supported by the json data format")
+	}
+	if d.cipher != nil {
+		return fmt.Errorf("the data is already encrypted")
+	}
+
+	c, err := createDataCipher(passphrase)
+	if err != nil {
+		return err
+	}
+	return d.convert(c)
+}
+
+// Decrypt decrypts the data files, the trash and the backups of s
+func Decrypt(s model.Store) error {
+	d, ok := s.(*DataStore)
+	if !ok || d.cipher == nil {
+		return fmt.Errorf("the data isn't encrypted")
+	}
+	return d.convert(nil)
+}
+
+// convert writes all data files, the trash and the backups with the cipher c, a nil cipher writes plain files.
+// The encryption file is written first and removed last, an interrupted conversion leaves readable data behind.
+func (d *DataStore) convert(c *dataCipher) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	unlock, err := d.lock.lockExclusive()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := d.refreshLocked(nil); err != nil {
+		return err
+	}
+	if err := d.readYearsLocked(d.shardYears()...); err != nil {
+		return err
+	}
+	if err := d.backupLocked(); err != nil {
+		return err
+	}
+
+	previous := d.cipher
+	if c != nil {
+		if err := c.writeInfo(d.path); err != nil {
+			return err
+		}
+	}
+
+	d.cipher = c
+	d.resetYearsLocked()
+	if err := d.saveLocked(); err != nil {
+		return err
+	}
+	if err := convertTrash(d.path, previous, c); err != nil {
+		return err
+	}
+	if err := convertDataFiles(d.path, previous, c); err != nil {
+		return err
+	}
+
+	if d.backupPath != "" {
+		for _, dir := range []string{d.backupPath, filepath.Join(d.backupPath, redoDirName)} {
+			backups, err := listBackups(dir, previous)
+			if err != nil {
+				return err
+			}
+			for _, backup := range backups {
+				if err := convertBackup(backup.Dir, previous, c); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if c == nil {
+		if err := os.Remove(filepath.Join(d.path, encryptionFileName)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	d.fileStates = d.statDataFiles()
+	return nil
+}
+
+// convertBackup rewrites the files of a backup of the json format with the cipher to
+func convertBackup(dir string, from, to *dataCipher) error {
+	if fileExists(filepath.Join(dir, sqliteFileName)) {
+		return nil
+	}
+
+	source, err := from.forDir(dir)
+	if err != nil {
+		return err
+	}
+	if to != nil {
+		if err := to.writeInfo(dir); err != nil {
+			return err
+		}
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		path := filepath.Join(dir, file.Name())
+		switch {
+		case file.IsDir() || plainFiles[file.Name()]:
+			continue
+		case file.Name() == journalFileName:
+			entries, _, err := readJournal(path, source)
+			if err != nil {
+				return err
+			}
+			data, err := encodeJournal(entries, to)
+			if err != nil {
+				return err
+			}
+			if err := util.WriteFileAtomic(path, data, 0600); err != nil {
+				return err
+			}
+		default:
+			data, err := source.readFile(path)
+			if err != nil {
+				return err
+			}
+			if err := to.writeFile(path, data); err != nil {
+				return err
+			}
+		}
+	}
+
+	if to == nil {
+		if err := os.Remove(filepath.Join(dir, encryptionFileName)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
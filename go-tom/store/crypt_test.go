@@ -0,0 +1,123 @@
+package store
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jansorg/tom/go-tom/model"
+)
+
+func Test_PBKDF2(t *testing.T) {
+	// test vectors of RFC 7914
+	key := pbkdf2Key([]byte("passwd"), []byte("salt"), 1, 64)
+	assert.EqualValues(t, "55ac046e56e3089fec1691c22544b605f94185216dde0465e68b9d57c20dacbc49ca9cccf179b645991664b39d77ef317c71b845b1e30bd509112041d3a19783", hex.EncodeToString(key))
+
+	key = pbkdf2Key([]byte("Password"), []byte("NaCl"), 80000, 64)
+	assert.EqualValues(t, "4ddcd8f60b98be21830cee5ef22701f9641a4418d04c0414aeff08876b34ab56a1d425a1225833549adb841b51c9b3176a272bdebba1d078478f62b397f33c8d", hex.EncodeToString(key))
+}
+
+// assertNoPlainText fails if a file in dir or its subdirectories contains text
+func assertNoPlainText(t *testing.T, dir string, text string) {
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		data, err := ioutil.ReadFile(path)
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), text, path)
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func Test_Encryption(t *testing.T) {
+	keyIterations = 1000
+	const secret = "secret-client"
+
+	for _, journal := range []bool{false, true} {
+		t.Run(fmt.Sprintf("journal %v", journal), func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "tom")
+			require.NoError(t, err)
+			defer os.RemoveAll(dir)
+
+			backupDir := filepath.Join(dir, "backup")
+			options := Options{Dir: dir, BackupDir: backupDir, MaxBackups: 10, Journal: journal, JournalCompactSize: 100}
+			s, err := newDataStore(options)
+			require.NoError(t, err)
+
+			p, err := s.AddProject(model.Project{Name: secret})
+			require.NoError(t, err)
+			start := time.Date(2019, 1, 1, 10, 0, 0, 0, time.UTC)
+			_, err = s.AddFrame(model.Frame{ProjectId: p.ID, Start: &start, Notes: secret})
+			require.NoError(t, err)
+
+			require.NoError(t, Encrypt(s, "passphrase"))
+			assert.True(t, Encrypted(s))
+			assert.FileExists(t, s.EncryptionFile)
+
+			// changes after the encryption are written into the encrypted journal and backups
+			_, err = s.AddTag(model.Tag{Name: secret})
+			require.NoError(t, err)
+			assertNoPlainText(t, dir, secret)
+
+			_, err = newDataStore(options)
+			assert.EqualValues(t, ErrPassphraseRequired, err)
+			options.Passphrase = "wrong"
+			_, err = newDataStore(options)
+			assert.EqualValues(t, ErrWrongPassphrase, err)
+
+			options.Passphrase = "passphrase"
+			reopened, err := newDataStore(options)
+			require.NoError(t, err)
+			assert.Len(t, reopened.Projects(), 1)
+			assert.Len(t, reopened.Tags(), 1)
+			require.Len(t, reopened.Frames(), 1)
+			assert.EqualValues(t, secret, reopened.Frames()[0].Notes)
+
+			// the backups, which were created before the encryption, are readable with the passphrase
+			backups, err := ListBackups(reopened)
+			require.NoError(t, err)
+			require.NotEmpty(t, backups)
+			assert.NotEmpty(t, backups[0].Session, "the info files of the backups must be decrypted")
+
+			// all changes were made by the same session
+			_, changes, err := Undo(reopened)
+			require.NoError(t, err)
+			assert.Len(t, changes, 3)
+			assert.Empty(t, reopened.Frames())
+			_, changes, err = Redo(reopened)
+			require.NoError(t, err)
+			assert.Len(t, changes, 3)
+			assert.Len(t, reopened.Frames(), 1)
+
+			require.NoError(t, Decrypt(reopened))
+			assert.False(t, Encrypted(reopened))
+			assert.NoFileExists(t, reopened.EncryptionFile)
+
+			options.Passphrase = ""
+			plain, err := newDataStore(options)
+			require.NoError(t, err)
+			assert.Len(t, plain.Frames(), 1)
+			backups, err = ListBackups(plain)
+			require.NoError(t, err)
+			for _, backup := range backups {
+				_, err := OpenBackup(plain, backup.Dir)
+				assert.NoError(t, err)
+				assert.NoFileExists(t, filepath.Join(backup.Dir, encryptionFileName))
+			}
+
+			data, err := ioutil.ReadFile(plain.ProjectFile)
+			require.NoError(t, err)
+			assert.True(t, strings.Contains(string(data), secret))
+		})
+	}
+}
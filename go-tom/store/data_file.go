@@ -0,0 +1,117 @@
+package store
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jansorg/tom/go-tom/model"
+)
+
+// dataFiles are the files of the data directory, which don't store projects, tags or frames.
+// They're encrypted like the data files, they're contained in the backups and they're restored by Undo.
+var dataFiles = []string{coldArchiveFileName}
+
+// RegisterDataFile adds a file of the data directory, which is managed outside of the store, e.g. the working time targets.
+// It must be called before the file is read or written, e.g. in the init function of a package.
+func RegisterDataFile(name string) {
+	for _, existing := range dataFiles {
+		if existing == name {
+			return
+		}
+	}
+	dataFiles = append(dataFiles, name)
+}
+
+// ReadDataFile returns the content of a registered file of the data directory of s. It's decrypted with the passphrase of s.
+// The error satisfies os.IsNotExist if the file doesn't exist.
+func ReadDataFile(s model.Store, name string) ([]byte, error) {
+	path, err := dataFilePath(s, name)
+	if err != nil {
+		return nil, err
+	}
+	return storeCipher(s).readFile(path)
+}
+
+// WriteDataFile writes a registered file of the data directory of s with a commit of the store.
+// A backup is created first, the change can be reverted by Undo.
+func WriteDataFile(s model.Store, name string, data []byte) error {
+	if _, err := dataFilePath(s, name); err != nil {
+		return err
+	}
+	stager, ok := s.(fileStager)
+	if !ok {
+		return fmt.Errorf("the store doesn't support data files")
+	}
+
+	return s.Update(func(tx model.Store) error {
+		stager.stageFile(name, data)
+		return nil
+	})
+}
+
+func dataFilePath(s model.Store, name string) (string, error) {
+	if s.DirPath() == "" {
+		return "", fmt.Errorf("the store doesn't support data files")
+	}
+	for _, registered := range dataFiles {
+		if registered == name {
+			return filepath.Join(s.DirPath(), name), nil
+		}
+	}
+	return "", fmt.Errorf("unknown data file %s", name)
+}
+
+// dataFilePaths returns the paths of the data files in dir
+func dataFilePaths(dir string) []string {
+	result := make([]string, len(dataFiles))
+	for i, name := range dataFiles {
+		result[i] = filepath.Join(dir, name)
+	}
+	return result
+}
+
+// restoreDataFiles replaces the data files of target with the data files of the backup in dir.
+// The files, which aren't contained in the backup, are removed.
+func restoreDataFiles(target model.Store, dir string) error {
+	if target.DirPath() == "" {
+		return nil
+	}
+
+	c, err := storeCipher(target).forDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, name := range dataFiles {
+		path := filepath.Join(target.DirPath(), name)
+		data, err := c.readFile(filepath.Join(dir, name))
+		if os.IsNotExist(err) {
+			err = os.Remove(path)
+			if os.IsNotExist(err) {
+				err = nil
+			}
+		} else if err == nil {
+			err = storeCipher(target).writeFile(path, data)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// convertDataFiles rewrites the data files in the data directory with the cipher to
+func convertDataFiles(dataDir string, from, to *dataCipher) error {
+	for _, path := range dataFilePaths(dataDir) {
+		data, err := from.readFile(path)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		if err := to.writeFile(path, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
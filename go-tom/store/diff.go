@@ -0,0 +1,151 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/jansorg/tom/go-tom/model"
+)
+
+// Types of changes between two stores
+const (
+	ChangeAdded    = "added"
+	ChangeRemoved  = "removed"
+	ChangeModified = "changed"
+)
+
+// Change is a difference of a project, tag, client or frame between two stores
+type Change struct {
+	Type   string `json:"type"`
+	Entity string `json:"entity"`
+	ID     string `json:"id"`
+	// Name is the full name of a project, the name of a tag or client or the full name of the project of a frame
+	Name string `json:"name"`
+}
+
+func (c Change) String() string {
+	if c.Entity == journalFrame {
+		return fmt.Sprintf("%s frame %s of project %s", c.Type, c.ID, c.Name)
+	}
+	return fmt.Sprintf("%s %s %s", c.Type, c.Entity, c.Name)
+}
+
+type diffValue struct {
+	id    string
+	value interface{}
+}
+
+// Diff returns the changes of the projects, tags, clients and frames from old to new
+func Diff(old model.Store, new model.Store) []Change {
+	var changes []Change
+	changes = append(changes, diffValues(journalProject, old, new, projectDiffValues, func(value interface{}, s model.Store) string {
+		return projectName(s, value.(*model.Project).ID)
+	})...)
+	changes = append(changes, diffValues(journalTag, old, new, tagDiffValues, func(value interface{}, s model.Store) string {
+		return value.(*model.Tag).Name
+	})...)
+	changes = append(changes, diffValues(journalClient, old, new, clientDiffValues, func(value interface{}, s model.Store) string {
+		return value.(*model.Client).Name
+	})...)
+	changes = append(changes, diffValues(journalFrame, old, new, frameDiffValues, func(value interface{}, s model.Store) string {
+		return projectName(s, value.(*model.Frame).ProjectId)
+	})...)
+	return changes
+}
+
+// diffValues compares the values of old and new. The names of removed values are taken from the old store.
+func diffValues(entity string, old, new model.Store, values func(model.Store) []diffValue, name func(interface{}, model.Store) string) []Change {
+	return compareValues(entity, values(old), values(new), func(value interface{}) string {
+		return name(value, old)
+	}, func(value interface{}) string {
+		return name(value, new)
+	})
+}
+
+// compareValues returns the changes from oldValues to newValues
+func compareValues(entity string, oldValues, newValues []diffValue, oldName, newName func(interface{}) string) []Change {
+	oldByID := map[string]interface{}{}
+	for _, v := range oldValues {
+		oldByID[v.id] = v.value
+	}
+	newByID := map[string]interface{}{}
+	for _, v := range newValues {
+		newByID[v.id] = v.value
+	}
+
+	var changes []Change
+	for _, v := range oldValues {
+		if newValue, ok := newByID[v.id]; !ok {
+			changes = append(changes, Change{Type: ChangeRemoved, Entity: entity, ID: v.id, Name: oldName(v.value)})
+		} else if !sameJSON(v.value, newValue) {
+			changes = append(changes, Change{Type: ChangeModified, Entity: entity, ID: v.id, Name: newName(newValue)})
+		}
+	}
+	for _, v := range newValues {
+		if _, ok := oldByID[v.id]; !ok {
+			changes = append(changes, Change{Type: ChangeAdded, Entity: entity, ID: v.id, Name: newName(v.value)})
+		}
+	}
+	return changes
+}
+
+func projectDiffValues(s model.Store) []diffValue {
+	return projectListValues(s.Projects())
+}
+
+func tagDiffValues(s model.Store) []diffValue {
+	return tagListValues(s.Tags())
+}
+
+func clientDiffValues(s model.Store) []diffValue {
+	return clientListValues(s.Clients())
+}
+
+func frameDiffValues(s model.Store) []diffValue {
+	return frameListValues(s.Frames())
+}
+
+func projectListValues(projects []*model.Project) []diffValue {
+	var result []diffValue
+	for _, p := range projects {
+		result = append(result, diffValue{id: p.ID, value: p})
+	}
+	return result
+}
+
+func tagListValues(tags []*model.Tag) []diffValue {
+	var result []diffValue
+	for _, t := range tags {
+		result = append(result, diffValue{id: t.ID, value: t})
+	}
+	return result
+}
+
+func clientListValues(clients []*model.Client) []diffValue {
+	var result []diffValue
+	for _, c := range clients {
+		result = append(result, diffValue{id: c.ID, value: c})
+	}
+	return result
+}
+
+func frameListValues(frames []*model.Frame) []diffValue {
+	var result []diffValue
+	for _, f := range frames {
+		result = append(result, diffValue{id: f.ID, value: f})
+	}
+	return result
+}
+
+func projectName(s model.Store, id string) string {
+	if p, err := s.ProjectByID(id); err == nil {
+		return p.GetFullName("/")
+	}
+	return id
+}
+
+func sameJSON(a, b interface{}) bool {
+	dataA, errA := json.Marshal(a)
+	dataB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(dataA) == string(dataB)
+}
@@ -0,0 +1,123 @@
+package store
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jansorg/tom/go-tom/model"
+)
+
+// Supported values of the data_format configuration key
+const (
+	FormatJSON   = "json"
+	FormatSQLite = "sqlite"
+)
+
+var Formats = []string{FormatJSON, FormatSQLite}
+
+// Options define how a data directory is opened
+type Options struct {
+	Format     string
+	Dir        string
+	BackupDir  string
+	MaxBackups int
+
+	// Journal enables the append-only journal of the json format.
+	// The journal is compacted into the data files when it contains JournalCompactSize entries.
+	Journal            bool
+	JournalCompactSize int
+
+	// LockTimeout is the maximum time to wait for the lock of the data directory, which is held by another process
+	LockTimeout time.Duration
+
+	// Command is the command line which is recorded in the backups of the store
+	Command string
+
+	// Passphrase is used to derive the key of an encrypted data directory of the json format
+	Passphrase string
+
+	noLock bool
+}
+
+// Open returns a store for the data format of the options
+func Open(options Options) (model.Store, error) {
+	switch options.Format {
+	case FormatJSON, "":
+		return newDataStore(options)
+	case FormatSQLite:
+		return newSQLiteStore(options)
+	default:
+		return nil, fmt.Errorf("unknown data format %s. Supported: %v", options.Format, Formats)
+	}
+}
+
+// replacer is implemented by the stores which support to replace all of their data at once.
+// The IDs of the passed values are kept. A backup of the current data is created if backup is true.
+type replacer interface {
+	replaceAll(projects []*model.Project, tags []*model.Tag, clients []*model.Client, frames []*model.Frame, backup bool) error
+}
+
+// restorer is implemented by the stores, which are able to add values with their existing IDs, e.g. values restored from the trash.
+// The values are added like the values passed to AddProject, AddTag and AddFrame.
+type restorer interface {
+	addProject(project model.Project) (*model.Project, error)
+	addTag(tag model.Tag) (*model.Tag, error)
+	addFrame(frame model.Frame) (*model.Frame, error)
+}
+
+// Migrate replaces all data of target with copies of the projects, tags, clients and frames of source.
+func Migrate(source model.Store, target model.Store) error {
+	return copyData(source, target, true)
+}
+
+// Replace replaces all data of s with the given projects, tags and frames. The clients of s are kept.
+// The IDs of the values are kept. A backup of the current data is created first.
+func Replace(s model.Store, projects []*model.Project, tags []*model.Tag, frames []*model.Frame) error {
+	return ReplaceWithClients(s, projects, tags, copyClients(s.Clients()), frames)
+}
+
+// ReplaceWithClients replaces all data of s with the given projects, tags, clients and frames.
+// The IDs of the values are kept. A backup of the current data is created first.
+func ReplaceWithClients(s model.Store, projects []*model.Project, tags []*model.Tag, clients []*model.Client, frames []*model.Frame) error {
+	r, ok := s.(replacer)
+	if !ok {
+		return fmt.Errorf("unsupported target store")
+	}
+	return r.replaceAll(projects, tags, clients, frames, true)
+}
+
+func copyClients(clients []*model.Client) []*model.Client {
+	var result []*model.Client
+	for _, c := range clients {
+		copied := *c
+		result = append(result, &copied)
+	}
+	return result
+}
+
+func copyData(source model.Store, target model.Store, backup bool) error {
+	r, ok := target.(replacer)
+	if !ok {
+		return fmt.Errorf("unsupported target store")
+	}
+
+	var projects []*model.Project
+	for _, p := range source.Projects() {
+		copied := *p
+		projects = append(projects, &copied)
+	}
+
+	var tags []*model.Tag
+	for _, t := range source.Tags() {
+		copied := *t
+		tags = append(tags, &copied)
+	}
+
+	var frames []*model.Frame
+	for _, f := range source.Frames() {
+		copied := *f
+		frames = append(frames, &copied)
+	}
+
+	return r.replaceAll(projects, tags, copyClients(source.Clients()), frames, backup)
+}
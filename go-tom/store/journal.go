@@ -0,0 +1,327 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/jansorg/tom/go-tom/model"
+)
+
+const (
+	journalPut    = "put"
+	journalRemove = "remove"
+	journalReset  = "reset"
+)
+
+const (
+	journalProject = "project"
+	journalTag     = "tag"
+	journalClient  = "client"
+	journalFrame   = "frame"
+)
+
+// journalEntry is a single change of the data, it's stored as one line of JSON in the journal file.
+// All entries are idempotent, i.e. replaying an entry again on data which already contains the change is safe.
+type journalEntry struct {
+	Action string          `json:"action"`
+	Type   string          `json:"type"`
+	ID     string          `json:"id,omitempty"`
+	Data   json.RawMessage `json:"data,omitempty"`
+	// Year is the year of the file, which contained the frame before the change.
+	// All years are read when a frame entry without year is replayed.
+	Year int `json:"year,omitempty"`
+}
+
+func newPutEntry(entryType string, id string, value interface{}) (journalEntry, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return journalEntry{}, err
+	}
+	return journalEntry{Action: journalPut, Type: entryType, ID: id, Data: data}, nil
+}
+
+func newRemoveEntry(entryType string, id string) journalEntry {
+	return journalEntry{Action: journalRemove, Type: entryType, ID: id}
+}
+
+func newFramePutEntry(year int, frame model.Frame) (journalEntry, error) {
+	entry, err := newPutEntry(journalFrame, frame.ID, frame)
+	entry.Year = year
+	return entry, err
+}
+
+func newFrameRemoveEntry(year int, id string) journalEntry {
+	entry := newRemoveEntry(journalFrame, id)
+	entry.Year = year
+	return entry
+}
+
+func newResetEntry(entryType string) journalEntry {
+	return journalEntry{Action: journalReset, Type: entryType}
+}
+
+// readJournal returns the entries of the journal file at path.
+// Reading stops at the first incomplete or invalid line, which is left behind by an interrupted write.
+// The returned size is the number of bytes of the valid entries.
+// Each line of an encrypted journal is encrypted separately and stored as base64.
+func readJournal(path string, c *dataCipher) ([]journalEntry, int64, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, 0, nil
+	} else if err != nil {
+		return nil, 0, err
+	}
+	defer file.Close()
+
+	var entries []journalEntry
+	var validSize int64
+
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			// a line without trailing newline wasn't completely written
+			break
+		} else if err != nil {
+			return nil, 0, err
+		}
+
+		data := bytes.TrimSpace(line)
+		if decoded, err := base64.StdEncoding.DecodeString(string(data)); err == nil {
+			if data, err = c.open(journalFileName, decoded); err == ErrPassphraseRequired {
+				return nil, 0, err
+			} else if err != nil {
+				break
+			}
+		}
+
+		var entry journalEntry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			break
+		}
+
+		entries = append(entries, entry)
+		validSize += int64(len(line))
+	}
+
+	return entries, validSize, nil
+}
+
+// encodeJournal returns the lines of the entries
+func encodeJournal(entries []journalEntry, c *dataCipher) ([]byte, error) {
+	var buffer bytes.Buffer
+	for _, e := range entries {
+		line, err := json.Marshal(e)
+		if err != nil {
+			return nil, err
+		}
+
+		if c != nil {
+			sealed, err := c.seal(journalFileName, line)
+			if err != nil {
+				return nil, err
+			}
+			line = []byte(base64.StdEncoding.EncodeToString(sealed))
+		}
+		buffer.Write(line)
+		buffer.WriteByte('\n')
+	}
+	return buffer.Bytes(), nil
+}
+
+// appendJournal appends the entries to the journal at path and syncs the file to disk
+func appendJournal(path string, entries []journalEntry, c *dataCipher) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	data, err := encodeJournal(entries, c)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(data); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// truncateJournal removes invalid data at the end of the journal file
+func truncateJournal(path string, size int64) error {
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if info.Size() == size {
+		return nil
+	}
+	return os.Truncate(path, size)
+}
+
+func removeJournal(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// replayJournalLocked applies the entries of the journal file to the data in memory
+func (d *DataStore) replayJournalLocked() error {
+	entries, validSize, err := readJournal(d.JournalFile, d.cipher)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		if err := d.applyJournalEntryLocked(e); err != nil {
+			return fmt.Errorf("error replaying journal %s: %s", d.JournalFile, err.Error())
+		}
+	}
+	d.journalSize = len(entries)
+	return truncateJournal(d.JournalFile, validSize)
+}
+
+func (d *DataStore) applyJournalEntryLocked(e journalEntry) error {
+	switch e.Action {
+	case journalReset:
+		switch e.Type {
+		case journalProject:
+			d.projects = []*model.Project{}
+		case journalTag:
+			d.tags = []*model.Tag{}
+		case journalClient:
+			d.clients = []*model.Client{}
+		case journalFrame:
+			d.frames = []*model.Frame{}
+			d.index = newQueryIndex(nil)
+			d.resetYearsLocked()
+		}
+		return nil
+	case journalPut, journalRemove:
+		switch e.Type {
+		case journalProject:
+			index := -1
+			for i, p := range d.projects {
+				if p.ID == e.ID {
+					index = i
+					break
+				}
+			}
+			if index >= 0 {
+				d.projects = append(d.projects[:index], d.projects[index+1:]...)
+			}
+			if e.Action == journalPut {
+				var p model.Project
+				if err := json.Unmarshal(e.Data, &p); err != nil {
+					return err
+				}
+				d.projects = append(d.projects, &p)
+			}
+		case journalTag:
+			index := -1
+			for i, t := range d.tags {
+				if t.ID == e.ID {
+					index = i
+					break
+				}
+			}
+			if index >= 0 {
+				d.tags = append(d.tags[:index], d.tags[index+1:]...)
+			}
+			if e.Action == journalPut {
+				var t model.Tag
+				if err := json.Unmarshal(e.Data, &t); err != nil {
+					return err
+				}
+				d.tags = append(d.tags, &t)
+			}
+		case journalClient:
+			index := -1
+			for i, c := range d.clients {
+				if c.ID == e.ID {
+					index = i
+					break
+				}
+			}
+			if index >= 0 {
+				d.clients = append(d.clients[:index], d.clients[index+1:]...)
+			}
+			if e.Action == journalPut {
+				var c model.Client
+				if err := json.Unmarshal(e.Data, &c); err != nil {
+					return err
+				}
+				d.clients = append(d.clients, &c)
+			}
+		case journalFrame:
+			return d.applyFrameEntryLocked(e)
+		default:
+			return fmt.Errorf("unknown journal type %s", e.Type)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown journal action %s", e.Action)
+	}
+}
+
+// applyFrameEntryLocked applies the put or remove of a frame. The years of the old and the new frame are read first.
+func (d *DataStore) applyFrameEntryLocked(e journalEntry) error {
+	var frame *model.Frame
+	if e.Action == journalPut {
+		frame = &model.Frame{}
+		if err := json.Unmarshal(e.Data, frame); err != nil {
+			return err
+		}
+	}
+
+	years := d.shardYears()
+	if e.Year != 0 {
+		years = []int{e.Year}
+	}
+	if frame != nil {
+		years = append(years, frameYear(frame))
+	}
+	if err := d.readYearsLocked(years...); err != nil {
+		return err
+	}
+
+	index := -1
+	for i, f := range d.frames {
+		if f.ID == e.ID {
+			index = i
+			break
+		}
+	}
+	if index >= 0 {
+		d.dirtyYears[d.frameYears[e.ID]] = true
+		delete(d.frameYears, e.ID)
+		d.frames = append(d.frames[:index], d.frames[index+1:]...)
+		d.index.remove(e.ID)
+	}
+	if frame != nil {
+		year := frameYear(frame)
+		d.frameYears[frame.ID] = year
+		d.dirtyYears[year] = true
+		d.frames = append(d.frames, frame)
+		d.index.add(frame)
+	}
+	return nil
+}
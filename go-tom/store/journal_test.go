@@ -0,0 +1,148 @@
+package store_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jansorg/tom/go-tom/model"
+	"github.com/jansorg/tom/go-tom/store"
+)
+
+func newJournalStore(t *testing.T, dir string, compactSize int) *store.DataStore {
+	s, err := store.Open(store.Options{Dir: dir, Journal: true, JournalCompactSize: compactSize})
+	require.NoError(t, err)
+	return s.(*store.DataStore)
+}
+
+func Test_Journal(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tom")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	s := newJournalStore(t, dir, 100)
+
+	p, err := s.AddProject(model.Project{Name: "project"})
+	require.NoError(t, err)
+	tag, err := s.AddTag(model.Tag{Name: "tag"})
+	require.NoError(t, err)
+
+	start := time.Now()
+	frame, err := s.AddFrame(model.Frame{ProjectId: p.ID, Start: &start, TagIDs: []string{tag.ID}})
+	require.NoError(t, err)
+	frame.Stop()
+	_, err = s.UpdateFrame(*frame)
+	require.NoError(t, err)
+	removed, err := s.AddFrame(model.Frame{ProjectId: p.ID, Start: &start})
+	require.NoError(t, err)
+	require.NoError(t, s.RemoveFrame(removed.ID))
+
+	assert.FileExists(t, s.JournalFile)
+	assert.NoFileExists(t, s.FrameIndexFile, "the data files must not be written before the journal is compacted")
+
+	// the journal must be replayed on load
+	reopened := newJournalStore(t, dir, 100)
+	assert.Len(t, reopened.Projects(), 1)
+	assert.Len(t, reopened.Tags(), 1)
+	require.Len(t, reopened.Frames(), 1)
+	assert.EqualValues(t, frame.ID, reopened.Frames()[0].ID)
+	assert.True(t, reopened.Frames()[0].IsStopped())
+	assert.EqualValues(t, "project", reopened.Projects()[0].GetFullName("/"))
+
+	require.NoError(t, reopened.Compact())
+	assert.NoFileExists(t, reopened.JournalFile)
+	assert.FileExists(t, reopened.FrameIndexFile)
+
+	// the compacted data without journal
+	plain, err := store.NewStore(dir, "", 0)
+	require.NoError(t, err)
+	assert.Len(t, plain.Frames(), 1)
+}
+
+func Test_JournalCompaction(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tom")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	s := newJournalStore(t, dir, 3)
+	for _, name := range []string{"a", "b"} {
+		_, err := s.AddProject(model.Project{Name: name})
+		require.NoError(t, err)
+	}
+	assert.FileExists(t, s.JournalFile)
+
+	_, err = s.AddProject(model.Project{Name: "c"})
+	require.NoError(t, err)
+	assert.NoFileExists(t, s.JournalFile, "the journal must be compacted after 3 entries")
+	assert.FileExists(t, s.ProjectFile)
+
+	// a transaction is written as a whole
+	err = s.Update(func(tx model.Store) error {
+		for _, name := range []string{"d", "e"} {
+			_, err := tx.AddProject(model.Project{Name: name})
+			require.NoError(t, err)
+		}
+		assert.NoFileExists(t, s.JournalFile)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.FileExists(t, s.JournalFile)
+
+	reopened := newJournalStore(t, dir, 3)
+	assert.Len(t, reopened.Projects(), 5)
+}
+
+func Test_JournalInterruptedWrite(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tom")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	s := newJournalStore(t, dir, 100)
+	_, err = s.AddProject(model.Project{Name: "project"})
+	require.NoError(t, err)
+
+	// simulate a crash in the middle of a write
+	file, err := os.OpenFile(s.JournalFile, os.O_WRONLY|os.O_APPEND, 0600)
+	require.NoError(t, err)
+	_, err = file.WriteString(`{"action":"put","type":"project","id":"123","da`)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	reopened := newJournalStore(t, dir, 100)
+	assert.Len(t, reopened.Projects(), 1, "the incomplete entry must be ignored")
+
+	_, err = reopened.AddProject(model.Project{Name: "second"})
+	require.NoError(t, err)
+
+	reopened = newJournalStore(t, dir, 100)
+	assert.Len(t, reopened.Projects(), 2, "new entries must be readable after an incomplete entry")
+}
+
+func Test_JournalBackups(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tom")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	backupDir := filepath.Join(dir, "backup")
+	s, err := store.Open(store.Options{Dir: dir, BackupDir: backupDir, MaxBackups: 10, Journal: true, JournalCompactSize: 100})
+	require.NoError(t, err)
+
+	for _, name := range []string{"a", "b", "c"} {
+		_, err := s.AddProject(model.Project{Name: name})
+		require.NoError(t, err)
+	}
+	assert.EqualValues(t, 1, countBackups(backupDir), "the changes of a command must only create a single backup")
+
+	// a failed backup fails the change
+	require.NoError(t, os.RemoveAll(backupDir))
+	require.NoError(t, ioutil.WriteFile(backupDir, []byte("file"), 0600))
+	s, err = store.Open(store.Options{Dir: dir, BackupDir: backupDir, MaxBackups: 10, Journal: true, JournalCompactSize: 100})
+	require.NoError(t, err)
+	_, err = s.AddProject(model.Project{Name: "d"})
+	assert.Error(t, err)
+}
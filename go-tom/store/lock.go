@@ -0,0 +1,88 @@
+package store
+
+import (
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+// ErrLockTimeout is returned when the lock of a data directory couldn't be acquired within the configured timeout
+var ErrLockTimeout = fmt.Errorf("timeout while waiting for the lock of the data directory")
+
+const lockRetryInterval = 25 * time.Millisecond
+
+// dirLock is an advisory lock of a data directory, which is shared by all processes using the same directory.
+// Readers acquire a shared lock, writers acquire an exclusive lock.
+type dirLock struct {
+	path    string
+	timeout time.Duration
+	// held is 1 while an operation of several steps holds the exclusive lock, e.g. Undo.
+	// The locks of the steps are granted without locking the file again.
+	held *int32
+}
+
+func newDirLock(path string, timeout time.Duration) dirLock {
+	return dirLock{path: path, timeout: timeout, held: new(int32)}
+}
+
+func (l dirLock) lockShared() (func(), error) {
+	return l.lock(false)
+}
+
+func (l dirLock) lockExclusive() (func(), error) {
+	return l.lock(true)
+}
+
+// holdExclusive acquires the exclusive lock for an operation of several steps, which lock the directory themselves.
+// The returned function releases the lock.
+func (l dirLock) holdExclusive() (func(), error) {
+	unlock, err := l.lock(true)
+	if err != nil || l.held == nil {
+		return unlock, err
+	}
+
+	atomic.StoreInt32(l.held, 1)
+	return func() {
+		atomic.StoreInt32(l.held, 0)
+		unlock()
+	}, nil
+}
+
+// lock blocks until the lock was acquired or until the timeout expired.
+// The returned function releases the lock.
+func (l dirLock) lock(exclusive bool) (func(), error) {
+	if l.path == "" || l.held != nil && atomic.LoadInt32(l.held) == 1 {
+		return func() {}, nil
+	}
+
+	file, err := os.OpenFile(l.path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil && os.IsPermission(err) && !exclusive {
+		// a read-only data directory can't be modified by other processes
+		return func() {}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(l.timeout)
+	for {
+		ok, err := tryLockFile(file, exclusive)
+		if err != nil {
+			_ = file.Close()
+			return nil, err
+		}
+
+		if ok {
+			return func() {
+				_ = unlockFile(file)
+				_ = file.Close()
+			}, nil
+		}
+
+		if !time.Now().Before(deadline) {
+			_ = file.Close()
+			return nil, fmt.Errorf("%w %s after %s, it's used by another process", ErrLockTimeout, l.path, l.timeout.String())
+		}
+		time.Sleep(lockRetryInterval)
+	}
+}
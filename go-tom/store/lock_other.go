@@ -0,0 +1,14 @@
+//go:build !unix && !windows
+
+package store
+
+import "os"
+
+// file locking isn't supported on this platform
+func tryLockFile(file *os.File, exclusive bool) (bool, error) {
+	return true, nil
+}
+
+func unlockFile(file *os.File) error {
+	return nil
+}
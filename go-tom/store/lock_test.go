@@ -0,0 +1,119 @@
+package store
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jansorg/tom/go-tom/model"
+)
+
+func Test_DirLock(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tom")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	lock := dirLock{path: filepath.Join(dir, "tom.lock"), timeout: 100 * time.Millisecond}
+
+	// shared locks don't block each other
+	unlockShared, err := lock.lockShared()
+	require.NoError(t, err)
+	unlockShared2, err := lock.lockShared()
+	require.NoError(t, err)
+
+	_, err = lock.lockExclusive()
+	require.Error(t, err, "the exclusive lock must not be granted while shared locks are held")
+	assert.True(t, errors.Is(err, ErrLockTimeout))
+
+	unlockShared()
+	unlockShared2()
+
+	unlockExclusive, err := lock.lockExclusive()
+	require.NoError(t, err)
+
+	_, err = lock.lockShared()
+	require.Error(t, err, "a shared lock must not be granted while the exclusive lock is held")
+	assert.True(t, errors.Is(err, ErrLockTimeout))
+
+	// waits until the lock is released
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		unlockExclusive()
+	}()
+	unlockShared, err = lock.lockShared()
+	require.NoError(t, err)
+	unlockShared()
+}
+
+func Test_DataStoreLockTimeout(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tom")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	s, err := newDataStore(Options{Dir: dir, LockTimeout: 50 * time.Millisecond})
+	require.NoError(t, err)
+
+	unlock, err := s.lock.lockShared()
+	require.NoError(t, err)
+	defer unlock()
+
+	_, err = s.AddTag(model.Tag{Name: "tag"})
+	require.Error(t, err, "a write must fail while another process is reading")
+	assert.True(t, errors.Is(err, ErrLockTimeout))
+}
+
+func Test_DirLockHoldExclusive(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tom")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	lock := newDirLock(filepath.Join(dir, "tom.lock"), 100*time.Millisecond)
+	other := newDirLock(filepath.Join(dir, "tom.lock"), 100*time.Millisecond)
+
+	release, err := lock.holdExclusive()
+	require.NoError(t, err)
+
+	// the steps of the operation lock the directory themselves
+	unlockShared, err := lock.lockShared()
+	require.NoError(t, err)
+	unlockShared()
+	unlockExclusive, err := lock.lockExclusive()
+	require.NoError(t, err)
+	unlockExclusive()
+
+	_, err = other.lockShared()
+	require.Error(t, err, "other processes must wait until the operation is complete")
+	assert.True(t, errors.Is(err, ErrLockTimeout))
+
+	release()
+	unlockShared, err = other.lockShared()
+	require.NoError(t, err)
+	unlockShared()
+}
+
+func Test_RemoveOldBackupsBySession(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tom")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	created := time.Date(2019, 1, 1, 10, 0, 0, 0, time.UTC)
+	for _, session := range []string{"a", "b", "b", "b", "c"} {
+		backupDir, err := newBackupDir(dir, created)
+		require.NoError(t, err)
+		require.NoError(t, writeBackupInfo(backupDir, BackupInfo{Created: created, Session: session}, nil))
+		created = created.Add(time.Minute)
+	}
+
+	// the backups of a session are removed together
+	removeOldBackups(dir, 3, nil)
+	backups, err := listBackups(dir, nil)
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+	assert.EqualValues(t, "c", backups[0].Session)
+}
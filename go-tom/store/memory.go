@@ -0,0 +1,697 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jansorg/tom/go-tom/model"
+	"github.com/jansorg/tom/go-tom/util"
+)
+
+// NewMemoryStore returns an empty store, which keeps its data in memory
+func NewMemoryStore() *MemoryStore {
+	s := &MemoryStore{}
+	s.setDataLocked(nil, nil, nil, nil)
+	return s
+}
+
+// MemoryStore is a store without data directory. Nothing is written to disk and the data is lost when the store isn't used anymore.
+// It's meant to be used by library users and tests, it supports the same operations as the stores of the data formats.
+// Backups aren't supported.
+type MemoryStore struct {
+	mu          sync.RWMutex
+	batch       bool
+	events      observers
+	projectsMap map[string]*model.Project
+	projects    []*model.Project
+	tags        []*model.Tag
+	clients     []*model.Client
+	frames      []*model.Frame
+}
+
+func (m *MemoryStore) DirPath() string {
+	return ""
+}
+
+func (m *MemoryStore) BackupDirPath() string {
+	return ""
+}
+
+func (m *MemoryStore) MaxBackups() int {
+	return 0
+}
+
+// Update runs fn in a transaction.
+// If fn returns an error, then the data is reset to the state before fn was called and the events are discarded.
+func (m *MemoryStore) Update(fn func(tx model.Store) error) (err error) {
+	m.mu.Lock()
+	if m.batch {
+		m.mu.Unlock()
+		return ErrNestedTransaction
+	}
+	m.batch = true
+	projects, tags, clients, frames := copyValues(m.projects, m.tags, m.clients, m.frames)
+	m.mu.Unlock()
+	defer m.notify()
+
+	defer func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		m.batch = false
+		if err != nil {
+			m.events.discard()
+			m.setDataLocked(projects, tags, clients, frames)
+		}
+	}()
+	return fn(m)
+}
+
+// Subscribe adds an observer of the changes of this store
+func (m *MemoryStore) Subscribe(observer model.Observer) func() {
+	return m.events.subscribe(observer)
+}
+
+// notify passes the events of the changes to the observers, unless a transaction is running.
+// It must be called without holding the lock of the store.
+func (m *MemoryStore) notify() {
+	m.mu.RLock()
+	inTransaction := m.batch
+	m.mu.RUnlock()
+
+	if !inTransaction {
+		m.events.flush()
+	}
+}
+
+// copyValues returns copies of the values, which are used to restore the data of a discarded transaction
+func copyValues(projects []*model.Project, tags []*model.Tag, clients []*model.Client, frames []*model.Frame) ([]*model.Project, []*model.Tag, []*model.Client, []*model.Frame) {
+	projectCopies := make([]*model.Project, 0, len(projects))
+	for _, p := range projects {
+		copied := *p
+		projectCopies = append(projectCopies, &copied)
+	}
+	tagCopies := make([]*model.Tag, 0, len(tags))
+	for _, t := range tags {
+		copied := *t
+		tagCopies = append(tagCopies, &copied)
+	}
+	clientCopies := make([]*model.Client, 0, len(clients))
+	for _, c := range clients {
+		copied := *c
+		clientCopies = append(clientCopies, &copied)
+	}
+	frameCopies := make([]*model.Frame, 0, len(frames))
+	for _, f := range frames {
+		copied := *f
+		copied.TagIDs = append([]string(nil), f.TagIDs...)
+		copied.Fields = nil
+		copied.SetFields(f.Fields)
+		frameCopies = append(frameCopies, &copied)
+	}
+	return projectCopies, tagCopies, clientCopies, frameCopies
+}
+
+func (m *MemoryStore) setDataLocked(projects []*model.Project, tags []*model.Tag, clients []*model.Client, frames []*model.Frame) {
+	if projects == nil {
+		projects = []*model.Project{}
+	}
+	if tags == nil {
+		tags = []*model.Tag{}
+	}
+	if clients == nil {
+		clients = []*model.Client{}
+	}
+	if frames == nil {
+		frames = []*model.Frame{}
+	}
+
+	m.projects = projects
+	m.tags = tags
+	m.clients = clients
+	m.frames = frames
+	m.updateProjectsMapping()
+	m.updateAllProjectInternals()
+	m.sortProjects()
+	m.sortTags()
+	m.sortClients()
+	m.sortFrames()
+	m.events.setValues(m.projects, m.tags, m.clients, m.frames, true)
+}
+
+func (m *MemoryStore) replaceAll(projects []*model.Project, tags []*model.Tag, clients []*model.Client, frames []*model.Frame, _ bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.setDataLocked(projects, tags, clients, frames)
+	return nil
+}
+
+func (m *MemoryStore) sortProjects() {
+	sort.SliceStable(m.projects, func(i, j int) bool {
+		return strings.Compare(m.projects[i].GetFullName("/"), m.projects[j].GetFullName("/")) < 0
+	})
+}
+
+func (m *MemoryStore) sortTags() {
+	sort.SliceStable(m.tags, func(i, j int) bool {
+		return strings.Compare(m.tags[i].Name, m.tags[j].Name) < 0
+	})
+}
+
+func (m *MemoryStore) sortClients() {
+	sort.SliceStable(m.clients, func(i, j int) bool {
+		return strings.Compare(m.clients[i].Name, m.clients[j].Name) < 0
+	})
+}
+
+func (m *MemoryStore) sortFrames() {
+	sort.SliceStable(m.frames, func(i, j int) bool {
+		return m.frames[i].IsBefore(m.frames[j])
+	})
+}
+
+func (m *MemoryStore) updateProjectsMapping() {
+	m.projectsMap = map[string]*model.Project{}
+	for _, p := range m.projects {
+		m.projectsMap[p.ID] = p
+	}
+}
+
+func (m *MemoryStore) updateAllProjectInternals() {
+	for _, p := range m.projects {
+		m.updateProjectInternals(p)
+	}
+}
+
+func (m *MemoryStore) updateProjectInternals(p *model.Project) {
+	p.Store = m
+
+	p.FullName = []string{p.Name}
+	if p.ParentID == "" {
+		return
+	}
+
+	parents := []string{p.Name}
+
+	// missing and cyclic parents are reported by "tom check"
+	visited := map[string]bool{p.ID: true}
+	id := p.ParentID
+	for id != "" && !visited[id] {
+		parent, ok := m.projectsMap[id]
+		if !ok {
+			break
+		}
+
+		visited[id] = true
+		id = parent.ParentID
+		parents = append([]string{parent.Name}, parents...)
+	}
+
+	p.FullName = parents
+}
+
+func (m *MemoryStore) Reset(projects, tags, frames bool) (int, int, int, error) {
+	defer m.notify()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var projectCount, tagCount, frameCount int
+	if projects {
+		projectCount = len(m.projects)
+		for _, p := range m.projects {
+			m.events.projectChanged(model.EventRemoved, p.ID, nil)
+		}
+		m.projects = []*model.Project{}
+		m.updateProjectsMapping()
+	}
+	if tags {
+		tagCount = len(m.tags)
+		for _, t := range m.tags {
+			m.events.tagChanged(model.EventRemoved, t.ID, nil)
+		}
+		m.tags = []*model.Tag{}
+	}
+	if frames {
+		frameCount = len(m.frames)
+		for _, f := range m.frames {
+			m.events.frameChanged(model.EventRemoved, f.ID, f, nil)
+		}
+		m.frames = []*model.Frame{}
+	}
+	return projectCount, tagCount, frameCount, nil
+}
+
+func (m *MemoryStore) Projects() model.ProjectList {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.projects
+}
+
+func (m *MemoryStore) ProjectByID(id string) (*model.Project, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	p, ok := m.projectsMap[id]
+	if !ok {
+		return nil, fmt.Errorf("no project found for %s", id)
+	}
+	return p, nil
+}
+
+func (m *MemoryStore) ProjectIsSameOrChild(parentID, id string) bool {
+	if parentID == id {
+		return true
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	visited := map[string]bool{}
+	for id != "" && !visited[id] {
+		if id == parentID {
+			return true
+		}
+
+		project, ok := m.projectsMap[id]
+		if !ok {
+			return false
+		}
+		visited[id] = true
+		id = project.ParentID
+	}
+	return false
+}
+
+func (m *MemoryStore) AddProject(project model.Project) (*model.Project, error) {
+	defer m.notify()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	project.ID = model.NextID()
+	m.projects = append(m.projects, &project)
+	m.updateProjectsMapping()
+	m.updateProjectInternals(&project)
+	m.sortProjects()
+	m.events.projectChanged(model.EventAdded, project.ID, &project)
+	return &project, nil
+}
+
+func (m *MemoryStore) UpdateProject(project model.Project) (*model.Project, error) {
+	if err := project.Validate(); err != nil {
+		return nil, err
+	}
+
+	defer m.notify()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	existing, ok := m.projectsMap[project.ID]
+	if !ok {
+		return nil, fmt.Errorf("no project found for %s", project.ID)
+	}
+
+	*existing = project
+	// the full names of the subprojects depend on this project
+	m.updateAllProjectInternals()
+	m.sortProjects()
+	m.events.projectChanged(model.EventUpdated, existing.ID, existing)
+	return existing, nil
+}
+
+func (m *MemoryStore) RemoveProject(id string) error {
+	defer m.notify()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, p := range m.projects {
+		if p.ID == id {
+			m.projects = append(m.projects[:i], m.projects[i+1:]...)
+			m.updateProjectsMapping()
+			m.events.projectChanged(model.EventRemoved, p.ID, nil)
+			return nil
+		}
+	}
+	return fmt.Errorf("project %s not found", id)
+}
+
+func (m *MemoryStore) FindFirstProject(filter func(*model.Project) bool) (*model.Project, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, p := range m.projects {
+		if filter(p) {
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("no matching project found")
+}
+
+func (m *MemoryStore) FindProjects(filter func(*model.Project) bool) []*model.Project {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var result []*model.Project
+	for _, p := range m.projects {
+		if filter(p) {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+func (m *MemoryStore) Tags() []*model.Tag {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.tags
+}
+
+func (m *MemoryStore) AddTag(tag model.Tag) (*model.Tag, error) {
+	if err := tag.Validate(); err != nil {
+		return nil, err
+	}
+
+	defer m.notify()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tag.ID = model.NextID()
+	m.tags = append(m.tags, &tag)
+	m.sortTags()
+	m.events.tagChanged(model.EventAdded, tag.ID, &tag)
+	return &tag, nil
+}
+
+func (m *MemoryStore) UpdateTag(tag model.Tag) (*model.Tag, error) {
+	if err := tag.Validate(); err != nil {
+		return nil, err
+	}
+
+	defer m.notify()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, existing := range m.tags {
+		if existing.ID == tag.ID {
+			*existing = tag
+			m.sortTags()
+			m.events.tagChanged(model.EventUpdated, existing.ID, existing)
+			return existing, nil
+		}
+	}
+	return nil, fmt.Errorf("tag %s not found", tag.ID)
+}
+
+func (m *MemoryStore) RemoveTag(id string) error {
+	defer m.notify()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, t := range m.tags {
+		if t.ID == id {
+			m.tags = append(m.tags[:i], m.tags[i+1:]...)
+			m.events.tagChanged(model.EventRemoved, t.ID, nil)
+			return nil
+		}
+	}
+	return fmt.Errorf("tag %s not found", id)
+}
+
+func (m *MemoryStore) FindFirstTag(filter func(*model.Tag) bool) (*model.Tag, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, tag := range m.tags {
+		if filter(tag) {
+			return tag, nil
+		}
+	}
+	return nil, ErrTagNotFound
+}
+
+func (m *MemoryStore) FindTags(filter func(*model.Tag) bool) []*model.Tag {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var result []*model.Tag
+	for _, tag := range m.tags {
+		if filter(tag) {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
+
+func (m *MemoryStore) Clients() []*model.Client {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.clients
+}
+
+func (m *MemoryStore) AddClient(client model.Client) (*model.Client, error) {
+	if err := client.Validate(); err != nil {
+		return nil, err
+	}
+
+	defer m.notify()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	client.ID = model.NextID()
+	m.clients = append(m.clients, &client)
+	m.sortClients()
+	m.events.clientChanged(model.EventAdded, client.ID, &client)
+	return &client, nil
+}
+
+func (m *MemoryStore) UpdateClient(client model.Client) (*model.Client, error) {
+	if err := client.Validate(); err != nil {
+		return nil, err
+	}
+
+	defer m.notify()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, existing := range m.clients {
+		if existing.ID == client.ID {
+			*existing = client
+			m.sortClients()
+			m.events.clientChanged(model.EventUpdated, existing.ID, existing)
+			return existing, nil
+		}
+	}
+	return nil, fmt.Errorf("client %s not found", client.ID)
+}
+
+func (m *MemoryStore) RemoveClient(id string) error {
+	defer m.notify()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, c := range m.clients {
+		if c.ID == id {
+			m.clients = append(m.clients[:i], m.clients[i+1:]...)
+			m.events.clientChanged(model.EventRemoved, c.ID, nil)
+			return nil
+		}
+	}
+	return fmt.Errorf("client %s not found", id)
+}
+
+func (m *MemoryStore) FindFirstClient(filter func(*model.Client) bool) (*model.Client, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, client := range m.clients {
+		if filter(client) {
+			return client, nil
+		}
+	}
+	return nil, ErrClientNotFound
+}
+
+func (m *MemoryStore) FindClients(filter func(*model.Client) bool) []*model.Client {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var result []*model.Client
+	for _, client := range m.clients {
+		if filter(client) {
+			result = append(result, client)
+		}
+	}
+	return result
+}
+
+func (m *MemoryStore) Frames() model.FrameList {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.frames
+}
+
+func (m *MemoryStore) AddFrame(frame model.Frame) (*model.Frame, error) {
+	if err := frame.Validate(false); err != nil {
+		return nil, err
+	}
+
+	defer m.notify()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	frame.ID = model.NextID()
+	now := time.Now()
+	if frame.Updated == nil {
+		frame.Updated = &now
+	}
+	if frame.Created == nil {
+		frame.Created = &now
+	}
+	m.frames = append(m.frames, &frame)
+	m.sortFrames()
+	m.events.frameChanged(model.EventAdded, frame.ID, nil, &frame)
+	return &frame, nil
+}
+
+func (m *MemoryStore) UpdateFrame(frame model.Frame) (*model.Frame, error) {
+	if err := frame.Validate(true); err != nil {
+		return nil, err
+	}
+
+	defer m.notify()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, existing := range m.frames {
+		if existing.ID == frame.ID {
+			now := time.Now()
+			frame.Updated = &now
+			frame.RecordChange(m.events.frameValue(existing), now, "")
+			*existing = frame
+			m.sortFrames()
+			m.events.frameChanged(model.EventUpdated, existing.ID, nil, existing)
+			return existing, nil
+		}
+	}
+	return nil, fmt.Errorf("frame %s not found", frame.ID)
+}
+
+func (m *MemoryStore) RemoveFrame(id string) error {
+	defer m.notify()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, f := range m.frames {
+		if f.ID == id {
+			m.frames = append(m.frames[:i], m.frames[i+1:]...)
+			m.events.frameChanged(model.EventRemoved, f.ID, nil, nil)
+			return nil
+		}
+	}
+	return fmt.Errorf("frame %s not found", id)
+}
+
+func (m *MemoryStore) FrameByID(id string) (*model.Frame, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, frame := range m.frames {
+		if frame.ID == id {
+			return frame, nil
+		}
+	}
+	return nil, fmt.Errorf("frame %s not found", id)
+}
+
+func (m *MemoryStore) FindFirstFrame(filter func(*model.Frame) bool) (*model.Frame, error) {
+	// the filter may query the store, the frames are iterated without holding the lock
+	for _, frame := range m.copyFrames() {
+		if filter(frame) {
+			return frame, nil
+		}
+	}
+	return nil, fmt.Errorf("no matching frame found")
+}
+
+func (m *MemoryStore) FindFrames(filter func(*model.Frame) (bool, error)) ([]*model.Frame, error) {
+	var result []*model.Frame
+	for _, frame := range m.copyFrames() {
+		if ok, err := filter(frame); err != nil {
+			return nil, err
+		} else if ok {
+			result = append(result, frame)
+		}
+	}
+	return result, nil
+}
+
+func (m *MemoryStore) copyFrames() []*model.Frame {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return append([]*model.Frame(nil), m.frames...)
+}
+
+func (m *MemoryStore) FramesByProject(projectIDs ...string) model.FrameList {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ids := util.MapStrings(projectIDs)
+	result := model.FrameList{}
+	for _, frame := range m.frames {
+		if ids[frame.ProjectId] {
+			result = append(result, frame)
+		}
+	}
+	return result
+}
+
+func (m *MemoryStore) FramesByTag(tagIDs ...string) model.FrameList {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ids := util.MapStrings(tagIDs)
+	result := model.FrameList{}
+	for _, frame := range m.frames {
+		for _, id := range frame.TagIDs {
+			if ids[id] {
+				result = append(result, frame)
+				break
+			}
+		}
+	}
+	return result
+}
+
+func (m *MemoryStore) FramesInRange(start, end *time.Time) model.FrameList {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := model.FrameList{}
+	for _, frame := range m.frames {
+		if frameInRange(frame, start, end) {
+			result = append(result, frame)
+		}
+	}
+	return result
+}
+
+func (m *MemoryStore) ActiveFrames() model.FrameList {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := model.FrameList{}
+	for _, frame := range m.frames {
+		if frame.IsActive() {
+			result = append(result, frame)
+		}
+	}
+	return result
+}
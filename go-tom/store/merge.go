@@ -0,0 +1,606 @@
+package store
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jansorg/tom/go-tom/model"
+)
+
+// Resolutions of a merge conflict
+const (
+	ResolveLocal = "local"
+	ResolveOther = "other"
+)
+
+// ErrNoMergeBase is returned by FindMergeBase if no backup was found, which both data directories had before they diverged
+var ErrNoMergeBase = fmt.Errorf("no backup found, which both data directories had before they diverged")
+
+// MergeConflict is a project, tag or frame, which was changed in both stores in different ways
+type MergeConflict struct {
+	Entity string `json:"entity"`
+	ID     string `json:"id"`
+	// Name is the full name of a project, the name of a tag or the full name of the project of a frame
+	Name string `json:"name"`
+	// LocalChange and OtherChange are the types of the changes since the common backup
+	LocalChange string `json:"localChange"`
+	OtherChange string `json:"otherChange"`
+	// Local and Other are the values of both stores, nil if the value was removed
+	Local interface{} `json:"local"`
+	Other interface{} `json:"other"`
+}
+
+func (c MergeConflict) String() string {
+	subject := fmt.Sprintf("%s %s", c.Entity, c.Name)
+	if c.Entity == journalFrame {
+		subject = fmt.Sprintf("frame %s of project %s", c.ID, c.Name)
+	}
+	return fmt.Sprintf("%s: %s locally, %s in the other directory", subject, c.LocalChange, c.OtherChange)
+}
+
+// MergeResolver returns ResolveLocal or ResolveOther to resolve a conflict
+type MergeResolver func(conflict MergeConflict) (string, error)
+
+// MergeResult is the merged data of two stores
+type MergeResult struct {
+	Projects []*model.Project
+	Tags     []*model.Tag
+	Clients  []*model.Client
+	Frames   []*model.Frame
+
+	// Changes are the changes of the local data
+	Changes []Change
+	// Conflicts are the conflicts, which weren't resolved. The local values are kept for them.
+	Conflicts []MergeConflict
+}
+
+// FindMergeBase returns the newest backup, which both data directories had before their data diverged.
+// The candidates are the backups of local, which are also stored in otherBackupDir. A synced backup directory contains
+// backups created after the data diverged, a candidate is only accepted if the data directory, which didn't create it,
+// had the data of the candidate or a later state of its creator before its own next backup.
+// ErrNoMergeBase is returned if no candidate is accepted, e.g. because the backups don't define which directory created them.
+func FindMergeBase(local, other model.Store, otherBackupDir string) (BackupInfo, error) {
+	localBackups, err := ListBackups(local)
+	if err != nil {
+		return BackupInfo{}, err
+	}
+
+	// the info files of the other backups may be encrypted with another key, their origin is unknown then
+	otherBackups, err := listBackups(otherBackupDir, storeCipher(local))
+	if err != nil {
+		return BackupInfo{}, err
+	}
+
+	// the backups of both directories, the local copy of a backup stored in both is used
+	backups := append([]BackupInfo(nil), localBackups...)
+	localNames := map[string]bool{}
+	for _, backup := range localBackups {
+		localNames[backup.Name()] = true
+	}
+	shared := map[string]bool{}
+	for _, backup := range otherBackups {
+		if localNames[backup.Name()] {
+			shared[backup.Name()] = true
+		} else {
+			backups = append(backups, backup)
+		}
+	}
+	sort.SliceStable(backups, func(i, j int) bool {
+		return backups[i].Created.Before(backups[j].Created)
+	})
+
+	finder := &mergeBaseFinder{
+		local:       local,
+		other:       other,
+		localOrigin: backupOrigin(local.DirPath()),
+		backups:     backups,
+		opened:      map[string]model.Store{},
+	}
+	defer finder.close()
+
+	for i := len(backups) - 1; i >= 0; i-- {
+		if shared[backups[i].Name()] && finder.accepts(i) {
+			return backups[i], nil
+		}
+	}
+	return BackupInfo{}, ErrNoMergeBase
+}
+
+// mergeBaseFinder verifies the candidates of FindMergeBase, the opened backups are shared by all candidates
+type mergeBaseFinder struct {
+	local       model.Store
+	other       model.Store
+	localOrigin string
+	// backups of both directories, sorted by creation time
+	backups []BackupInfo
+	opened  map[string]model.Store
+}
+
+// accepts returns if the backup at index is a common state of both data directories.
+// The next backup of the directory, which didn't create it, contains the data before its first command after the candidate.
+// Without such a backup, the current data of the directory is used. The data has to match the candidate
+// or a later state of the creator, which had no other changes since then.
+// A backup of unknown origin after the candidate makes it unclear which directory modified the data.
+func (f *mergeBaseFinder) accepts(index int) bool {
+	creator := f.backups[index].Origin
+	if creator == "" {
+		return false
+	}
+
+	creatorData, otherData := f.other, f.local
+	if creator == f.localOrigin {
+		creatorData, otherData = f.local, f.other
+	}
+
+	states := []BackupInfo{f.backups[index]}
+	for _, backup := range f.backups[index+1:] {
+		if backup.Origin == "" {
+			return false
+		}
+		if backup.Origin != creator {
+			if otherData = f.open(backup); otherData == nil {
+				return false
+			}
+			creatorData = nil
+			break
+		}
+		states = append(states, backup)
+	}
+
+	for _, state := range states {
+		data := f.open(state)
+		if data == nil {
+			return false
+		}
+		if len(Diff(data, otherData)) == 0 {
+			return true
+		}
+	}
+	// the current data of the creator is only a state before the next backup of the other directory if there's no such backup
+	return creatorData != nil && len(Diff(creatorData, otherData)) == 0
+}
+
+// open returns the data of the backup, nil if it couldn't be read, e.g. if it's encrypted with another key
+func (f *mergeBaseFinder) open(backup BackupInfo) model.Store {
+	if data, ok := f.opened[backup.Dir]; ok {
+		return data
+	}
+
+	data, err := OpenBackup(f.local, backup.Dir)
+	if err != nil {
+		data = nil
+	}
+	f.opened[backup.Dir] = data
+	return data
+}
+
+func (f *mergeBaseFinder) close() {
+	for _, data := range f.opened {
+		if data != nil {
+			CloseStore(data)
+		}
+	}
+}
+
+// Merge merges the projects, tags, clients and frames of local and other, which are matched by ID.
+// base is the common ancestor of both, e.g. returned by FindMergeBase. It's used to tell apart changes and deletions on both sides.
+// Without base, values are never removed by the merge. Removed projects and tags are kept while merged frames still reference them.
+// Frames, which were changed on both sides, are resolved by their update timestamps.
+// The remaining conflicts are passed to resolve, they're returned by the result if resolve is nil.
+// Projects and tags, which were added on both sides with the same full name, are merged into the local project or tag.
+func Merge(local, other, base model.Store, resolve MergeResolver) (*MergeResult, error) {
+	var baseProjects, baseTags, baseClients, baseFrames []diffValue
+	if base != nil {
+		baseProjects, baseTags, baseClients, baseFrames = projectDiffValues(base), tagDiffValues(base), clientDiffValues(base), frameDiffValues(base)
+	}
+
+	m := merger{resolve: resolve, result: &MergeResult{}}
+	localProjectName := func(id string) string {
+		if name := projectName(local, id); name != id {
+			return name
+		}
+		return projectName(other, id)
+	}
+
+	projectValues, err := m.mergeValues(journalProject, baseProjects, projectDiffValues(local), projectDiffValues(other), nil, func(value interface{}) string {
+		return localProjectName(value.(*model.Project).ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	tagValues, err := m.mergeValues(journalTag, baseTags, tagDiffValues(local), tagDiffValues(other), nil, func(value interface{}) string {
+		return value.(*model.Tag).Name
+	})
+	if err != nil {
+		return nil, err
+	}
+	clientValues, err := m.mergeValues(journalClient, baseClients, clientDiffValues(local), clientDiffValues(other), nil, func(value interface{}) string {
+		return value.(*model.Client).Name
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// copies are returned, the values of the stores must not be modified
+	result := m.result
+	for _, v := range projectValues {
+		copied := *v.(*model.Project)
+		result.Projects = append(result.Projects, &copied)
+	}
+	for _, v := range tagValues {
+		copied := *v.(*model.Tag)
+		result.Tags = append(result.Tags, &copied)
+	}
+	for _, v := range clientValues {
+		copied := *v.(*model.Client)
+		result.Clients = append(result.Clients, &copied)
+	}
+
+	knownIDs := func(stores ...model.Store) map[string]bool {
+		ids := map[string]bool{}
+		for _, s := range stores {
+			if s == nil {
+				continue
+			}
+			for _, p := range s.Projects() {
+				ids["p"+p.ID] = true
+			}
+			for _, t := range s.Tags() {
+				ids["t"+t.ID] = true
+			}
+		}
+		return ids
+	}(local, base)
+	projectIDs := result.unifyProjects(func(id string) bool { return knownIDs["p"+id] })
+	tagIDs := result.unifyTags(func(id string) bool { return knownIDs["t"+id] })
+
+	// the frames of the other side reference the unified projects and tags
+	var otherFrames []diffValue
+	for _, f := range other.Frames() {
+		otherFrames = append(otherFrames, diffValue{id: f.ID, value: remapFrame(f, projectIDs, tagIDs)})
+	}
+	frameValues, err := m.mergeValues(journalFrame, baseFrames, frameDiffValues(local), otherFrames, newerFrame, func(value interface{}) string {
+		return localProjectName(value.(*model.Frame).ProjectId)
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range frameValues {
+		result.Frames = append(result.Frames, remapFrame(v.(*model.Frame), nil, nil))
+	}
+	result.keepReferenced(local, other)
+
+	names := projectFullNames(result.Projects)
+	mergedProjectName := func(id string) string {
+		if name, ok := names[id]; ok {
+			return name
+		}
+		return id
+	}
+	result.Changes = append(result.Changes, compareValues(journalProject, projectDiffValues(local), projectListValues(result.Projects), func(value interface{}) string {
+		return projectName(local, value.(*model.Project).ID)
+	}, func(value interface{}) string {
+		return mergedProjectName(value.(*model.Project).ID)
+	})...)
+	result.Changes = append(result.Changes, compareValues(journalTag, tagDiffValues(local), tagListValues(result.Tags), func(value interface{}) string {
+		return value.(*model.Tag).Name
+	}, func(value interface{}) string {
+		return value.(*model.Tag).Name
+	})...)
+	result.Changes = append(result.Changes, compareValues(journalClient, clientDiffValues(local), clientListValues(result.Clients), func(value interface{}) string {
+		return value.(*model.Client).Name
+	}, func(value interface{}) string {
+		return value.(*model.Client).Name
+	})...)
+	result.Changes = append(result.Changes, compareValues(journalFrame, frameDiffValues(local), frameListValues(result.Frames), func(value interface{}) string {
+		return projectName(local, value.(*model.Frame).ProjectId)
+	}, func(value interface{}) string {
+		return mergedProjectName(value.(*model.Frame).ProjectId)
+	})...)
+	return result, nil
+}
+
+// ApplyMerge replaces the data of s with the merged data. A backup is created first, the merge can be reverted by Undo.
+func ApplyMerge(s model.Store, result *MergeResult) error {
+	if len(result.Conflicts) > 0 {
+		return fmt.Errorf("%d conflicts weren't resolved", len(result.Conflicts))
+	}
+	return ReplaceWithClients(s, result.Projects, result.Tags, result.Clients, result.Frames)
+}
+
+type merger struct {
+	resolve MergeResolver
+	result  *MergeResult
+}
+
+// mergeValues returns the merged values, the local values come first.
+// newer returns a positive number if the first value was updated after the second, a negative number if it's the other way round and 0 if that's unknown.
+func (m *merger) mergeValues(entity string, base, local, other []diffValue, newer func(a, b interface{}) int, name func(interface{}) string) ([]interface{}, error) {
+	baseByID, localByID, otherByID := diffValuesByID(base), diffValuesByID(local), diffValuesByID(other)
+
+	var ids []string
+	seen := map[string]bool{}
+	for _, values := range [][]diffValue{local, other} {
+		for _, v := range values {
+			if !seen[v.id] {
+				seen[v.id] = true
+				ids = append(ids, v.id)
+			}
+		}
+	}
+
+	var result []interface{}
+	for _, id := range ids {
+		l, inLocal := localByID[id]
+		o, inOther := otherByID[id]
+		b, inBase := baseByID[id]
+
+		var value interface{}
+		conflict := false
+		switch {
+		case inLocal && inOther && sameJSON(l, o):
+			value = l
+		case inBase && inLocal && sameJSON(l, b):
+			// only changed or removed by the other side
+			value = o
+		case inBase && inOther && sameJSON(o, b):
+			// only changed or removed by the local side
+			value = l
+		case !inBase && !inOther:
+			value = l
+		case !inBase && !inLocal:
+			value = o
+		case inLocal && inOther && newer != nil && newer(l, o) > 0:
+			value = l
+		case inLocal && inOther && newer != nil && newer(l, o) < 0:
+			value = o
+		default:
+			conflict = true
+		}
+
+		if conflict {
+			c := MergeConflict{
+				Entity:      entity,
+				ID:          id,
+				LocalChange: mergeChangeType(inBase, inLocal),
+				OtherChange: mergeChangeType(inBase, inOther),
+				Local:       l,
+				Other:       o,
+			}
+			if inLocal {
+				c.Name = name(l)
+			} else {
+				c.Name = name(o)
+			}
+
+			resolution := ""
+			if m.resolve != nil {
+				var err error
+				if resolution, err = m.resolve(c); err != nil {
+					return nil, err
+				}
+			}
+
+			switch resolution {
+			case ResolveOther:
+				value = o
+			case ResolveLocal:
+				value = l
+			case "":
+				m.result.Conflicts = append(m.result.Conflicts, c)
+				value = l
+			default:
+				return nil, fmt.Errorf("unknown resolution %s", resolution)
+			}
+		}
+
+		if value != nil {
+			result = append(result, value)
+		}
+	}
+	return result, nil
+}
+
+func mergeChangeType(inBase, exists bool) string {
+	switch {
+	case !inBase:
+		return ChangeAdded
+	case !exists:
+		return ChangeRemoved
+	default:
+		return ChangeModified
+	}
+}
+
+func newerFrame(a, b interface{}) int {
+	updatedA, updatedB := a.(*model.Frame).Updated, b.(*model.Frame).Updated
+	switch {
+	case updatedA == nil || updatedB == nil || updatedA.Equal(*updatedB):
+		return 0
+	case updatedA.After(*updatedB):
+		return 1
+	default:
+		return -1
+	}
+}
+
+// keepReferenced adds the projects and tags, which were removed by one side but are still referenced by the merged data
+func (r *MergeResult) keepReferenced(stores ...model.Store) {
+	for added := true; added; {
+		added = false
+
+		projectIDs := map[string]bool{}
+		for _, p := range r.Projects {
+			projectIDs[p.ID] = true
+		}
+		tagIDs := map[string]bool{}
+		for _, t := range r.Tags {
+			tagIDs[t.ID] = true
+		}
+
+		var missingProjects, missingTags []string
+		for _, p := range r.Projects {
+			if p.ParentID != "" && !projectIDs[p.ParentID] {
+				missingProjects = append(missingProjects, p.ParentID)
+			}
+		}
+		for _, f := range r.Frames {
+			if f.ProjectId != "" && !projectIDs[f.ProjectId] {
+				missingProjects = append(missingProjects, f.ProjectId)
+			}
+			for _, id := range f.TagIDs {
+				if !tagIDs[id] {
+					missingTags = append(missingTags, id)
+				}
+			}
+		}
+
+		for _, id := range missingProjects {
+			for _, s := range stores {
+				if p, err := s.ProjectByID(id); err == nil && !projectIDs[id] {
+					copied := *p
+					r.Projects = append(r.Projects, &copied)
+					projectIDs[id] = true
+					added = true
+				}
+			}
+		}
+		for _, id := range missingTags {
+			for _, s := range stores {
+				if t, err := s.FindFirstTag(func(t *model.Tag) bool { return t.ID == id }); err == nil && !tagIDs[id] {
+					copied := *t
+					r.Tags = append(r.Tags, &copied)
+					tagIDs[id] = true
+				}
+			}
+		}
+	}
+}
+
+// unifyProjects merges the projects, which are unknown to the local side, into the local projects with the same full name.
+// It returns the IDs of the merged projects mapped to the IDs of the local projects.
+func (r *MergeResult) unifyProjects(known func(id string) bool) map[string]string {
+	remapped := map[string]string{}
+	for merged := true; merged; {
+		merged = false
+
+		names := projectFullNames(r.Projects)
+		byName := map[string]string{}
+		for _, p := range r.Projects {
+			if known(p.ID) {
+				byName[names[p.ID]] = p.ID
+			}
+		}
+
+		for i, p := range r.Projects {
+			target, ok := byName[names[p.ID]]
+			if known(p.ID) || !ok {
+				continue
+			}
+
+			r.Projects = append(r.Projects[:i], r.Projects[i+1:]...)
+			for _, child := range r.Projects {
+				if child.ParentID == p.ID {
+					child.ParentID = target
+				}
+			}
+			remapped[p.ID] = target
+			// the names of the children have to be computed again
+			merged = true
+			break
+		}
+	}
+	return remapped
+}
+
+// unifyTags merges the tags, which are unknown to the local side, into the local tags with the same name and parent.
+// It returns the IDs of the merged tags mapped to the IDs of the local tags.
+func (r *MergeResult) unifyTags(known func(id string) bool) map[string]string {
+	remapped := map[string]string{}
+	for merged := true; merged; {
+		merged = false
+
+		byName := map[string]string{}
+		for _, t := range r.Tags {
+			if known(t.ID) {
+				byName[t.ParentID+"/"+t.Name] = t.ID
+			}
+		}
+
+		for i, t := range r.Tags {
+			target, ok := byName[t.ParentID+"/"+t.Name]
+			if known(t.ID) || !ok {
+				continue
+			}
+
+			r.Tags = append(r.Tags[:i], r.Tags[i+1:]...)
+			for _, child := range r.Tags {
+				if child.ParentID == t.ID {
+					child.ParentID = target
+				}
+			}
+			remapped[t.ID] = target
+			// the children may match local tags now
+			merged = true
+			break
+		}
+	}
+	return remapped
+}
+
+// remapFrame returns a copy of f, which references the mapped projects and tags
+func remapFrame(f *model.Frame, projectIDs, tagIDs map[string]string) *model.Frame {
+	copied := *f
+	if target, ok := projectIDs[f.ProjectId]; ok {
+		copied.ProjectId = target
+	}
+
+	copied.TagIDs = nil
+	for _, id := range f.TagIDs {
+		if target, ok := tagIDs[id]; ok {
+			id = target
+		}
+		if !containsString(copied.TagIDs, id) {
+			copied.TagIDs = append(copied.TagIDs, id)
+		}
+	}
+	return &copied
+}
+
+// projectFullNames returns the full names of the projects by ID, it doesn't depend on the store of the projects
+func projectFullNames(projects []*model.Project) map[string]string {
+	byID := map[string]*model.Project{}
+	for _, p := range projects {
+		byID[p.ID] = p
+	}
+
+	result := map[string]string{}
+	for _, p := range projects {
+		var names []string
+		seen := map[string]bool{}
+		for current := p; current != nil && !seen[current.ID]; current = byID[current.ParentID] {
+			seen[current.ID] = true
+			names = append([]string{current.Name}, names...)
+		}
+		result[p.ID] = strings.Join(names, "/")
+	}
+	return result
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func diffValuesByID(values []diffValue) map[string]interface{} {
+	result := map[string]interface{}{}
+	for _, v := range values {
+		result[v.id] = v.value
+	}
+	return result
+}
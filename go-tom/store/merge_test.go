@@ -0,0 +1,241 @@
+package store_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jansorg/tom/go-tom/model"
+	"github.com/jansorg/tom/go-tom/store"
+	"github.com/jansorg/tom/go-tom/util"
+)
+
+// copyDir copies the files of source into target, like a file-sync tool
+func copyDir(t *testing.T, source, target string) {
+	err := filepath.Walk(source, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		relative, err := filepath.Rel(source, path)
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return os.MkdirAll(filepath.Join(target, relative), 0700)
+		}
+		return util.CopyFile(path, filepath.Join(target, relative), false)
+	})
+	require.NoError(t, err)
+}
+
+func findFrame(t *testing.T, s model.Store, id string) *model.Frame {
+	frame, err := s.FindFirstFrame(func(f *model.Frame) bool {
+		return f.ID == id
+	})
+	require.NoError(t, err)
+	return frame
+}
+
+func Test_Merge(t *testing.T) {
+	localDir, err := ioutil.TempDir("", "tom")
+	require.NoError(t, err)
+	defer os.RemoveAll(localDir)
+	otherDir, err := ioutil.TempDir("", "tom")
+	require.NoError(t, err)
+	defer os.RemoveAll(otherDir)
+
+	options := store.Options{Dir: localDir, BackupDir: filepath.Join(localDir, "backup"), MaxBackups: 20}
+	local, err := store.Open(options)
+	require.NoError(t, err)
+
+	acme, err := local.AddProject(model.Project{Name: "acme"})
+	require.NoError(t, err)
+	var frames []*model.Frame
+	for i := 0; i < 5; i++ {
+		start := time.Date(2019, 1, i+1, 10, 0, 0, 0, time.UTC)
+		end := start.Add(time.Hour)
+		frame, err := local.AddFrame(model.Frame{ProjectId: acme.ID, Start: &start, End: &end})
+		require.NoError(t, err)
+		frames = append(frames, frame)
+	}
+
+	// both directories share the state of the last sync
+	_, err = store.CreateBackup(local, "sync")
+	require.NoError(t, err)
+	copyDir(t, localDir, otherDir)
+	other, err := store.Open(store.Options{Dir: otherDir, BackupDir: filepath.Join(otherDir, "backup")})
+	require.NoError(t, err)
+
+	// changes of the other machine
+	acmeOther, err := other.ProjectByID(acme.ID)
+	require.NoError(t, err)
+	acmeOther.Name = "acme-other"
+	_, err = other.UpdateProject(*acmeOther)
+	require.NoError(t, err)
+	for _, index := range []int{0, 1, 4} {
+		frame := findFrame(t, other, frames[index].ID)
+		frame.Notes = "other"
+		_, err = other.UpdateFrame(*frame)
+		require.NoError(t, err)
+	}
+	require.NoError(t, other.RemoveFrame(frames[3].ID))
+	webOther, err := other.AddProject(model.Project{Name: "web", ParentID: acme.ID})
+	require.NoError(t, err)
+	tagOther, err := other.AddTag(model.Tag{Name: "review"})
+	require.NoError(t, err)
+	start := time.Date(2019, 2, 1, 10, 0, 0, 0, time.UTC)
+	otherFrame, err := other.AddFrame(model.Frame{ProjectId: webOther.ID, Start: &start, TagIDs: []string{tagOther.ID}})
+	require.NoError(t, err)
+
+	// changes of the local machine, they're more recent
+	acme.Name = "acme-local"
+	_, err = local.UpdateProject(*acme)
+	require.NoError(t, err)
+	frames[0].Notes = "local"
+	_, err = local.UpdateFrame(*frames[0])
+	require.NoError(t, err)
+	require.NoError(t, local.RemoveFrame(frames[1].ID))
+	web, err := local.AddProject(model.Project{Name: "web", ParentID: acme.ID})
+	require.NoError(t, err)
+	tag, err := local.AddTag(model.Tag{Name: "review"})
+	require.NoError(t, err)
+
+	// the merge is a new command
+	options.Command = "tom store merge"
+	local, err = store.Open(options)
+	require.NoError(t, err)
+
+	baseInfo, err := store.FindMergeBase(local, other, other.BackupDirPath())
+	require.NoError(t, err)
+	assert.EqualValues(t, "sync", baseInfo.Label)
+	base, err := store.OpenBackup(local, baseInfo.Dir)
+	require.NoError(t, err)
+
+	// without resolver the conflicts are reported
+	result, err := store.Merge(local, other, base, nil)
+	require.NoError(t, err)
+	require.Len(t, result.Conflicts, 2)
+	assert.EqualValues(t, "project acme-local: changed locally, changed in the other directory", result.Conflicts[0].String())
+	assert.EqualValues(t, "frame "+frames[1].ID+" of project acme-local: removed locally, changed in the other directory", result.Conflicts[1].String())
+	assert.Nil(t, result.Conflicts[1].Local)
+	assert.Error(t, store.ApplyMerge(local, result))
+
+	result, err = store.Merge(local, other, base, func(conflict store.MergeConflict) (string, error) {
+		return store.ResolveOther, nil
+	})
+	require.NoError(t, err)
+	assert.Empty(t, result.Conflicts)
+	require.NoError(t, store.ApplyMerge(local, result))
+
+	assert.Len(t, local.Projects(), 2, "the projects added on both sides must be merged")
+	acme, err = local.ProjectByID(acme.ID)
+	require.NoError(t, err)
+	assert.EqualValues(t, "acme-other", acme.Name)
+	assert.Len(t, local.Tags(), 1, "the tags added on both sides must be merged")
+
+	assert.Len(t, local.Frames(), 5)
+	assert.EqualValues(t, "local", findFrame(t, local, frames[0].ID).Notes, "the more recent change must be kept")
+	assert.EqualValues(t, "other", findFrame(t, local, frames[1].ID).Notes, "the conflict must be resolved with the other frame")
+	assert.EqualValues(t, "other", findFrame(t, local, frames[4].ID).Notes)
+	_, err = local.FindFirstFrame(func(f *model.Frame) bool {
+		return f.ID == frames[3].ID
+	})
+	assert.Error(t, err, "the frame removed by the other side must be removed")
+	merged := findFrame(t, local, otherFrame.ID)
+	assert.EqualValues(t, web.ID, merged.ProjectId)
+	assert.EqualValues(t, []string{tag.ID}, merged.TagIDs)
+
+	// the frames of the other side reference the merged project and tag
+	result, err = store.Merge(local, other, base, nil)
+	require.NoError(t, err)
+	assert.Empty(t, result.Conflicts)
+	assert.Empty(t, result.Changes, "a merged directory must be up to date")
+
+	// the merge can be reverted
+	_, changes, err := store.Undo(local)
+	require.NoError(t, err)
+	assert.NotEmpty(t, changes)
+	assert.Len(t, local.Frames(), 4)
+}
+
+// a synced backup directory contains the backups of both directories, including the backups created after the data diverged
+func Test_MergeBaseSyncedBackups(t *testing.T) {
+	localDir, err := ioutil.TempDir("", "tom")
+	require.NoError(t, err)
+	defer os.RemoveAll(localDir)
+	otherDir, err := ioutil.TempDir("", "tom")
+	require.NoError(t, err)
+	defer os.RemoveAll(otherDir)
+
+	options := store.Options{Dir: localDir, BackupDir: filepath.Join(localDir, "backup"), MaxBackups: 20, Command: "tom project create"}
+	local, err := store.Open(options)
+	require.NoError(t, err)
+	acme, err := local.AddProject(model.Project{Name: "acme"})
+	require.NoError(t, err)
+	var frames []*model.Frame
+	for i := 0; i < 2; i++ {
+		start := time.Date(2019, 1, i+1, 10, 0, 0, 0, time.UTC)
+		end := start.Add(time.Hour)
+		frame, err := local.AddFrame(model.Frame{ProjectId: acme.ID, Start: &start, End: &end})
+		require.NoError(t, err)
+		frames = append(frames, frame)
+	}
+
+	// the data diverges after the copy
+	copyDir(t, localDir, otherDir)
+
+	// two local commands, the backup of the second one contains the frame added by the first one
+	addFrame := func(day int) *model.Frame {
+		options.Command = "tom frame add"
+		local, err = store.Open(options)
+		require.NoError(t, err)
+		start := time.Date(2019, 2, day, 10, 0, 0, 0, time.UTC)
+		end := start.Add(time.Hour)
+		frame, err := local.AddFrame(model.Frame{ProjectId: acme.ID, Start: &start, End: &end})
+		require.NoError(t, err)
+		return frame
+	}
+	localFrame := addFrame(1)
+	addFrame(2)
+	backups, err := store.ListBackups(local)
+	require.NoError(t, err)
+	newest := backups[len(backups)-1]
+
+	other, err := store.Open(store.Options{Dir: otherDir, BackupDir: filepath.Join(otherDir, "backup"), MaxBackups: 20})
+	require.NoError(t, err)
+	require.NoError(t, other.RemoveFrame(frames[0].ID))
+
+	// the local backups are synced into the other backup directory
+	copyDir(t, local.BackupDirPath(), other.BackupDirPath())
+
+	options.Command = "tom store merge"
+	local, err = store.Open(options)
+	require.NoError(t, err)
+
+	baseInfo, err := store.FindMergeBase(local, other, other.BackupDirPath())
+	require.NoError(t, err)
+	assert.NotEqual(t, newest.Name(), baseInfo.Name(), "a backup created after the data diverged must not be the merge base")
+	base, err := store.OpenBackup(local, baseInfo.Dir)
+	require.NoError(t, err)
+	defer store.CloseStore(base)
+	assert.Len(t, base.Frames(), 2)
+
+	result, err := store.Merge(local, other, base, nil)
+	require.NoError(t, err)
+	assert.Empty(t, result.Conflicts)
+	require.NoError(t, store.ApplyMerge(local, result))
+	findFrame(t, local, localFrame.ID)
+	_, err = local.FrameByID(frames[0].ID)
+	assert.Error(t, err, "the frame removed by the other side must be removed")
+
+	// the changes of the other side are unclear without its backups, e.g. if they weren't synced yet
+	require.NoError(t, os.RemoveAll(other.BackupDirPath()))
+	copyDir(t, local.BackupDirPath(), other.BackupDirPath())
+	_, err = store.FindMergeBase(local, other, other.BackupDirPath())
+	assert.Equal(t, store.ErrNoMergeBase, err)
+}
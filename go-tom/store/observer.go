@@ -0,0 +1,212 @@
+package store
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/jansorg/tom/go-tom/model"
+)
+
+// observers manages the observers of a store.
+// Events are queued while the data is modified and passed to the observers after the changes were persisted.
+//
+// The values returned by a store are modified in place by its callers before they're updated.
+// Therefore copies of the stored values are kept to provide the old values of the events.
+// Frames are only tracked if frames isn't nil.
+type observers struct {
+	mu        sync.Mutex
+	nextID    int
+	observers map[int]model.Observer
+	queued    []model.Event
+
+	projects map[string]model.Project
+	tags     map[string]model.Tag
+	clients  map[string]model.Client
+	frames   map[string]model.Frame
+}
+
+func (o *observers) subscribe(observer model.Observer) func() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.observers == nil {
+		o.observers = make(map[int]model.Observer)
+	}
+	id := o.nextID
+	o.nextID++
+	o.observers[id] = observer
+
+	return func() {
+		o.mu.Lock()
+		defer o.mu.Unlock()
+		delete(o.observers, id)
+	}
+}
+
+// active returns if at least one observer was added.
+// It's used to avoid expensive reads of old values if nobody is interested in them.
+func (o *observers) active() bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return len(o.observers) > 0
+}
+
+// setValues replaces the copies of the stored values, frames aren't tracked if trackFrames is false
+func (o *observers) setValues(projects []*model.Project, tags []*model.Tag, clients []*model.Client, frames []*model.Frame, trackFrames bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.projects = make(map[string]model.Project, len(projects))
+	for _, p := range projects {
+		o.projects[p.ID] = *p
+	}
+	o.tags = make(map[string]model.Tag, len(tags))
+	for _, t := range tags {
+		o.tags[t.ID] = *t
+	}
+	o.clients = make(map[string]model.Client, len(clients))
+	for _, c := range clients {
+		o.clients[c.ID] = *c
+	}
+
+	o.frames = nil
+	if trackFrames {
+		o.frames = make(map[string]model.Frame, len(frames))
+		for _, f := range frames {
+			o.frames[f.ID] = *f
+		}
+	}
+}
+
+// addFrameValues adds copies of frames, which were read after the other values were set
+func (o *observers) addFrameValues(frames []*model.Frame) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.frames != nil {
+		for _, f := range frames {
+			o.frames[f.ID] = *f
+		}
+	}
+}
+
+// frameValue returns the copy of the stored frame, it returns the given value if frames aren't tracked
+func (o *observers) frameValue(f *model.Frame) *model.Frame {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if stored, ok := o.frames[f.ID]; ok {
+		return &stored
+	}
+	return f
+}
+
+// projectChanged queues the event of a project, value is nil if the project was removed
+func (o *observers) projectChanged(eventType model.EventType, id string, value *model.Project) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	var old *model.Project
+	if stored, ok := o.projects[id]; ok {
+		old = &stored
+	}
+	if value != nil {
+		o.projects[id] = *value
+	} else {
+		delete(o.projects, id)
+	}
+	o.queueLocked(model.NewProjectEvent(eventType, old, value))
+}
+
+// tagChanged queues the event of a tag, value is nil if the tag was removed
+func (o *observers) tagChanged(eventType model.EventType, id string, value *model.Tag) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	var old *model.Tag
+	if stored, ok := o.tags[id]; ok {
+		old = &stored
+	}
+	if value != nil {
+		o.tags[id] = *value
+	} else {
+		delete(o.tags, id)
+	}
+	o.queueLocked(model.NewTagEvent(eventType, old, value))
+}
+
+// clientChanged queues the event of a client, value is nil if the client was removed
+func (o *observers) clientChanged(eventType model.EventType, id string, value *model.Client) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	var old *model.Client
+	if stored, ok := o.clients[id]; ok {
+		old = &stored
+	}
+	if value != nil {
+		o.clients[id] = *value
+	} else {
+		delete(o.clients, id)
+	}
+	o.queueLocked(model.NewClientEvent(eventType, old, value))
+}
+
+// frameChanged queues the event of a frame, value is nil if the frame was removed.
+// old is used if frames aren't tracked.
+func (o *observers) frameChanged(eventType model.EventType, id string, old, value *model.Frame) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.frames != nil {
+		old = nil
+		if stored, ok := o.frames[id]; ok {
+			old = &stored
+		}
+		if value != nil {
+			o.frames[id] = *value
+		} else {
+			delete(o.frames, id)
+		}
+	}
+	o.queueLocked(model.NewFrameEvent(eventType, old, value))
+}
+
+func (o *observers) queueLocked(event model.Event) {
+	if len(o.observers) > 0 {
+		o.queued = append(o.queued, event)
+	}
+}
+
+// discard removes the queued events of changes, which weren't persisted
+func (o *observers) discard() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.queued = nil
+}
+
+// flush passes the queued events to the observers.
+// The observers are called without holding a lock, they may use the store.
+func (o *observers) flush() {
+	o.mu.Lock()
+	events := o.queued
+	o.queued = nil
+	var targets []model.Observer
+	if len(events) > 0 {
+		ids := make([]int, 0, len(o.observers))
+		for id := range o.observers {
+			ids = append(ids, id)
+		}
+		sort.Ints(ids)
+		for _, id := range ids {
+			targets = append(targets, o.observers[id])
+		}
+	}
+	o.mu.Unlock()
+
+	for _, e := range events {
+		for _, observer := range targets {
+			observer(e)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package store_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jansorg/tom/go-tom/model"
+	"github.com/jansorg/tom/go-tom/store"
+)
+
+func Test_Observers(t *testing.T) {
+	for _, options := range []store.Options{{Format: store.FormatJSON}, {Format: store.FormatJSON, Journal: true}, {Format: store.FormatSQLite}} {
+		t.Run(fmt.Sprintf("%s journal=%v", options.Format, options.Journal), func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "tom")
+			require.NoError(t, err)
+			defer os.RemoveAll(dir)
+
+			options.Dir = dir
+			s, err := store.Open(options)
+			require.NoError(t, err)
+			defer store.CloseStore(s)
+
+			var events []model.Event
+			unsubscribe := s.Subscribe(func(e model.Event) {
+				events = append(events, e)
+			})
+
+			project, err := s.AddProject(model.Project{Name: "project"})
+			require.NoError(t, err)
+			require.Len(t, events, 1)
+			assert.EqualValues(t, model.EventAdded, events[0].Type)
+			assert.EqualValues(t, model.EntityProject, events[0].Entity)
+			assert.Nil(t, events[0].Old)
+			assert.EqualValues(t, "project", events[0].New.(*model.Project).Name)
+
+			tag, err := s.AddTag(model.Tag{Name: "tag"})
+			require.NoError(t, err)
+			tag.Name = "renamed"
+			_, err = s.UpdateTag(*tag)
+			require.NoError(t, err)
+			require.Len(t, events, 3)
+			assert.EqualValues(t, model.EventUpdated, events[2].Type)
+			assert.EqualValues(t, "tag", events[2].Old.(*model.Tag).Name)
+			assert.EqualValues(t, "renamed", events[2].New.(*model.Tag).Name)
+
+			start := time.Now().Add(-time.Hour)
+			frame, err := s.AddFrame(model.Frame{ProjectId: project.ID, Start: &start})
+			require.NoError(t, err)
+			frame.StopAt(time.Now())
+			_, err = s.UpdateFrame(*frame)
+			require.NoError(t, err)
+			require.Len(t, events, 5)
+			assert.EqualValues(t, model.EventUpdated, events[4].Type)
+			assert.True(t, events[4].OldFrame().IsActive(), "the old value must be passed to observers")
+			assert.True(t, events[4].NewFrame().IsStopped())
+
+			require.NoError(t, s.RemoveFrame(frame.ID))
+			require.Len(t, events, 6)
+			assert.EqualValues(t, model.EventRemoved, events[5].Type)
+			assert.EqualValues(t, frame.ID, events[5].OldFrame().ID)
+			assert.Nil(t, events[5].New)
+
+			// events of a transaction are passed after the commit
+			events = nil
+			err = s.Update(func(tx model.Store) error {
+				for _, name := range []string{"a", "b"} {
+					if _, err := tx.AddProject(model.Project{Name: name}); err != nil {
+						return err
+					}
+				}
+				assert.Empty(t, events)
+				return nil
+			})
+			require.NoError(t, err)
+			assert.Len(t, events, 2)
+
+			// and they're discarded if it's rolled back
+			events = nil
+			err = s.Update(func(tx model.Store) error {
+				if _, err := tx.AddProject(model.Project{Name: "discarded"}); err != nil {
+					return err
+				}
+				return fmt.Errorf("failure")
+			})
+			require.Error(t, err)
+			assert.Empty(t, events)
+
+			unsubscribe()
+			_, err = s.AddProject(model.Project{Name: "unobserved"})
+			require.NoError(t, err)
+			assert.Empty(t, events)
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package store
+
+import (
+	"sort"
+	"time"
+
+	"github.com/jansorg/tom/go-tom/model"
+)
+
+// queryIndex contains the indexes of the frames in memory, which are used by the frame queries of DataStore.
+// Callers modify the stored frames in place before they update them. Therefore the indexed values of each frame are kept
+// to remove the frame from the indexes when it's updated or removed.
+type queryIndex struct {
+	values    map[string]indexedFrame
+	byProject map[string]map[string]*model.Frame
+	byTag     map[string]map[string]*model.Frame
+	active    map[string]*model.Frame
+
+	// stopped contains the stopped frames sorted by start.
+	// maxDuration is at least the duration of the longest stopped frame, it limits the frames which have to be checked by inRange.
+	stopped     []indexedFrame
+	maxDuration time.Duration
+}
+
+// indexedFrame contains the values of a frame, under which it was added to the index. end is nil for active frames.
+type indexedFrame struct {
+	frame     *model.Frame
+	projectID string
+	tagIDs    []string
+	start     time.Time
+	end       *time.Time
+}
+
+func newIndexedFrame(frame *model.Frame) indexedFrame {
+	v := indexedFrame{
+		frame:     frame,
+		projectID: frame.ProjectId,
+		tagIDs:    append([]string(nil), frame.TagIDs...),
+	}
+	if frame.Start != nil {
+		v.start = *frame.Start
+	}
+	if frame.End != nil {
+		end := *frame.End
+		v.end = &end
+	}
+	return v
+}
+
+func newQueryIndex(frames []*model.Frame) *queryIndex {
+	index := &queryIndex{}
+	index.add(frames...)
+	return index
+}
+
+// add adds the frames to the index. A single frame is inserted into the sorted frames, more frames are sorted at once.
+func (q *queryIndex) add(frames ...*model.Frame) {
+	if q.values == nil {
+		q.values = make(map[string]indexedFrame, len(frames))
+		q.byProject = map[string]map[string]*model.Frame{}
+		q.byTag = map[string]map[string]*model.Frame{}
+		q.active = map[string]*model.Frame{}
+	}
+
+	sorted := len(frames) == 1
+	for _, frame := range frames {
+		v := newIndexedFrame(frame)
+		q.values[frame.ID] = v
+		addToSet(q.byProject, v.projectID, frame)
+		for _, id := range v.tagIDs {
+			addToSet(q.byTag, id, frame)
+		}
+
+		if v.end == nil {
+			q.active[frame.ID] = frame
+			continue
+		}
+
+		if duration := v.end.Sub(v.start); duration > q.maxDuration {
+			q.maxDuration = duration
+		}
+		if sorted {
+			i := sort.Search(len(q.stopped), func(i int) bool {
+				return v.start.Before(q.stopped[i].start)
+			})
+			q.stopped = append(q.stopped, indexedFrame{})
+			copy(q.stopped[i+1:], q.stopped[i:])
+			q.stopped[i] = v
+		} else {
+			q.stopped = append(q.stopped, v)
+		}
+	}
+
+	if !sorted {
+		sort.SliceStable(q.stopped, func(i, j int) bool {
+			return q.stopped[i].start.Before(q.stopped[j].start)
+		})
+	}
+}
+
+// remove removes the frame from the index, the values of the frame may have been modified since it was added
+func (q *queryIndex) remove(id string) {
+	v, ok := q.values[id]
+	if !ok {
+		return
+	}
+
+	delete(q.values, id)
+	removeFromSet(q.byProject, v.projectID, id)
+	for _, tagID := range v.tagIDs {
+		removeFromSet(q.byTag, tagID, id)
+	}
+
+	if v.end == nil {
+		delete(q.active, id)
+		return
+	}
+
+	for i := sort.Search(len(q.stopped), func(i int) bool {
+		return !q.stopped[i].start.Before(v.start)
+	}); i < len(q.stopped); i++ {
+		if q.stopped[i].frame.ID == id {
+			q.stopped = append(q.stopped[:i], q.stopped[i+1:]...)
+			break
+		}
+	}
+}
+
+// update indexes the current values of the frame
+func (q *queryIndex) update(frame *model.Frame) {
+	q.remove(frame.ID)
+	q.add(frame)
+}
+
+func (q *queryIndex) byProjects(ids ...string) model.FrameList {
+	result := model.FrameList{}
+	for _, id := range ids {
+		for _, frame := range q.byProject[id] {
+			result = append(result, frame)
+		}
+	}
+	return sortedByStart(result)
+}
+
+// byTags returns the frames, which have at least one of the tags
+func (q *queryIndex) byTags(ids ...string) model.FrameList {
+	set := map[string]*model.Frame{}
+	for _, id := range ids {
+		for frameID, frame := range q.byTag[id] {
+			set[frameID] = frame
+		}
+	}
+
+	result := model.FrameList{}
+	for _, frame := range set {
+		result = append(result, frame)
+	}
+	return sortedByStart(result)
+}
+
+func (q *queryIndex) activeFrames() model.FrameList {
+	result := model.FrameList{}
+	for _, frame := range q.active {
+		result = append(result, frame)
+	}
+	return sortedByStart(result)
+}
+
+// inRange returns the frames intersecting the range, active frames have no end. A nil value of start or end is unbounded.
+func (q *queryIndex) inRange(start, end *time.Time) model.FrameList {
+	first := 0
+	if start != nil {
+		earliest := start.Add(-q.maxDuration)
+		first = sort.Search(len(q.stopped), func(i int) bool {
+			return !q.stopped[i].start.Before(earliest)
+		})
+	}
+
+	result := model.FrameList{}
+	for _, v := range q.stopped[first:] {
+		if end != nil && v.start.After(*end) {
+			break
+		}
+		if start == nil || !v.end.Before(*start) {
+			result = append(result, v.frame)
+		}
+	}
+	for id, frame := range q.active {
+		if end == nil || !q.values[id].start.After(*end) {
+			result = append(result, frame)
+		}
+	}
+	return sortedByStart(result)
+}
+
+func addToSet(sets map[string]map[string]*model.Frame, key string, frame *model.Frame) {
+	set, ok := sets[key]
+	if !ok {
+		set = map[string]*model.Frame{}
+		sets[key] = set
+	}
+	set[frame.ID] = frame
+}
+
+func removeFromSet(sets map[string]map[string]*model.Frame, key string, id string) {
+	if set, ok := sets[key]; ok {
+		delete(set, id)
+		if len(set) == 0 {
+			delete(sets, key)
+		}
+	}
+}
+
+// sortedByStart sorts the frames by start. Frames with the same start are sorted by ID to return them in a stable order.
+func sortedByStart(frames model.FrameList) model.FrameList {
+	sort.Slice(frames, func(i, j int) bool {
+		if frames[i].IsBefore(frames[j]) {
+			return true
+		}
+		return !frames[j].IsBefore(frames[i]) && frames[i].ID < frames[j].ID
+	})
+	return frames
+}
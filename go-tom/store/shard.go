@@ -0,0 +1,342 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jansorg/tom/go-tom/model"
+)
+
+// The frames are stored in one file per year of their start time, e.g. frames-2019.json.
+// The years with active frames and the current year are read when the store is opened,
+// the other years are only read when frames of them are requested.
+// frames-index.json describes the files to decide which years have to be read without reading them.
+
+var shardFilePattern = regexp.MustCompile(`^frames-(\d+)\.json$`)
+
+// shardInfo describes the frames of a single year
+type shardInfo struct {
+	Frames int `json:"frames"`
+	Active int `json:"active"`
+	// First is the earliest start time of the frames
+	First *time.Time `json:"first,omitempty"`
+	// Last is the latest end time of the stopped frames
+	Last *time.Time `json:"last,omitempty"`
+	// Size and Modified are used to detect files, which were written without updating the index
+	Size     int64     `json:"size"`
+	Modified time.Time `json:"modified"`
+}
+
+// frameIndex maps the year to the information about its frames
+type frameIndex map[int]*shardInfo
+
+// frameYear returns the year of the file, which stores the frame
+func frameYear(frame *model.Frame) int {
+	if frame.Start == nil {
+		return 0
+	}
+	return frame.Start.UTC().Year()
+}
+
+// frameInRange returns if the frame intersects the range, nil values of start and end are unbounded
+func frameInRange(frame *model.Frame, start, end *time.Time) bool {
+	if end != nil && frame.Start != nil && frame.Start.After(*end) {
+		return false
+	}
+	return start == nil || frame.End == nil || !frame.End.Before(*start)
+}
+
+func (d *DataStore) shardFile(year int) string {
+	return filepath.Join(d.path, fmt.Sprintf("frames-%d.json", year))
+}
+
+// shardYears returns the years, which are stored in a file
+func (d *DataStore) shardYears() []int {
+	files, err := ioutil.ReadDir(d.path)
+	if err != nil {
+		return nil
+	}
+
+	var years []int
+	for _, f := range files {
+		if match := shardFilePattern.FindStringSubmatch(f.Name()); match != nil {
+			if year, err := strconv.Atoi(match[1]); err == nil {
+				years = append(years, year)
+			}
+		}
+	}
+	sort.Ints(years)
+	return years
+}
+
+// shardFiles returns the paths of the files of all years
+func (d *DataStore) shardFiles() []string {
+	var files []string
+	for _, year := range d.shardYears() {
+		files = append(files, d.shardFile(year))
+	}
+	return files
+}
+
+// readFrameIndexLocked returns the index of the frame files or nil if it doesn't exist or is invalid.
+// Without index all years are read.
+func (d *DataStore) readFrameIndexLocked() frameIndex {
+	data, err := d.cipher.readFile(d.FrameIndexFile)
+	if err != nil {
+		return nil
+	}
+
+	var index frameIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil
+	}
+	return index
+}
+
+// indexedLocked returns if the index has valid information about the file of the year
+func (d *DataStore) indexedLocked(year int) bool {
+	info, ok := d.frameIndex[year]
+	if !ok {
+		return false
+	}
+
+	stat, err := os.Stat(d.shardFile(year))
+	if err != nil {
+		return false
+	}
+	return stat.Size() == info.Size && stat.ModTime().Equal(info.Modified)
+}
+
+// openYearsLocked returns the years, which are read when the store is opened.
+// These are the current year, the years with active frames and the years without valid information in the index.
+func (d *DataStore) openYearsLocked() []int {
+	years := []int{time.Now().UTC().Year()}
+	for _, year := range d.shardYears() {
+		if !d.indexedLocked(year) || d.frameIndex[year].Active > 0 {
+			years = append(years, year)
+		}
+	}
+	return years
+}
+
+// yearsInRangeLocked returns the years, which may contain frames intersecting the range. A nil value is unbounded.
+func (d *DataStore) yearsInRangeLocked(start, end *time.Time) []int {
+	var years []int
+	for _, year := range d.shardYears() {
+		if !d.indexedLocked(year) {
+			years = append(years, year)
+			continue
+		}
+
+		info := d.frameIndex[year]
+		startsBeforeEnd := end == nil || info.First == nil || !info.First.After(*end)
+		endsAfterStart := start == nil || info.Active > 0 || info.Last == nil || !info.Last.Before(*start)
+		if startsBeforeEnd && endsAfterStart {
+			years = append(years, year)
+		}
+	}
+	return years
+}
+
+// readYearsLocked reads the frames of the years, which weren't read yet.
+// The caller must hold a lock of the data directory.
+func (d *DataStore) readYearsLocked(years ...int) error {
+	var added []*model.Frame
+	for _, year := range years {
+		if d.loadedYears[year] {
+			continue
+		}
+
+		path := d.shardFile(year)
+		if fileExists(path) {
+			data, err := d.cipher.readFile(path)
+			if err != nil {
+				return err
+			}
+
+			var frames []*model.Frame
+			if err := json.Unmarshal(data, &frames); err != nil {
+				return fmt.Errorf("invalid frame file %s: %s", path, err.Error())
+			}
+			for _, f := range frames {
+				d.frameYears[f.ID] = year
+			}
+			added = append(added, frames...)
+		}
+		d.loadedYears[year] = true
+	}
+
+	if len(added) > 0 {
+		d.frames = append(d.frames, added...)
+		d.sortFrames()
+		d.index.add(added...)
+		for _, f := range added {
+			d.frameVersions[f.ID] = f.Updated
+		}
+		d.events.addFrameValues(added)
+	}
+	return nil
+}
+
+// loadYearsLocked makes sure that the frames of the years are in memory.
+// If another process modified the data in the meantime, then all data is read again.
+func (d *DataStore) loadYearsLocked(years ...int) error {
+	if d.yearsLoadedLocked(years) {
+		return nil
+	}
+
+	unlock, err := d.lock.lockShared()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if !d.changedOnDiskLocked() {
+		return d.readYearsLocked(years...)
+	}
+
+	// the years are read with the other years, which were already in memory
+	for _, year := range years {
+		d.loadedYears[year] = true
+	}
+	return d.refreshLocked(d.pendingEntries)
+}
+
+func (d *DataStore) loadAllYearsLocked() error {
+	return d.loadYearsLocked(d.shardYears()...)
+}
+
+func (d *DataStore) yearsLoadedLocked(years []int) bool {
+	for _, year := range years {
+		if !d.loadedYears[year] {
+			return false
+		}
+	}
+	return true
+}
+
+// loadYears makes sure that the frames of the years returned by years are in memory.
+// The exclusive lock of the store is only held while years are read, the frames are queried with the shared lock afterwards.
+func (d *DataStore) loadYears(years func() []int) error {
+	d.mu.RLock()
+	loaded := d.yearsLoadedLocked(years())
+	d.mu.RUnlock()
+	if loaded {
+		return nil
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.loadYearsLocked(years()...)
+}
+
+// tryLoadYears is used by the methods, which aren't able to return an error.
+// Years, which can't be read, are logged and skipped. Their frames are missing until they were read successfully.
+func (d *DataStore) tryLoadYears(years func() []int) {
+	if err := d.loadYears(years); err == nil {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, year := range years() {
+		if err := d.loadYearsLocked(year); err != nil {
+			log.Printf("skipping frames of %d: %s", year, err.Error())
+		}
+	}
+}
+
+// resetYearsLocked marks all years as read and modified, it's used after all frames were replaced
+func (d *DataStore) resetYearsLocked() {
+	for _, year := range d.shardYears() {
+		d.loadedYears[year] = true
+		d.dirtyYears[year] = true
+	}
+
+	d.frameYears = map[string]int{}
+	for _, f := range d.frames {
+		year := frameYear(f)
+		d.frameYears[f.ID] = year
+		d.loadedYears[year] = true
+		d.dirtyYears[year] = true
+	}
+}
+
+// writeShardsLocked writes the files of the modified years and then the index
+func (d *DataStore) writeShardsLocked() error {
+	byYear := map[int][]*model.Frame{}
+	for _, f := range d.frames {
+		year := frameYear(f)
+		byYear[year] = append(byYear[year], f)
+	}
+
+	if d.frameIndex == nil {
+		d.frameIndex = frameIndex{}
+	}
+
+	for year := range d.dirtyYears {
+		path := d.shardFile(year)
+		frames := byYear[year]
+		if len(frames) == 0 {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			continue
+		}
+
+		data, err := json.Marshal(frames)
+		if err != nil {
+			return err
+		}
+		if err := d.cipher.writeFile(path, data); err != nil {
+			return err
+		}
+	}
+
+	// the index is updated for all years in memory, the information about the other years is kept
+	for year := range d.loadedYears {
+		frames := byYear[year]
+		stat, err := os.Stat(d.shardFile(year))
+		if len(frames) == 0 || err != nil {
+			delete(d.frameIndex, year)
+			continue
+		}
+
+		info := &shardInfo{Frames: len(frames), Size: stat.Size(), Modified: stat.ModTime()}
+		for _, f := range frames {
+			if f.Start != nil && (info.First == nil || f.Start.Before(*info.First)) {
+				info.First = f.Start
+			}
+			if f.IsActive() {
+				info.Active++
+			} else if f.End != nil && (info.Last == nil || f.End.After(*info.Last)) {
+				info.Last = f.End
+			}
+		}
+		d.frameIndex[year] = info
+	}
+
+	data, err := json.Marshal(d.frameIndex)
+	if err != nil {
+		return err
+	}
+	if err := d.cipher.writeFile(d.FrameIndexFile, data); err != nil {
+		return err
+	}
+
+	// all frames of the file of data format version 1 are now stored in the files of the years
+	if err := os.Remove(d.FrameFile); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	d.dirtyYears = map[int]bool{}
+	return nil
+}
@@ -0,0 +1,189 @@
+package store
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jansorg/tom/go-tom/model"
+)
+
+func addTestFrame(t *testing.T, s *DataStore, projectID string, start time.Time, duration time.Duration) *model.Frame {
+	frame := model.Frame{ProjectId: projectID, Start: &start}
+	if duration > 0 {
+		end := start.Add(duration)
+		frame.End = &end
+	}
+
+	added, err := s.AddFrame(frame)
+	require.NoError(t, err)
+	return added
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 10, 0, 0, 0, time.UTC)
+}
+
+func Test_FrameShards(t *testing.T) {
+	for _, journal := range []bool{false, true} {
+		t.Run(fmt.Sprintf("journal %v", journal), func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "tom")
+			require.NoError(t, err)
+			defer os.RemoveAll(dir)
+
+			options := Options{Dir: dir, Journal: journal, JournalCompactSize: 100}
+			s, err := newDataStore(options)
+			require.NoError(t, err)
+
+			p, err := s.AddProject(model.Project{Name: "project"})
+			require.NoError(t, err)
+
+			now := time.Now().UTC()
+			old := addTestFrame(t, s, p.ID, date(2017, 3, 1), time.Hour)
+			addTestFrame(t, s, p.ID, date(2018, 12, 31), 48*time.Hour)
+			active := addTestFrame(t, s, p.ID, date(2016, 6, 1), 0)
+			addTestFrame(t, s, p.ID, now.Add(-time.Hour), time.Minute)
+			require.NoError(t, s.Compact())
+
+			for _, year := range []int{2016, 2017, 2018, now.Year()} {
+				assert.FileExists(t, s.shardFile(year))
+			}
+			assert.NoFileExists(t, s.FrameFile)
+
+			// the closed years are read when they're needed
+			s, err = newDataStore(options)
+			require.NoError(t, err)
+			assert.EqualValues(t, map[int]bool{2016: true, now.Year(): true}, s.loadedYears)
+			require.Len(t, s.ActiveFrames(), 1)
+			assert.EqualValues(t, active.ID, s.ActiveFrames()[0].ID)
+
+			addTestFrame(t, s, p.ID, now, time.Minute)
+			assert.False(t, s.loadedYears[2017], "adding a frame must not read the closed years")
+
+			// the frame of 2018 ends in 2019
+			start := date(2019, 1, 1)
+			end := date(2019, 12, 31)
+			frames := s.FramesInRange(&start, &end)
+			assert.Len(t, frames, 2, "the frame of 2018 and the active frame intersect the range")
+			assert.True(t, s.loadedYears[2018])
+			assert.False(t, s.loadedYears[2017])
+
+			// updating a frame moves it into the file of the new year, the stored frame is modified in place like the commands do
+			old.Start = &start
+			old.End = &end
+			_, err = s.UpdateFrame(*old)
+			require.NoError(t, err)
+			assert.Len(t, s.FramesInRange(&start, &end), 3)
+
+			require.Len(t, s.Frames(), 5)
+			assert.True(t, s.loadedYears[2017])
+
+			// the data of another process
+			reopened, err := newDataStore(options)
+			require.NoError(t, err)
+			assert.Len(t, reopened.FramesInRange(&start, &end), 3)
+			frame, err := reopened.FindFirstFrame(func(f *model.Frame) bool {
+				return f.ID == old.ID
+			})
+			require.NoError(t, err)
+			assert.EqualValues(t, 2019, frame.Start.Year())
+			assert.Len(t, reopened.Frames(), 5, "the updated frame must only be stored in the file of the new year")
+			require.NoError(t, reopened.RemoveFrame(old.ID))
+			require.NoError(t, reopened.Compact())
+			assert.NoFileExists(t, s.shardFile(2017), "the file of a year without frames must be removed")
+			assert.Len(t, reopened.Frames(), 4)
+		})
+	}
+}
+
+func Test_FrameShardsMigration(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tom")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	frames := `[{"id":"f1","project":"p1","start":"2018-01-01T10:00:00Z","end":"2018-01-01T12:00:00Z"},` +
+		`{"id":"f2","project":"p1","start":"2019-01-01T10:00:00Z","end":"2019-01-01T12:00:00Z"}]`
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "version.json"), []byte(`{"version":1}`), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "projects.json"), []byte(`[{"id":"p1","name":"project"}]`), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "frames.json"), []byte(frames), 0600))
+
+	s, err := newDataStore(Options{Dir: dir})
+	require.NoError(t, err)
+	assert.EqualValues(t, FormatVersion, s.version)
+	assert.NoFileExists(t, s.FrameFile)
+	assert.FileExists(t, s.shardFile(2018))
+	assert.FileExists(t, s.shardFile(2019))
+
+	s, err = newDataStore(Options{Dir: dir})
+	require.NoError(t, err)
+	assert.Len(t, s.Frames(), 2)
+}
+
+func Test_FrameShardsNestedQueries(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tom")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	s, err := newDataStore(Options{Dir: dir})
+	require.NoError(t, err)
+	p, err := s.AddProject(model.Project{Name: "project"})
+	require.NoError(t, err)
+	frame := addTestFrame(t, s, p.ID, date(2017, 3, 1), time.Hour)
+	require.NoError(t, s.Compact())
+
+	// the filters may query the store while the frames are iterated
+	s, err = newDataStore(Options{Dir: dir})
+	require.NoError(t, err)
+	found, err := s.FindFirstFrame(func(f *model.Frame) bool {
+		project, err := s.ProjectByID(f.ProjectId)
+		return err == nil && project.Name == "project" && len(s.FramesByProject(project.ID)) == 1
+	})
+	require.NoError(t, err)
+	assert.EqualValues(t, frame.ID, found.ID)
+
+	frames, err := s.FindFrames(func(f *model.Frame) (bool, error) {
+		return len(s.FramesInRange(f.Start, f.End)) == 1, nil
+	})
+	require.NoError(t, err)
+	assert.Len(t, frames, 1)
+}
+
+func Test_FrameShardsInvalidYear(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tom")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	s, err := newDataStore(Options{Dir: dir})
+	require.NoError(t, err)
+	p, err := s.AddProject(model.Project{Name: "project"})
+	require.NoError(t, err)
+	addTestFrame(t, s, p.ID, date(2017, 3, 1), time.Hour)
+	valid := addTestFrame(t, s, p.ID, date(2018, 3, 1), time.Hour)
+	require.NoError(t, s.Compact())
+
+	// the file is damaged without changing its size and modification time, the index is still considered valid
+	path := s.shardFile(2017)
+	stat, err := os.Stat(path)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(path, bytes.Repeat([]byte("x"), int(stat.Size())), 0600))
+	require.NoError(t, os.Chtimes(path, stat.ModTime(), stat.ModTime()))
+
+	// the invalid year is skipped by the methods without error result
+	s, err = newDataStore(Options{Dir: dir})
+	require.NoError(t, err)
+	frames := s.Frames()
+	require.Len(t, frames, 1)
+	assert.EqualValues(t, valid.ID, frames[0].ID)
+
+	_, err = s.FindFrames(func(f *model.Frame) (bool, error) {
+		return true, nil
+	})
+	assert.Error(t, err)
+}
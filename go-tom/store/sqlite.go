@@ -0,0 +1,1330 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/jansorg/tom/go-tom/model"
+	"github.com/jansorg/tom/go-tom/util"
+)
+
+const sqliteFileName = "tom.db"
+
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS projects (
+	id        TEXT PRIMARY KEY,
+	parent_id TEXT NOT NULL DEFAULT '',
+	data      TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS tags (
+	id   TEXT PRIMARY KEY,
+	data TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS clients (
+	id   TEXT PRIMARY KEY,
+	data TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS frames (
+	id         TEXT PRIMARY KEY,
+	project_id TEXT NOT NULL,
+	start_time INTEGER NOT NULL,
+	end_time   INTEGER,
+	data       TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS frames_project ON frames(project_id);
+CREATE INDEX IF NOT EXISTS frames_start ON frames(start_time);
+CREATE INDEX IF NOT EXISTS frames_end ON frames(end_time);
+`
+
+// dbExecutor is implemented by *sql.DB and *sql.Tx
+type dbExecutor interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// NewSQLiteStore returns a store which keeps its data in a SQLite database file in dir.
+// Projects, tags and clients are cached in memory, frames are only loaded when they're requested.
+func NewSQLiteStore(dir string, backupDir string, maxBackups int) (model.Store, error) {
+	return newSQLiteStore(Options{Dir: dir, BackupDir: backupDir, MaxBackups: maxBackups})
+}
+
+func newSQLiteStore(options Options) (*SQLiteStore, error) {
+	dir := options.Dir
+	if _, err := os.Stat(dir); err != nil && os.IsNotExist(err) {
+		return nil, fmt.Errorf("directory %s does not exist", dir)
+	}
+
+	if fileExists(filepath.Join(dir, encryptionFileName)) {
+		return nil, fmt.Errorf("the data directory %s is encrypted, which is only supported by the json data format", dir)
+	}
+
+	// SQLite locks the database file itself, other processes wait until the lock is released or the timeout expired
+	dbFile := filepath.Join(dir, sqliteFileName)
+	db, err := sql.Open("sqlite", fmt.Sprintf("%s?_pragma=busy_timeout(%d)", dbFile, options.LockTimeout.Milliseconds()))
+	if err != nil {
+		return nil, err
+	}
+	// there's only a single connection to keep the transaction of Update() and the plain statements consistent
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	store := &SQLiteStore{
+		path:       dir,
+		backupPath: options.BackupDir,
+		maxBackups: options.MaxBackups,
+		command:    options.Command,
+		session:    model.NextID(),
+		DBFile:     dbFile,
+		db:         db,
+	}
+
+	if err := store.upgradeLocked(); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	if err := store.loadLocked(); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return store, nil
+}
+
+type SQLiteStore struct {
+	path       string
+	backupPath string
+	maxBackups int
+	command    string
+	session    string
+	// backedUp is true after the backup of the session was created
+	backedUp bool
+
+	DBFile string
+
+	mu    sync.RWMutex
+	db    *sql.DB
+	batch *sql.Tx
+	// stagedFiles are written into the data directory when the transaction is committed, a nil value removes the file
+	stagedFiles map[string][]byte
+	projectsMap map[string]*model.Project
+	projects    []*model.Project
+	tags        []*model.Tag
+	clients     []*model.Client
+
+	events observers
+
+	// maps frame ID to Frame.Updated of the frames, which were read from the database.
+	// It's used to detect updates of frames, which were modified by another process in the meantime.
+	frameVersions sync.Map
+}
+
+func (d *SQLiteStore) DirPath() string {
+	return d.path
+}
+
+func (d *SQLiteStore) BackupDirPath() string {
+	return d.backupPath
+}
+
+func (d *SQLiteStore) MaxBackups() int {
+	return d.maxBackups
+}
+
+// Update runs fn in a transaction of the database.
+// The changes are committed when fn returns nil. If fn returns an error, then the transaction is rolled back
+// and the cached projects and tags are read again.
+func (d *SQLiteStore) Update(fn func(tx model.Store) error) (err error) {
+	d.mu.Lock()
+	if d.batch != nil {
+		d.mu.Unlock()
+		return ErrNestedTransaction
+	}
+	defer d.notify()
+
+	backupDir, err := d.backupLocked()
+	if err != nil {
+		d.mu.Unlock()
+		return err
+	}
+	tx, err := d.db.Begin()
+	if err != nil {
+		d.mu.Unlock()
+		return err
+	}
+	d.batch = tx
+	d.mu.Unlock()
+
+	done := false
+	defer func() {
+		if done {
+			return
+		}
+
+		d.mu.Lock()
+		defer d.mu.Unlock()
+
+		d.batch = nil
+		d.stagedFiles = nil
+		_ = tx.Rollback()
+		d.events.discard()
+		d.resetFrameVersions()
+		// nothing was changed, the backup isn't needed
+		if backupDir != "" {
+			_ = os.RemoveAll(backupDir)
+			d.backedUp = false
+		}
+		if loadErr := d.loadLocked(); loadErr != nil && err == nil {
+			err = loadErr
+		}
+	}()
+
+	if err = fn(d); err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	done = true
+	d.batch = nil
+	if err = d.writeStagedFilesLocked(); err != nil {
+		_ = tx.Rollback()
+		d.events.discard()
+		d.resetFrameVersions()
+		_ = d.loadLocked()
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		d.events.discard()
+		d.resetFrameVersions()
+		_ = d.loadLocked()
+		return err
+	}
+	return nil
+}
+
+// Subscribe adds an observer of the changes of this store
+func (d *SQLiteStore) Subscribe(observer model.Observer) func() {
+	return d.events.subscribe(observer)
+}
+
+// notify passes the events of the persisted changes to the observers, unless a transaction is running.
+// It must be called without holding the lock of the store.
+func (d *SQLiteStore) notify() {
+	d.mu.RLock()
+	inTransaction := d.batch != nil
+	d.mu.RUnlock()
+
+	if !inTransaction {
+		d.events.flush()
+	}
+}
+
+// Close closes the underlying database
+func (d *SQLiteStore) Close() error {
+	return d.db.Close()
+}
+
+func (d *SQLiteStore) executor() dbExecutor {
+	if d.batch != nil {
+		return d.batch
+	}
+	return d.db
+}
+
+// beforeChangeLocked creates a backup of the database, unless it's running in a transaction.
+// The backup of a transaction is created when the transaction is started.
+func (d *SQLiteStore) beforeChangeLocked() error {
+	if d.batch == nil {
+		_, err := d.backupLocked()
+		return err
+	}
+	return nil
+}
+
+func (d *SQLiteStore) loadLocked() error {
+	d.projects = []*model.Project{}
+	d.tags = []*model.Tag{}
+	d.clients = []*model.Client{}
+
+	rows, err := d.executor().Query("SELECT data FROM projects")
+	if err != nil {
+		return err
+	}
+	err = scanJSONRows(rows, func(data []byte) error {
+		var p model.Project
+		if err := json.Unmarshal(data, &p); err != nil {
+			return err
+		}
+		d.projects = append(d.projects, &p)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	rows, err = d.executor().Query("SELECT data FROM tags")
+	if err != nil {
+		return err
+	}
+	err = scanJSONRows(rows, func(data []byte) error {
+		var t model.Tag
+		if err := json.Unmarshal(data, &t); err != nil {
+			return err
+		}
+		d.tags = append(d.tags, &t)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	rows, err = d.executor().Query("SELECT data FROM clients")
+	if err != nil {
+		return err
+	}
+	err = scanJSONRows(rows, func(data []byte) error {
+		var c model.Client
+		if err := json.Unmarshal(data, &c); err != nil {
+			return err
+		}
+		d.clients = append(d.clients, &c)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	d.updateProjectsMapping()
+	d.updateAllProjectInternals()
+	d.sortProjects()
+	d.sortTags()
+	d.sortClients()
+	// frames are read from the database, they're always up-to-date
+	d.events.setValues(d.projects, d.tags, d.clients, nil, false)
+	return nil
+}
+
+func (d *SQLiteStore) sortProjects() {
+	sort.SliceStable(d.projects, func(i, j int) bool {
+		return strings.Compare(d.projects[i].GetFullName("/"), d.projects[j].GetFullName("/")) < 0
+	})
+}
+
+func (d *SQLiteStore) sortTags() {
+	sort.SliceStable(d.tags, func(i, j int) bool {
+		return strings.Compare(d.tags[i].Name, d.tags[j].Name) < 0
+	})
+}
+
+func (d *SQLiteStore) sortClients() {
+	sort.SliceStable(d.clients, func(i, j int) bool {
+		return strings.Compare(d.clients[i].Name, d.clients[j].Name) < 0
+	})
+}
+
+func (d *SQLiteStore) updateProjectsMapping() {
+	d.projectsMap = map[string]*model.Project{}
+	for _, p := range d.projects {
+		d.projectsMap[p.ID] = p
+	}
+}
+
+func (d *SQLiteStore) updateAllProjectInternals() {
+	for _, p := range d.projects {
+		d.updateProjectInternals(p)
+	}
+}
+
+func (d *SQLiteStore) updateProjectInternals(p *model.Project) {
+	p.Store = d
+
+	p.FullName = []string{p.Name}
+	if p.ParentID == "" {
+		return
+	}
+
+	parents := []string{p.Name}
+
+	// missing and cyclic parents are reported by "tom check"
+	visited := map[string]bool{p.ID: true}
+	id := p.ParentID
+	for id != "" && !visited[id] {
+		parent, ok := d.projectsMap[id]
+		if !ok {
+			break
+		}
+
+		visited[id] = true
+		id = parent.ParentID
+		parents = append([]string{parent.Name}, parents...)
+	}
+
+	p.FullName = parents
+}
+
+func (d *SQLiteStore) Reset(projects, tags, frames bool) (int, int, int, error) {
+	defer d.notify()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if err := d.beforeChangeLocked(); err != nil {
+		return 0, 0, 0, err
+	}
+
+	var projectCount, tagCount, frameCount int
+	if projects {
+		projectCount = len(d.projects)
+		if _, err := d.executor().Exec("DELETE FROM projects"); err != nil {
+			return 0, 0, 0, err
+		}
+		for _, p := range d.projects {
+			d.events.projectChanged(model.EventRemoved, p.ID, nil)
+		}
+		d.projects = []*model.Project{}
+		d.updateProjectsMapping()
+	}
+	if tags {
+		tagCount = len(d.tags)
+		if _, err := d.executor().Exec("DELETE FROM tags"); err != nil {
+			return 0, 0, 0, err
+		}
+		for _, t := range d.tags {
+			d.events.tagChanged(model.EventRemoved, t.ID, nil)
+		}
+		d.tags = []*model.Tag{}
+	}
+	if frames {
+		// the frames aren't kept in memory, they're only read if the events are needed
+		if d.events.active() {
+			err := d.scanFramesLocked("", nil, func(f *model.Frame) (bool, error) {
+				d.events.frameChanged(model.EventRemoved, f.ID, f, nil)
+				return true, nil
+			})
+			if err != nil {
+				return 0, 0, 0, err
+			}
+		}
+
+		result, err := d.executor().Exec("DELETE FROM frames")
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		count, _ := result.RowsAffected()
+		frameCount = int(count)
+	}
+
+	return projectCount, tagCount, frameCount, nil
+}
+
+func (d *SQLiteStore) Projects() model.ProjectList {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return d.projects
+}
+
+func (d *SQLiteStore) ProjectByID(id string) (*model.Project, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	p, ok := d.projectsMap[id]
+	if !ok {
+		return nil, fmt.Errorf("no project found for %s", id)
+	}
+	return p, nil
+}
+
+func (d *SQLiteStore) ProjectIsSameOrChild(parentID, id string) bool {
+	if parentID == id {
+		return true
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	visited := map[string]bool{}
+	for id != "" && !visited[id] {
+		if id == parentID {
+			return true
+		}
+
+		project, ok := d.projectsMap[id]
+		if !ok {
+			return false
+		}
+		visited[id] = true
+		id = project.ParentID
+	}
+	return false
+}
+
+func (d *SQLiteStore) AddProject(project model.Project) (*model.Project, error) {
+	project.ID = model.NextID()
+	return d.addProject(project)
+}
+
+// addProject adds the project with its ID, it's used to restore a removed project
+func (d *SQLiteStore) addProject(project model.Project) (*model.Project, error) {
+	defer d.notify()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if err := d.writeProjectLocked(&project); err != nil {
+		return nil, err
+	}
+
+	d.projects = append(d.projects, &project)
+	d.updateProjectsMapping()
+	d.updateProjectInternals(&project)
+	d.sortProjects()
+	d.events.projectChanged(model.EventAdded, project.ID, &project)
+	return &project, nil
+}
+
+func (d *SQLiteStore) UpdateProject(project model.Project) (*model.Project, error) {
+	if err := project.Validate(); err != nil {
+		return nil, err
+	}
+
+	defer d.notify()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	existing, ok := d.projectsMap[project.ID]
+	if !ok {
+		return nil, fmt.Errorf("no project found for %s", project.ID)
+	}
+
+	if err := d.writeProjectLocked(&project); err != nil {
+		return nil, err
+	}
+
+	*existing = project
+	// the full names of the subprojects depend on this project
+	d.updateAllProjectInternals()
+	d.sortProjects()
+	d.events.projectChanged(model.EventUpdated, existing.ID, existing)
+	return existing, nil
+}
+
+func (d *SQLiteStore) writeProjectLocked(project *model.Project) error {
+	data, err := json.Marshal(project)
+	if err != nil {
+		return err
+	}
+
+	if err := d.beforeChangeLocked(); err != nil {
+		return err
+	}
+	_, err = d.executor().Exec("INSERT OR REPLACE INTO projects (id, parent_id, data) VALUES (?, ?, ?)", project.ID, project.ParentID, string(data))
+	return err
+}
+
+func (d *SQLiteStore) RemoveProject(id string) error {
+	defer d.notify()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for i, p := range d.projects {
+		if p.ID == id {
+			if err := d.beforeChangeLocked(); err != nil {
+				return err
+			}
+			if _, err := d.executor().Exec("DELETE FROM projects WHERE id = ?", id); err != nil {
+				return err
+			}
+
+			d.projects = append(d.projects[:i], d.projects[i+1:]...)
+			d.updateProjectsMapping()
+			d.events.projectChanged(model.EventRemoved, p.ID, nil)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("project %s not found", id)
+}
+
+func (d *SQLiteStore) FindFirstProject(filter func(*model.Project) bool) (*model.Project, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	for _, p := range d.projects {
+		if filter(p) {
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("no matching project found")
+}
+
+func (d *SQLiteStore) FindProjects(filter func(*model.Project) bool) []*model.Project {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	var result []*model.Project
+	for _, p := range d.projects {
+		if filter(p) {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+func (d *SQLiteStore) Tags() []*model.Tag {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return d.tags
+}
+
+func (d *SQLiteStore) AddTag(tag model.Tag) (*model.Tag, error) {
+	tag.ID = model.NextID()
+	return d.addTag(tag)
+}
+
+// addTag adds the tag with its ID, it's used to restore a removed tag
+func (d *SQLiteStore) addTag(tag model.Tag) (*model.Tag, error) {
+	if err := tag.Validate(); err != nil {
+		return nil, err
+	}
+
+	defer d.notify()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if err := d.writeTagLocked(&tag); err != nil {
+		return nil, err
+	}
+
+	d.tags = append(d.tags, &tag)
+	d.sortTags()
+	d.events.tagChanged(model.EventAdded, tag.ID, &tag)
+	return &tag, nil
+}
+
+func (d *SQLiteStore) UpdateTag(tag model.Tag) (*model.Tag, error) {
+	if err := tag.Validate(); err != nil {
+		return nil, err
+	}
+
+	defer d.notify()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, existing := range d.tags {
+		if existing.ID == tag.ID {
+			if err := d.writeTagLocked(&tag); err != nil {
+				return nil, err
+			}
+
+			*existing = tag
+			d.sortTags()
+			d.events.tagChanged(model.EventUpdated, existing.ID, existing)
+			return existing, nil
+		}
+	}
+	return nil, fmt.Errorf("tag %s not found", tag.ID)
+}
+
+func (d *SQLiteStore) writeTagLocked(tag *model.Tag) error {
+	data, err := json.Marshal(tag)
+	if err != nil {
+		return err
+	}
+
+	if err := d.beforeChangeLocked(); err != nil {
+		return err
+	}
+	_, err = d.executor().Exec("INSERT OR REPLACE INTO tags (id, data) VALUES (?, ?)", tag.ID, string(data))
+	return err
+}
+
+func (d *SQLiteStore) RemoveTag(id string) error {
+	defer d.notify()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for i, t := range d.tags {
+		if t.ID == id {
+			if err := d.beforeChangeLocked(); err != nil {
+				return err
+			}
+			if _, err := d.executor().Exec("DELETE FROM tags WHERE id = ?", id); err != nil {
+				return err
+			}
+
+			d.tags = append(d.tags[:i], d.tags[i+1:]...)
+			d.events.tagChanged(model.EventRemoved, t.ID, nil)
+			return nil
+		}
+	}
+	return fmt.Errorf("tag %s not found", id)
+}
+
+func (d *SQLiteStore) FindFirstTag(filter func(*model.Tag) bool) (*model.Tag, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	for _, tag := range d.tags {
+		if filter(tag) {
+			return tag, nil
+		}
+	}
+	return nil, ErrTagNotFound
+}
+
+func (d *SQLiteStore) FindTags(filter func(*model.Tag) bool) []*model.Tag {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	var result []*model.Tag
+	for _, tag := range d.tags {
+		if filter(tag) {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
+
+func (d *SQLiteStore) Clients() []*model.Client {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return d.clients
+}
+
+func (d *SQLiteStore) AddClient(client model.Client) (*model.Client, error) {
+	if err := client.Validate(); err != nil {
+		return nil, err
+	}
+
+	defer d.notify()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	client.ID = model.NextID()
+	if err := d.writeClientLocked(&client); err != nil {
+		return nil, err
+	}
+
+	d.clients = append(d.clients, &client)
+	d.sortClients()
+	d.events.clientChanged(model.EventAdded, client.ID, &client)
+	return &client, nil
+}
+
+func (d *SQLiteStore) UpdateClient(client model.Client) (*model.Client, error) {
+	if err := client.Validate(); err != nil {
+		return nil, err
+	}
+
+	defer d.notify()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, existing := range d.clients {
+		if existing.ID == client.ID {
+			if err := d.writeClientLocked(&client); err != nil {
+				return nil, err
+			}
+
+			*existing = client
+			d.sortClients()
+			d.events.clientChanged(model.EventUpdated, existing.ID, existing)
+			return existing, nil
+		}
+	}
+	return nil, fmt.Errorf("client %s not found", client.ID)
+}
+
+func (d *SQLiteStore) writeClientLocked(client *model.Client) error {
+	data, err := json.Marshal(client)
+	if err != nil {
+		return err
+	}
+
+	if err := d.beforeChangeLocked(); err != nil {
+		return err
+	}
+	_, err = d.executor().Exec("INSERT OR REPLACE INTO clients (id, data) VALUES (?, ?)", client.ID, string(data))
+	return err
+}
+
+func (d *SQLiteStore) RemoveClient(id string) error {
+	defer d.notify()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for i, c := range d.clients {
+		if c.ID == id {
+			if err := d.beforeChangeLocked(); err != nil {
+				return err
+			}
+			if _, err := d.executor().Exec("DELETE FROM clients WHERE id = ?", id); err != nil {
+				return err
+			}
+
+			d.clients = append(d.clients[:i], d.clients[i+1:]...)
+			d.events.clientChanged(model.EventRemoved, c.ID, nil)
+			return nil
+		}
+	}
+	return fmt.Errorf("client %s not found", id)
+}
+
+func (d *SQLiteStore) FindFirstClient(filter func(*model.Client) bool) (*model.Client, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	for _, client := range d.clients {
+		if filter(client) {
+			return client, nil
+		}
+	}
+	return nil, ErrClientNotFound
+}
+
+func (d *SQLiteStore) FindClients(filter func(*model.Client) bool) []*model.Client {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	var result []*model.Client
+	for _, client := range d.clients {
+		if filter(client) {
+			result = append(result, client)
+		}
+	}
+	return result
+}
+
+func (d *SQLiteStore) Frames() model.FrameList {
+	return d.tryQueryFrames("", nil)
+}
+
+func (d *SQLiteStore) FramesByProject(projectIDs ...string) model.FrameList {
+	if len(projectIDs) == 0 {
+		return model.FrameList{}
+	}
+
+	args := make([]interface{}, len(projectIDs))
+	for i, id := range projectIDs {
+		args[i] = id
+	}
+
+	return d.tryQueryFrames("WHERE project_id IN (?"+strings.Repeat(",?", len(projectIDs)-1)+")", args)
+}
+
+// FramesByTag returns the frames, which have at least one of the tags
+func (d *SQLiteStore) FramesByTag(tagIDs ...string) model.FrameList {
+	if len(tagIDs) == 0 {
+		return model.FrameList{}
+	}
+
+	args := make([]interface{}, len(tagIDs))
+	for i, id := range tagIDs {
+		args[i] = id
+	}
+
+	return d.tryQueryFrames("WHERE EXISTS (SELECT 1 FROM json_each(frames.data, '$.tags') WHERE value IN (?"+strings.Repeat(",?", len(tagIDs)-1)+"))", args)
+}
+
+// FramesInRange returns the frames intersecting the range, the indexes of start and end time are used.
+func (d *SQLiteStore) FramesInRange(start, end *time.Time) model.FrameList {
+	var conditions []string
+	var args []interface{}
+	if end != nil {
+		conditions = append(conditions, "start_time <= ?")
+		args = append(args, end.UnixNano())
+	}
+	if start != nil {
+		conditions = append(conditions, "(end_time IS NULL OR end_time >= ?)")
+		args = append(args, start.UnixNano())
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+	return d.tryQueryFrames(where, args)
+}
+
+func (d *SQLiteStore) ActiveFrames() model.FrameList {
+	return d.tryQueryFrames("WHERE end_time IS NULL", nil)
+}
+
+// tryQueryFrames is used by the methods, which aren't able to return an error.
+// The error of a failed query is logged and an empty list is returned.
+func (d *SQLiteStore) tryQueryFrames(where string, args []interface{}) model.FrameList {
+	frames, err := d.queryFrames(where, args)
+	if err != nil {
+		log.Printf("unable to read frames: %s", err.Error())
+		return model.FrameList{}
+	}
+	return frames
+}
+
+func (d *SQLiteStore) queryFrames(where string, args []interface{}) (model.FrameList, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	result := model.FrameList{}
+	err := d.scanFramesLocked(where, args, func(frame *model.Frame) (bool, error) {
+		result = append(result, frame)
+		return true, nil
+	})
+	return result, err
+}
+
+// scanFramesLocked passes the matching frames ordered by start time to handler until it returns false
+func (d *SQLiteStore) scanFramesLocked(where string, args []interface{}, handler func(*model.Frame) (bool, error)) error {
+	rows, err := d.executor().Query("SELECT data FROM frames "+where+" ORDER BY start_time, rowid", args...)
+	if err != nil {
+		return err
+	}
+
+	return scanJSONRows(rows, func(data []byte) error {
+		var frame model.Frame
+		if err := json.Unmarshal(data, &frame); err != nil {
+			return err
+		}
+		d.frameVersions.Store(frame.ID, frame.Updated)
+
+		next, err := handler(&frame)
+		if err != nil {
+			return err
+		} else if !next {
+			return errStopScan
+		}
+		return nil
+	})
+}
+
+func (d *SQLiteStore) AddFrame(frame model.Frame) (*model.Frame, error) {
+	frame.ID = model.NextID()
+	return d.addFrame(frame)
+}
+
+// addFrame adds the frame with its ID, it's used to restore a removed frame
+func (d *SQLiteStore) addFrame(frame model.Frame) (*model.Frame, error) {
+	if err := frame.Validate(false); err != nil {
+		return nil, err
+	}
+
+	defer d.notify()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := time.Now()
+	if frame.Updated == nil {
+		frame.Updated = &now
+	}
+	if frame.Created == nil {
+		frame.Created = &now
+		frame.CreatedBy = d.command
+	}
+	if err := d.writeFrameLocked(&frame); err != nil {
+		return nil, err
+	}
+	d.frameVersions.Store(frame.ID, frame.Updated)
+	d.events.frameChanged(model.EventAdded, frame.ID, nil, &frame)
+	return &frame, nil
+}
+
+func (d *SQLiteStore) UpdateFrame(frame model.Frame) (*model.Frame, error) {
+	if err := frame.Validate(true); err != nil {
+		return nil, err
+	}
+
+	defer d.notify()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	old, err := d.frameByIDLocked(frame.ID)
+	if err != nil {
+		return nil, err
+	} else if old == nil {
+		return nil, fmt.Errorf("no frame with ID %s found", frame.ID)
+	}
+
+	now := time.Now()
+	frame.Updated = &now
+	frame.RecordChange(old, now, d.command)
+
+	if base, ok := d.frameVersions.Load(frame.ID); ok {
+		if err := d.updateFrameVersionLocked(&frame, base.(*time.Time)); err != nil {
+			return nil, err
+		}
+	} else if err := d.writeFrameLocked(&frame); err != nil {
+		return nil, err
+	}
+	d.frameVersions.Store(frame.ID, frame.Updated)
+	d.events.frameChanged(model.EventUpdated, frame.ID, old, &frame)
+	return &frame, nil
+}
+
+// updateFrameVersionLocked updates the frame only if it's still at the version base.
+// ErrConflict is returned if it was modified or removed by another process.
+func (d *SQLiteStore) updateFrameVersionLocked(frame *model.Frame, base *time.Time) error {
+	data, err := json.Marshal(frame)
+	if err != nil {
+		return err
+	}
+
+	var end interface{}
+	if frame.IsStopped() {
+		end = frame.End.UnixNano()
+	}
+
+	version, err := versionValue(base)
+	if err != nil {
+		return err
+	}
+
+	if err := d.beforeChangeLocked(); err != nil {
+		return err
+	}
+	result, err := d.executor().Exec("UPDATE frames SET project_id = ?, start_time = ?, end_time = ?, data = ? WHERE id = ? AND json_extract(data, '$.updated') IS ?",
+		frame.ProjectId, frame.Start.UnixNano(), end, string(data), frame.ID, version)
+	if err != nil {
+		return err
+	}
+
+	if count, err := result.RowsAffected(); err != nil {
+		return err
+	} else if count == 0 {
+		if !d.rowExistsLocked("frames", frame.ID) {
+			return fmt.Errorf("%w: frame %s was removed by another process", ErrConflict, frame.ID)
+		}
+		return fmt.Errorf("%w: frame %s was modified by another process", ErrConflict, frame.ID)
+	}
+	return nil
+}
+
+// versionValue returns the value of Frame.Updated as it's stored in the JSON data of the frames table
+func versionValue(version *time.Time) (interface{}, error) {
+	if version == nil {
+		return nil, nil
+	}
+
+	encoded, err := json.Marshal(version)
+	if err != nil {
+		return nil, err
+	}
+	return strings.Trim(string(encoded), `"`), nil
+}
+
+func (d *SQLiteStore) writeFrameLocked(frame *model.Frame) error {
+	data, err := json.Marshal(frame)
+	if err != nil {
+		return err
+	}
+
+	var end interface{}
+	if frame.IsStopped() {
+		end = frame.End.UnixNano()
+	}
+
+	if err := d.beforeChangeLocked(); err != nil {
+		return err
+	}
+	_, err = d.executor().Exec("INSERT OR REPLACE INTO frames (id, project_id, start_time, end_time, data) VALUES (?, ?, ?, ?, ?)",
+		frame.ID, frame.ProjectId, frame.Start.UnixNano(), end, string(data))
+	return err
+}
+
+func (d *SQLiteStore) rowExistsLocked(table string, id string) bool {
+	rows, err := d.executor().Query("SELECT 1 FROM "+table+" WHERE id = ?", id)
+	if err != nil {
+		return false
+	}
+	defer rows.Close()
+	return rows.Next()
+}
+
+// frameByIDLocked returns the frame with the given ID or nil if it doesn't exist.
+// Unlike scanFramesLocked, it doesn't update the known version of the frame, which is used to detect conflicts.
+func (d *SQLiteStore) frameByIDLocked(id string) (*model.Frame, error) {
+	rows, err := d.executor().Query("SELECT data FROM frames WHERE id = ?", id)
+	if err != nil {
+		return nil, err
+	}
+
+	var frame *model.Frame
+	err = scanJSONRows(rows, func(data []byte) error {
+		frame = &model.Frame{}
+		return json.Unmarshal(data, frame)
+	})
+	return frame, err
+}
+
+func (d *SQLiteStore) RemoveFrame(id string) error {
+	defer d.notify()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	old, err := d.frameByIDLocked(id)
+	if err != nil {
+		return err
+	} else if old == nil {
+		return fmt.Errorf("frame %s not found", id)
+	}
+
+	if err := d.beforeChangeLocked(); err != nil {
+		return err
+	}
+	base, ok := d.frameVersions.Load(id)
+	if !ok {
+		if _, err := d.executor().Exec("DELETE FROM frames WHERE id = ?", id); err != nil {
+			return err
+		}
+		d.events.frameChanged(model.EventRemoved, id, old, nil)
+		return nil
+	}
+
+	version, err := versionValue(base.(*time.Time))
+	if err != nil {
+		return err
+	}
+
+	result, err := d.executor().Exec("DELETE FROM frames WHERE id = ? AND json_extract(data, '$.updated') IS ?", id, version)
+	if err != nil {
+		return err
+	}
+	if count, err := result.RowsAffected(); err != nil {
+		return err
+	} else if count == 0 {
+		return fmt.Errorf("%w: frame %s was modified by another process", ErrConflict, id)
+	}
+	d.frameVersions.Delete(id)
+	d.events.frameChanged(model.EventRemoved, id, old, nil)
+	return nil
+}
+
+func (d *SQLiteStore) FrameByID(id string) (*model.Frame, error) {
+	frames, err := d.queryFrames("WHERE id = ?", []interface{}{id})
+	if err != nil {
+		return nil, err
+	} else if len(frames) == 0 {
+		return nil, fmt.Errorf("frame %s not found", id)
+	}
+	return frames[0], nil
+}
+
+// FindFirstFrame has to decode all frames because the filter function can't be mapped to an index.
+// The filter may query the store, it's called after the rows were read and the lock was released.
+func (d *SQLiteStore) FindFirstFrame(filter func(*model.Frame) bool) (*model.Frame, error) {
+	frames, err := d.queryFrames("", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, frame := range frames {
+		if filter(frame) {
+			return frame, nil
+		}
+	}
+	return nil, fmt.Errorf("no matching frame found")
+}
+
+// FindFrames has to decode all frames because the filter function can't be mapped to an index.
+// Use FramesByProject or ActiveFrames if possible. The filter is called like the filter of FindFirstFrame.
+func (d *SQLiteStore) FindFrames(filter func(*model.Frame) (bool, error)) ([]*model.Frame, error) {
+	frames, err := d.queryFrames("", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*model.Frame
+	for _, frame := range frames {
+		if ok, err := filter(frame); err != nil {
+			return nil, err
+		} else if ok {
+			result = append(result, frame)
+		}
+	}
+	return result, nil
+}
+
+// backupLocked creates a backup of the database before it's modified for the first time by the session and returns the directory of the backup.
+// The directory is empty if the session was already backed up, Undo reverts all changes of a session at once.
+// Changes reverted by Undo can't be redone after the data was modified again.
+func (d *SQLiteStore) backupLocked() (string, error) {
+	if d.backupPath == "" || d.backedUp {
+		return "", nil
+	}
+
+	backupDir, err := d.createSnapshotLocked(d.backupPath, BackupInfo{Command: d.command, Session: d.session})
+	if err != nil {
+		return "", err
+	}
+	d.backedUp = true
+	_ = os.RemoveAll(filepath.Join(d.backupPath, redoDirName))
+
+	removeOldBackups(d.backupPath, d.maxBackups, nil)
+	return backupDir, nil
+}
+
+// stageFile writes the file of the data directory when the transaction of Update is committed.
+// The backup of the transaction contains the previous version of the file.
+func (d *SQLiteStore) stageFile(name string, data []byte) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.stagedFiles == nil {
+		d.stagedFiles = map[string][]byte{}
+	}
+	d.stagedFiles[name] = data
+}
+
+func (d *SQLiteStore) writeStagedFilesLocked() error {
+	files := d.stagedFiles
+	d.stagedFiles = nil
+	for name, data := range files {
+		path := filepath.Join(d.path, name)
+		if data == nil {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		} else if err := util.WriteFileAtomic(path, data, 0600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lockExclusive is used by operations of several steps, e.g. Undo.
+// SQLite locks the database file itself, there's no lock of the data directory.
+func (d *SQLiteStore) lockExclusive() (func(), error) {
+	return func() {}, nil
+}
+
+func (d *SQLiteStore) createSnapshot(parentDir string, info BackupInfo) (string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.createSnapshotLocked(parentDir, info)
+}
+
+func (d *SQLiteStore) createSnapshotLocked(parentDir string, info BackupInfo) (string, error) {
+	info.Created = time.Now()
+	info.Origin = backupOrigin(d.path)
+	targetDir, err := newBackupDir(parentDir, info.Created)
+	if err != nil {
+		return "", err
+	}
+
+	// VACUUM INTO creates a consistent copy of the database
+	if _, err := d.db.Exec("VACUUM INTO ?", filepath.Join(targetDir, sqliteFileName)); err != nil {
+		return "", err
+	}
+	if err := copyFiles(dataFilePaths(d.path), targetDir, true); err != nil {
+		return "", err
+	}
+	return targetDir, writeBackupInfo(targetDir, info, nil)
+}
+
+var errStopScan = fmt.Errorf("stop scan")
+
+// scanJSONRows passes the first column of each row to handler and closes rows when done
+func scanJSONRows(rows *sql.Rows, handler func(data []byte) error) error {
+	defer rows.Close()
+
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return err
+		}
+		if err := handler(data); err == errStopScan {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (d *SQLiteStore) replaceAll(projects []*model.Project, tags []*model.Tag, clients []*model.Client, frames []*model.Frame, backup bool) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.batch != nil {
+		return fmt.Errorf("unable to replace data in a transaction")
+	}
+
+	if backup {
+		if _, err := d.backupLocked(); err != nil {
+			return err
+		}
+	}
+
+	tx, err := d.db.Begin()
+	if err != nil {
+		return err
+	}
+	d.batch = tx
+
+	err = d.replaceAllLocked(projects, tags, clients, frames)
+	d.batch = nil
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+
+	d.resetFrameVersions()
+	return d.loadLocked()
+}
+
+// resetFrameVersions forgets the versions of the frames, which were read from the database
+func (d *SQLiteStore) resetFrameVersions() {
+	d.frameVersions.Range(func(key, _ interface{}) bool {
+		d.frameVersions.Delete(key)
+		return true
+	})
+}
+
+func (d *SQLiteStore) replaceAllLocked(projects []*model.Project, tags []*model.Tag, clients []*model.Client, frames []*model.Frame) error {
+	for _, table := range []string{"projects", "tags", "clients", "frames"} {
+		if _, err := d.batch.Exec("DELETE FROM " + table); err != nil {
+			return err
+		}
+	}
+
+	for _, p := range projects {
+		if err := d.writeProjectLocked(p); err != nil {
+			return err
+		}
+	}
+	for _, t := range tags {
+		if err := d.writeTagLocked(t); err != nil {
+			return err
+		}
+	}
+	for _, c := range clients {
+		if err := d.writeClientLocked(c); err != nil {
+			return err
+		}
+	}
+	for _, f := range frames {
+		if err := d.writeFrameLocked(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
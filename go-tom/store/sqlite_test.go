@@ -0,0 +1,191 @@
+package store_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jansorg/tom/go-tom/model"
+	"github.com/jansorg/tom/go-tom/store"
+)
+
+func Test_SQLiteStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tom")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	backupDir, err := ioutil.TempDir("", "tom-backup")
+	require.NoError(t, err)
+	defer os.RemoveAll(backupDir)
+
+	s, err := store.NewSQLiteStore(dir, backupDir, 10)
+	require.NoError(t, err)
+	assert.Empty(t, s.Projects())
+	assert.Empty(t, s.Tags())
+	assert.Empty(t, s.Frames())
+
+	top, err := s.AddProject(model.Project{Name: "top"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, top.ID)
+
+	child, err := s.AddProject(model.Project{Name: "child", ParentID: top.ID})
+	require.NoError(t, err)
+	assert.EqualValues(t, "top/child", child.GetFullName("/"))
+
+	// renaming the parent must update the full name of the child
+	top.Name = "renamed"
+	_, err = s.UpdateProject(*top)
+	require.NoError(t, err)
+	child, err = s.ProjectByID(child.ID)
+	require.NoError(t, err)
+	assert.EqualValues(t, "renamed/child", child.GetFullName("/"))
+
+	tag, err := s.AddTag(model.Tag{Name: "tag"})
+	require.NoError(t, err)
+
+	start := time.Now().Add(-time.Hour)
+	end := start.Add(10 * time.Minute)
+	stopped, err := s.AddFrame(model.Frame{ProjectId: top.ID, Start: &start, End: &end, TagIDs: []string{tag.ID}})
+	require.NoError(t, err)
+
+	active, err := s.AddFrame(model.Frame{ProjectId: child.ID, Start: &end})
+	require.NoError(t, err)
+
+	assert.Len(t, s.Frames(), 2)
+	assert.Len(t, s.FramesByProject(top.ID), 1)
+	assert.Len(t, s.FramesByProject(top.ID, child.ID), 2)
+	require.Len(t, s.ActiveFrames(), 1)
+	assert.EqualValues(t, active.ID, s.ActiveFrames()[0].ID)
+
+	active.StopAt(end.Add(5 * time.Minute))
+	_, err = s.UpdateFrame(*active)
+	require.NoError(t, err)
+	assert.Empty(t, s.ActiveFrames())
+
+	_, err = s.UpdateFrame(model.Frame{ID: "unknown", ProjectId: top.ID, Start: &start})
+	require.Error(t, err)
+
+	// reopen and verify that the data was persisted
+	reopened, err := store.NewSQLiteStore(dir, "", 0)
+	require.NoError(t, err)
+	assert.Len(t, reopened.Projects(), 2)
+	assert.Len(t, reopened.Tags(), 1)
+
+	frame, err := reopened.FindFirstFrame(func(f *model.Frame) bool {
+		return f.ID == stopped.ID
+	})
+	require.NoError(t, err)
+	assert.True(t, frame.Start.Equal(start))
+	assert.True(t, frame.End.Equal(end))
+	assert.EqualValues(t, []string{tag.ID}, frame.TagIDs)
+	require.NoError(t, reopened.(*store.SQLiteStore).Close())
+
+	require.NoError(t, s.RemoveFrame(stopped.ID))
+	require.Error(t, s.RemoveFrame(stopped.ID))
+	require.NoError(t, s.RemoveTag(tag.ID))
+	require.Error(t, s.RemoveTag(tag.ID))
+	require.NoError(t, s.RemoveProject(child.ID))
+	require.Error(t, s.RemoveProject(child.ID))
+
+	assert.NotEmpty(t, countBackups(backupDir))
+}
+
+func Test_SQLiteStoreBatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tom")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	backupDir, err := ioutil.TempDir("", "tom-backup")
+	require.NoError(t, err)
+	defer os.RemoveAll(backupDir)
+
+	s, err := store.NewSQLiteStore(dir, backupDir, 10)
+	require.NoError(t, err)
+
+	_, err = s.AddProject(model.Project{Name: "first"})
+	require.NoError(t, err)
+
+	err = s.Update(func(tx model.Store) error {
+		for _, name := range []string{"a", "b", "c"} {
+			if _, err := tx.AddProject(model.Project{Name: name}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Len(t, s.Projects(), 4)
+	assert.EqualValues(t, 1, countBackups(backupDir), "a command must only create a single backup")
+
+	// a rolled back transaction doesn't keep its backup
+	s, err = store.NewSQLiteStore(dir, backupDir, 10)
+	require.NoError(t, err)
+	err = s.Update(func(tx model.Store) error {
+		return fmt.Errorf("rollback")
+	})
+	require.Error(t, err)
+	assert.EqualValues(t, 1, countBackups(backupDir))
+
+	_, err = s.AddProject(model.Project{Name: "second"})
+	require.NoError(t, err)
+	_, err = s.AddProject(model.Project{Name: "third"})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, countBackups(backupDir), "each command must create a backup")
+}
+
+func Test_Migrate(t *testing.T) {
+	jsonDir, err := ioutil.TempDir("", "tom")
+	require.NoError(t, err)
+	defer os.RemoveAll(jsonDir)
+
+	sqliteDir, err := ioutil.TempDir("", "tom")
+	require.NoError(t, err)
+	defer os.RemoveAll(sqliteDir)
+
+	jsonStore, err := store.NewStore(jsonDir, "", 0)
+	require.NoError(t, err)
+
+	top, err := jsonStore.AddProject(model.Project{Name: "top"})
+	require.NoError(t, err)
+	child, err := jsonStore.AddProject(model.Project{Name: "child", ParentID: top.ID})
+	require.NoError(t, err)
+	tag, err := jsonStore.AddTag(model.Tag{Name: "tag"})
+	require.NoError(t, err)
+
+	start := time.Now()
+	frame, err := jsonStore.AddFrame(model.Frame{ProjectId: child.ID, Start: &start, TagIDs: []string{tag.ID}, Notes: "notes"})
+	require.NoError(t, err)
+
+	sqliteStore, err := store.NewSQLiteStore(sqliteDir, "", 0)
+	require.NoError(t, err)
+	require.NoError(t, store.Migrate(jsonStore, sqliteStore))
+
+	migratedChild, err := sqliteStore.ProjectByID(child.ID)
+	require.NoError(t, err)
+	assert.EqualValues(t, "top/child", migratedChild.GetFullName("/"))
+	_, err = sqliteStore.FindFirstTag(func(t *model.Tag) bool { return t.ID == tag.ID })
+	require.NoError(t, err)
+
+	frames := sqliteStore.FramesByProject(child.ID)
+	require.Len(t, frames, 1)
+	assert.EqualValues(t, frame.ID, frames[0].ID)
+	assert.EqualValues(t, "notes", frames[0].Notes)
+
+	// and back into an empty json store
+	jsonDir2, err := ioutil.TempDir("", "tom")
+	require.NoError(t, err)
+	defer os.RemoveAll(jsonDir2)
+
+	jsonStore2, err := store.NewStore(jsonDir2, "", 0)
+	require.NoError(t, err)
+	require.NoError(t, store.Migrate(sqliteStore, jsonStore2))
+	assert.Len(t, jsonStore2.Projects(), 2)
+	assert.Len(t, jsonStore2.Tags(), 1)
+	assert.Len(t, jsonStore2.ActiveFrames(), 1)
+}
@@ -3,8 +3,6 @@ package store
 import (
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
-	"log"
 	"os"
 	"path/filepath"
 	"sort"
@@ -14,29 +12,66 @@ import (
 	"time"
 
 	"github.com/jansorg/tom/go-tom/model"
-	"github.com/jansorg/tom/go-tom/util"
+)
+
+const (
+	journalFileName = "journal.log"
+	versionFileName = "version.json"
+	lockFileName    = "tom.lock"
 )
 
 var ErrTagNotFound = fmt.Errorf("tag not found")
+var ErrClientNotFound = fmt.Errorf("client not found")
+var ErrNestedTransaction = fmt.Errorf("Update() called in a transaction")
 
 func NewStore(dir string, backupDir string, maxBackups int) (model.Store, error) {
+	return newDataStore(Options{Dir: dir, BackupDir: backupDir, MaxBackups: maxBackups})
+}
+
+func newDataStore(options Options) (*DataStore, error) {
+	dir := options.Dir
 	if _, err := os.Stat(dir); err != nil && os.IsNotExist(err) {
 		return nil, fmt.Errorf("directory %s does not exist", dir)
 	}
 
 	store := &DataStore{
-		path:         dir,
-		backupPath:   backupDir,
-		maxBackups:   maxBackups,
-		ProjectFile:  filepath.Join(dir, "projects.json"),
-		TagFile:      filepath.Join(dir, "tags.json"),
-		FrameFile:    filepath.Join(dir, "frames.json"),
-		PropertyFile: filepath.Join(dir, "properties.json"),
+		path:               dir,
+		backupPath:         options.BackupDir,
+		maxBackups:         options.MaxBackups,
+		command:            options.Command,
+		session:            model.NextID(),
+		journalEnabled:     options.Journal,
+		journalCompactSize: options.JournalCompactSize,
+		ProjectFile:        filepath.Join(dir, "projects.json"),
+		TagFile:            filepath.Join(dir, "tags.json"),
+		ClientFile:         filepath.Join(dir, "clients.json"),
+		FrameFile:          filepath.Join(dir, "frames.json"),
+		FrameIndexFile:     filepath.Join(dir, "frames-index.json"),
+		PropertyFile:       filepath.Join(dir, "properties.json"),
+		JournalFile:        filepath.Join(dir, journalFileName),
+		VersionFile:        filepath.Join(dir, versionFileName),
+		LockFile:           filepath.Join(dir, lockFileName),
+		EncryptionFile:     filepath.Join(dir, encryptionFileName),
+	}
+	if !options.noLock {
+		store.lock = newDirLock(store.LockFile, options.LockTimeout)
+	}
+
+	var err error
+	if store.cipher, err = openDataCipher(dir, options.Passphrase); err != nil {
+		return nil, err
 	}
 
 	if err := store.loadLocked(); err != nil {
 		return nil, err
 	}
+
+	// backups are migrated when they're loaded, but they're never modified
+	if store.version < FormatVersion && !options.noLock {
+		if err := store.upgrade(); err != nil {
+			return nil, err
+		}
+	}
 	return store, nil
 }
 
@@ -46,17 +81,60 @@ type DataStore struct {
 	batchMode  int32
 
 	maxBackups int
-
-	ProjectFile  string
-	TagFile      string
-	FrameFile    string
-	PropertyFile string
+	command    string
+	session    string
+	// backedUp is true after the backup of the session was created
+	backedUp bool
+
+	// changes are appended to the journal file, the data files are only written when the journal is compacted
+	journalEnabled     bool
+	journalCompactSize int
+	journalSize        int
+	pendingEntries     []journalEntry
+	// stagedFiles are written into the data directory with the next commit, a nil value removes the file
+	stagedFiles map[string][]byte
+
+	ProjectFile string
+	TagFile     string
+	ClientFile  string
+	// FrameFile contains all frames in data format version 1, newer versions store the frames in one file per year
+	FrameFile      string
+	FrameIndexFile string
+	PropertyFile   string
+	JournalFile    string
+	VersionFile    string
+	LockFile       string
+	EncryptionFile string
+
+	// version of the data format on disk and if it's stored in the version file
+	version       int
+	versionStored bool
+
+	lock   dirLock
+	events observers
+	// cipher encrypts the data files, it's nil if the data isn't encrypted
+	cipher *dataCipher
+	// state of the data files when they were last read or written by this store
+	fileStates map[string]fileState
+	// Frame.Updated of the frames when they were last read or written by this store
+	frameVersions map[string]*time.Time
+
+	// frameIndex describes the files of the years, loadedYears are the years in memory
+	// and dirtyYears the years which have to be written.
+	// frameYears is the year of the file of each frame in memory, callers modify the start of the stored frames in place.
+	frameIndex  frameIndex
+	loadedYears map[int]bool
+	dirtyYears  map[int]bool
+	frameYears  map[string]int
 
 	mu          sync.RWMutex
 	projectsMap map[string]*model.Project
 	projects    []*model.Project
 	tags        []*model.Tag
+	clients     []*model.Client
 	frames      []*model.Frame
+	// index is maintained with the frames in memory, it's used by the queries of frames
+	index *queryIndex
 }
 
 func (d *DataStore) DirPath() string {
@@ -71,20 +149,50 @@ func (d *DataStore) MaxBackups() int {
 	return d.maxBackups
 }
 
-func (d *DataStore) StartBatch() {
-	swapped := atomic.CompareAndSwapInt32(&d.batchMode, 0, 1)
-	if !swapped {
-		log.Fatal(fmt.Errorf("StartBatch() in batch mode"))
+// Update runs fn in a transaction. The changes made by fn are persisted at once when it returns nil.
+// If fn returns an error, then all changes are discarded and the data is read again from disk.
+func (d *DataStore) Update(fn func(tx model.Store) error) (err error) {
+	if !atomic.CompareAndSwapInt32(&d.batchMode, 0, 1) {
+		return ErrNestedTransaction
 	}
-}
+	defer d.notify()
+
+	done := false
+	defer func() {
+		if done {
+			return
+		}
+
+		d.mu.Lock()
+		defer d.mu.Unlock()
 
-func (d *DataStore) StopBatch() {
-	swapped := atomic.CompareAndSwapInt32(&d.batchMode, 1, 0)
-	if !swapped {
-		log.Fatal(fmt.Errorf("StopBatch() called without prior StartBatch()"))
+		// the data on disk wasn't modified by the transaction
+		atomic.StoreInt32(&d.batchMode, 0)
+		d.pendingEntries = nil
+		d.stagedFiles = nil
+		d.events.discard()
+		if loadErr := d.loadLocked(); loadErr != nil && err == nil {
+			err = loadErr
+		}
+	}()
+
+	if err = fn(d); err != nil {
+		return err
 	}
 
-	_ = d.save()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	done = true
+	atomic.StoreInt32(&d.batchMode, 0)
+	if err = d.commitLocked(); err != nil {
+		d.pendingEntries = nil
+		d.stagedFiles = nil
+		d.events.discard()
+		_ = d.loadLocked()
+		return err
+	}
+	return nil
 }
 
 func (d *DataStore) sortProjects() {
@@ -99,6 +207,12 @@ func (d *DataStore) sortTags() {
 	})
 }
 
+func (d *DataStore) sortClients() {
+	sort.SliceStable(d.clients, func(i, j int) bool {
+		return strings.Compare(d.clients[i].Name, d.clients[j].Name) < 0
+	})
+}
+
 func (d *DataStore) sortFrames() {
 	sort.SliceStable(d.frames, func(i, j int) bool {
 		return d.frames[i].IsBefore(d.frames[j])
@@ -112,12 +226,56 @@ func (d *DataStore) load() error {
 	return d.loadLocked()
 }
 
+// loadLocked reads the data files while holding a shared lock of the data directory
 func (d *DataStore) loadLocked() error {
+	unlock, err := d.lock.lockShared()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return d.readLocked()
+}
+
+func (d *DataStore) readLocked() error {
 	var data []byte
 	var err error
 
+	d.projects = nil
+	d.tags = nil
+	d.clients = nil
+	d.frames = nil
+	d.index = newQueryIndex(nil)
+
+	// the years, which were already in memory, are read again
+	previousYears := d.loadedYears
+	d.loadedYears = map[int]bool{}
+	d.dirtyYears = map[int]bool{}
+	d.frameYears = map[string]int{}
+	d.frameIndex = d.readFrameIndexLocked()
+	d.frameVersions = map[string]*time.Time{}
+
+	if d.version, d.versionStored, err = d.readVersionLocked(); err != nil {
+		return err
+	}
+	if err = checkVersion(d.version); err != nil {
+		return err
+	}
+	if d.version < FormatVersion {
+		if err = d.readMigratedLocked(); err != nil {
+			return err
+		}
+		// all frames are written into the files of the new data format
+		d.index = newQueryIndex(d.frames)
+		d.resetYearsLocked()
+		d.updateInternalsLocked()
+		d.fileStates = d.statDataFiles()
+		d.frameVersions = frameVersions(d.frames)
+		return nil
+	}
+
 	if fileExists(d.ProjectFile) {
-		if data, err = ioutil.ReadFile(d.ProjectFile); err != nil {
+		if data, err = d.cipher.readFile(d.ProjectFile); err != nil {
 			return err
 		}
 		if err = json.Unmarshal(data, &d.projects); err != nil {
@@ -126,7 +284,7 @@ func (d *DataStore) loadLocked() error {
 	}
 
 	if fileExists(d.TagFile) {
-		if data, err = ioutil.ReadFile(d.TagFile); err != nil {
+		if data, err = d.cipher.readFile(d.TagFile); err != nil {
 			return err
 		}
 		if err = json.Unmarshal(data, &d.tags); err != nil {
@@ -134,42 +292,52 @@ func (d *DataStore) loadLocked() error {
 		}
 	}
 
-	if fileExists(d.FrameFile) {
-		if data, err = ioutil.ReadFile(d.FrameFile); err != nil {
+	if fileExists(d.ClientFile) {
+		if data, err = d.cipher.readFile(d.ClientFile); err != nil {
 			return err
 		}
-		if err = json.Unmarshal(data, &d.frames); err != nil {
+		if err = json.Unmarshal(data, &d.clients); err != nil {
 			return err
 		}
 	}
 
-	// update internal data
+	years := d.openYearsLocked()
+	for year := range previousYears {
+		years = append(years, year)
+	}
+	if err = d.readYearsLocked(years...); err != nil {
+		return err
+	}
+
+	// changes, which were not yet compacted into the data files
+	if err = d.replayJournalLocked(); err != nil {
+		return err
+	}
+
+	d.updateInternalsLocked()
+	d.fileStates = d.statDataFiles()
+	d.frameVersions = frameVersions(d.frames)
+	return nil
+}
+
+func (d *DataStore) updateInternalsLocked() {
 	d.updateProjectsMapping()
 	for _, p := range d.projects {
 		d.updateProjectInternals(p)
 	}
 	d.sortProjects()
 	d.sortTags()
+	d.sortClients()
 	d.sortFrames()
-
-	return nil
-}
-
-func (d *DataStore) save() error {
-	d.mu.Lock()
-	defer d.mu.Unlock()
-	return d.saveLocked()
+	d.events.setValues(d.projects, d.tags, d.clients, d.frames, true)
 }
 
+// saveLocked writes all data files and removes the journal.
+// The exclusive lock of the data directory must be held by the caller.
 func (d *DataStore) saveLocked() error {
-	if atomic.LoadInt32(&d.batchMode) == 1 {
-		return nil
-	}
-
-	d.backupLocked()
-
 	d.sortProjects()
 	d.sortTags()
+	d.sortClients()
 	d.sortFrames()
 
 	var data []byte
@@ -179,7 +347,7 @@ func (d *DataStore) saveLocked() error {
 	if data, err = json.Marshal(d.projects); err != nil {
 		return err
 	}
-	if err := ioutil.WriteFile(d.ProjectFile, data, 0600); err != nil {
+	if err := d.cipher.writeFile(d.ProjectFile, data); err != nil {
 		return err
 	}
 
@@ -187,41 +355,197 @@ func (d *DataStore) saveLocked() error {
 	if data, err = json.Marshal(d.tags); err != nil {
 		return err
 	}
-	if err := ioutil.WriteFile(d.TagFile, data, 0600); err != nil {
+	if err := d.cipher.writeFile(d.TagFile, data); err != nil {
+		return err
+	}
+
+	// clients
+	if data, err = json.Marshal(d.clients); err != nil {
+		return err
+	}
+	if err := d.cipher.writeFile(d.ClientFile, data); err != nil {
 		return err
 	}
 
 	// frames
-	if data, err = json.Marshal(d.frames); err != nil {
+	if err := d.writeShardsLocked(); err != nil {
+		return err
+	}
+
+	// the data files now contain all changes of the journal
+	d.journalSize = 0
+	if err := removeJournal(d.JournalFile); err != nil {
+		return err
+	}
+
+	// the version is updated after the data files to never mark data of an old version as up-to-date
+	if err := d.writeVersionLocked(); err != nil {
+		return err
+	}
+
+	d.fileStates = d.statDataFiles()
+	d.frameVersions = frameVersions(d.frames)
+	return nil
+}
+
+// Subscribe adds an observer of the changes of this store
+func (d *DataStore) Subscribe(observer model.Observer) func() {
+	return d.events.subscribe(observer)
+}
+
+// notify passes the events of the persisted changes to the observers, unless a transaction is running.
+// It must be called without holding the lock of the store.
+func (d *DataStore) notify() {
+	if atomic.LoadInt32(&d.batchMode) == 0 {
+		d.events.flush()
+	}
+}
+
+// changedLocked persists a change of the data.
+// In a transaction the entries are kept until the transaction is committed.
+// The queued events are discarded if the changes couldn't be persisted.
+func (d *DataStore) changedLocked(entries ...journalEntry) error {
+	d.pendingEntries = append(d.pendingEntries, entries...)
+	if err := d.commitLocked(); err != nil {
+		d.events.discard()
+		return err
+	}
+	return nil
+}
+
+// commitLocked persists the pending entries while holding the exclusive lock of the data directory.
+// If another process modified the data files in the meantime, then the data is read again and the pending entries are applied to it.
+// In journal mode the entries are appended to the journal, which is compacted into the data files when it's grown too large.
+// Without journal the data files are written.
+func (d *DataStore) commitLocked() error {
+	if atomic.LoadInt32(&d.batchMode) == 1 {
+		return nil
+	}
+
+	unlock, err := d.lock.lockExclusive()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	entries := d.pendingEntries
+	d.pendingEntries = nil
+	if err := d.refreshLocked(entries); err != nil {
+		return err
+	}
+
+	if err := d.backupLocked(); err != nil {
+		return err
+	}
+	if err := d.writeStagedFilesLocked(); err != nil {
+		return err
+	}
+	if !d.journalEnabled {
+		return d.saveLocked()
+	}
+
+	if err := d.writeVersionLocked(); err != nil {
+		return err
+	}
+	if err := appendJournal(d.JournalFile, entries, d.cipher); err != nil {
+		return err
+	}
+	d.journalSize += len(entries)
+
+	if d.journalCompactSize > 0 && d.journalSize >= d.journalCompactSize {
+		return d.saveLocked()
+	}
+
+	d.fileStates = d.statDataFiles()
+	d.updateFrameVersionsLocked(entries)
+	return nil
+}
+
+// refreshLocked reads the data again if it was modified by another process and then applies the entries to it.
+// ErrConflict is returned if one of the entries modifies a frame, which was modified or removed by the other process.
+func (d *DataStore) refreshLocked(entries []journalEntry) error {
+	if !d.changedOnDiskLocked() {
+		return nil
+	}
+
+	baseVersions := d.frameVersions
+	if err := d.readLocked(); err != nil {
 		return err
 	}
-	if err := ioutil.WriteFile(d.FrameFile, data, 0600); err != nil {
+
+	if err := checkConflicts(entries, baseVersions, d.frameVersions); err != nil {
 		return err
 	}
 
+	for _, e := range entries {
+		if err := d.applyJournalEntryLocked(e); err != nil {
+			return err
+		}
+	}
+	d.updateInternalsLocked()
 	return nil
 }
 
+// Compact writes all data into the data files and removes the journal
+func (d *DataStore) Compact() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	unlock, err := d.lock.lockExclusive()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := d.refreshLocked(nil); err != nil {
+		return err
+	}
+	return d.saveLocked()
+}
+
 func (d *DataStore) Reset(projects, tags, frames bool) (int, int, int, error) {
+	defer d.notify()
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
+	if frames {
+		if err := d.loadAllYearsLocked(); err != nil {
+			return 0, 0, 0, err
+		}
+	}
+
 	var projectCount, tagCount, frameCount int
+	var entries []journalEntry
 
 	if projects {
 		projectCount = len(d.projects)
+		for _, p := range d.projects {
+			d.events.projectChanged(model.EventRemoved, p.ID, nil)
+		}
 		d.projects = []*model.Project{}
+		d.updateProjectsMapping()
+		entries = append(entries, newResetEntry(journalProject))
 	}
 	if tags {
 		tagCount = len(d.tags)
+		for _, t := range d.tags {
+			d.events.tagChanged(model.EventRemoved, t.ID, nil)
+		}
 		d.tags = []*model.Tag{}
+		entries = append(entries, newResetEntry(journalTag))
 	}
 	if frames {
 		frameCount = len(d.frames)
+		for _, f := range d.frames {
+			d.events.frameChanged(model.EventRemoved, f.ID, f, nil)
+		}
 		d.frames = []*model.Frame{}
+		d.index = newQueryIndex(nil)
+		d.resetYearsLocked()
+		entries = append(entries, newResetEntry(journalFrame))
 	}
 
-	return projectCount, tagCount, frameCount, d.saveLocked()
+	return projectCount, tagCount, frameCount, d.changedLocked(entries...)
 }
 
 func (d *DataStore) Projects() model.ProjectList {
@@ -243,15 +567,27 @@ func (d *DataStore) ProjectByID(id string) (*model.Project, error) {
 }
 
 func (d *DataStore) AddProject(project model.Project) (*model.Project, error) {
+	project.ID = model.NextID()
+	return d.addProject(project)
+}
+
+// addProject adds the project with its ID, it's used to restore a removed project
+func (d *DataStore) addProject(project model.Project) (*model.Project, error) {
+	defer d.notify()
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
-	project.ID = model.NextID()
 	d.updateProjectInternals(&project)
 	d.projects = append(d.projects, &project)
 	d.updateProjectsMapping()
+	d.sortProjects()
 
-	return &project, d.saveLocked()
+	entry, err := newPutEntry(journalProject, project.ID, project)
+	if err != nil {
+		return nil, err
+	}
+	d.events.projectChanged(model.EventAdded, project.ID, &project)
+	return &project, d.changedLocked(entry)
 }
 
 func (d *DataStore) updateProjectInternals(p *model.Project) {
@@ -264,16 +600,18 @@ func (d *DataStore) updateProjectInternals(p *model.Project) {
 
 	parents := []string{p.Name}
 
+	// missing and cyclic parents are reported by "tom check"
+	visited := map[string]bool{p.ID: true}
 	id := p.ParentID
-	for id != "" {
+	for id != "" && !visited[id] {
 		parent, err := d.findFirstProjectLocked(func(current *model.Project) bool {
 			return current.ID == id
 		})
-
 		if err != nil {
-			log.Fatal(fmt.Errorf("unable to find project %s", id))
+			break
 		}
 
+		visited[id] = true
 		id = parent.ParentID
 		parents = append([]string{parent.Name}, parents...)
 	}
@@ -290,12 +628,28 @@ func (d *DataStore) UpdateProject(project model.Project) (*model.Project, error)
 	if err != nil {
 		return nil, err
 	}
+
+	defer d.notify()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
 	*existing = project
-	d.updateProjectInternals(existing)
-	return existing, d.save()
+	// the full names of the subprojects depend on this project
+	for _, p := range d.projects {
+		d.updateProjectInternals(p)
+	}
+	d.sortProjects()
+
+	entry, err := newPutEntry(journalProject, project.ID, project)
+	if err != nil {
+		return nil, err
+	}
+	d.events.projectChanged(model.EventUpdated, existing.ID, existing)
+	return existing, d.changedLocked(entry)
 }
 
 func (d *DataStore) RemoveProject(id string) error {
+	defer d.notify()
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
@@ -303,7 +657,8 @@ func (d *DataStore) RemoveProject(id string) error {
 		if p.ID == id {
 			d.projects = append(d.projects[:i], d.projects[i+1:]...)
 			d.updateProjectsMapping()
-			return d.saveLocked()
+			d.events.projectChanged(model.EventRemoved, p.ID, nil)
+			return d.changedLocked(newRemoveEntry(journalProject, id))
 		}
 	}
 
@@ -347,7 +702,8 @@ func (d *DataStore) ProjectIsSameOrChild(parentID, id string) bool {
 	d.mu.RLock()
 	defer d.mu.RUnlock()
 
-	for id != "" {
+	visited := map[string]bool{}
+	for id != "" && !visited[id] {
 		if id == parentID {
 			return true
 		}
@@ -356,6 +712,7 @@ func (d *DataStore) ProjectIsSameOrChild(parentID, id string) bool {
 		if !ok {
 			return false
 		}
+		visited[id] = true
 		id = project.ParentID
 	}
 	return false
@@ -369,16 +726,29 @@ func (d *DataStore) Tags() []*model.Tag {
 }
 
 func (d *DataStore) AddTag(tag model.Tag) (*model.Tag, error) {
+	tag.ID = model.NextID()
+	return d.addTag(tag)
+}
+
+// addTag adds the tag with its ID, it's used to restore a removed tag
+func (d *DataStore) addTag(tag model.Tag) (*model.Tag, error) {
 	if err := tag.Validate(); err != nil {
 		return nil, err
 	}
 
+	defer d.notify()
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
-	tag.ID = model.NextID()
 	d.tags = append(d.tags, &tag)
-	return &tag, d.saveLocked()
+	d.sortTags()
+
+	entry, err := newPutEntry(journalTag, tag.ID, tag)
+	if err != nil {
+		return nil, err
+	}
+	d.events.tagChanged(model.EventAdded, tag.ID, &tag)
+	return &tag, d.changedLocked(entry)
 }
 
 func (d *DataStore) UpdateTag(tag model.Tag) (*model.Tag, error) {
@@ -391,18 +761,31 @@ func (d *DataStore) UpdateTag(tag model.Tag) (*model.Tag, error) {
 		return nil, err
 	}
 
+	defer d.notify()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
 	*existing = tag
-	return existing, d.saveLocked()
+	d.sortTags()
+
+	entry, err := newPutEntry(journalTag, tag.ID, tag)
+	if err != nil {
+		return nil, err
+	}
+	d.events.tagChanged(model.EventUpdated, existing.ID, existing)
+	return existing, d.changedLocked(entry)
 }
 
 func (d *DataStore) RemoveTag(id string) error {
+	defer d.notify()
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
 	for i, t := range d.tags {
 		if t.ID == id {
 			d.tags = append(d.tags[:i], d.tags[i+1:]...)
-			return nil
+			d.events.tagChanged(model.EventRemoved, t.ID, nil)
+			return d.changedLocked(newRemoveEntry(journalTag, id))
 		}
 	}
 	return fmt.Errorf("tag %s not found", id)
@@ -445,24 +828,162 @@ func (d *DataStore) FindTags(filter func(*model.Tag) bool) []*model.Tag {
 	return result
 }
 
-func (d *DataStore) Frames() model.FrameList {
+func (d *DataStore) Clients() []*model.Client {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.clients
+}
+
+func (d *DataStore) AddClient(client model.Client) (*model.Client, error) {
+	if err := client.Validate(); err != nil {
+		return nil, err
+	}
+
+	defer d.notify()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	client.ID = model.NextID()
+	d.clients = append(d.clients, &client)
+	d.sortClients()
+
+	entry, err := newPutEntry(journalClient, client.ID, client)
+	if err != nil {
+		return nil, err
+	}
+	d.events.clientChanged(model.EventAdded, client.ID, &client)
+	return &client, d.changedLocked(entry)
+}
+
+func (d *DataStore) UpdateClient(client model.Client) (*model.Client, error) {
+	if err := client.Validate(); err != nil {
+		return nil, err
+	}
+
+	existing, err := d.FindFirstClient(func(c *model.Client) bool {
+		return c.ID == client.ID
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	defer d.notify()
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
+	*existing = client
+	d.sortClients()
+
+	entry, err := newPutEntry(journalClient, client.ID, client)
+	if err != nil {
+		return nil, err
+	}
+	d.events.clientChanged(model.EventUpdated, existing.ID, existing)
+	return existing, d.changedLocked(entry)
+}
+
+func (d *DataStore) RemoveClient(id string) error {
+	defer d.notify()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for i, c := range d.clients {
+		if c.ID == id {
+			d.clients = append(d.clients[:i], d.clients[i+1:]...)
+			d.events.clientChanged(model.EventRemoved, c.ID, nil)
+			return d.changedLocked(newRemoveEntry(journalClient, id))
+		}
+	}
+	return fmt.Errorf("client %s not found", id)
+}
+
+func (d *DataStore) FindFirstClient(filter func(*model.Client) bool) (*model.Client, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	for _, client := range d.clients {
+		if filter(client) {
+			return client, nil
+		}
+	}
+	return nil, ErrClientNotFound
+}
+
+func (d *DataStore) FindClients(filter func(*model.Client) bool) []*model.Client {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var result []*model.Client
+	for _, client := range d.clients {
+		if filter(client) {
+			result = append(result, client)
+		}
+	}
+	return result
+}
+
+func (d *DataStore) Frames() model.FrameList {
+	d.tryLoadYears(d.shardYears)
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
 	return d.frames
 }
 
+// FramesInRange returns the frames intersecting the range. Only the years needed for the range are read.
+// A nil value of start or end is unbounded. Active frames are treated as frames without end.
+func (d *DataStore) FramesInRange(start, end *time.Time) model.FrameList {
+	d.tryLoadYears(func() []int {
+		return d.yearsInRangeLocked(start, end)
+	})
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.index.inRange(start, end)
+}
+
 func (d *DataStore) AddFrame(frame model.Frame) (*model.Frame, error) {
+	frame.ID = model.NextID()
+	return d.addFrame(frame)
+}
+
+// addFrame adds the frame with its ID, it's used to restore a removed frame
+func (d *DataStore) addFrame(frame model.Frame) (*model.Frame, error) {
 	if err := frame.Validate(false); err != nil {
 		return nil, err
 	}
 
+	defer d.notify()
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
-	frame.ID = model.NextID()
+	// the file of the year is written with the new frame
+	year := frameYear(&frame)
+	if err := d.loadYearsLocked(year); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if frame.Updated == nil {
+		frame.Updated = &now
+	}
+	if frame.Created == nil {
+		frame.Created = &now
+		frame.CreatedBy = d.command
+	}
 	d.frames = append(d.frames, &frame)
-	return &frame, d.saveLocked()
+	d.sortFrames()
+	d.index.add(&frame)
+	d.frameYears[frame.ID] = year
+	d.dirtyYears[year] = true
+
+	entry, err := newFramePutEntry(year, frame)
+	if err != nil {
+		return nil, err
+	}
+	d.events.frameChanged(model.EventAdded, frame.ID, nil, &frame)
+	return &frame, d.changedLocked(entry)
 }
 
 func (d *DataStore) UpdateFrame(frame model.Frame) (*model.Frame, error) {
@@ -470,35 +991,131 @@ func (d *DataStore) UpdateFrame(frame model.Frame) (*model.Frame, error) {
 		return nil, err
 	}
 
+	defer d.notify()
 	d.mu.Lock()
 	defer d.mu.Unlock()
-	for _, f := range d.frames {
-		if f.ID == frame.ID {
-			*f = frame
-			return f, d.saveLocked()
-		}
+
+	// the frame may move into another year
+	if err := d.loadYearsLocked(frameYear(&frame)); err != nil {
+		return nil, err
+	}
+	f, err := d.findFrameLocked(frame.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	year := d.frameYears[f.ID]
+	// the new version is used to detect conflicting updates of other processes
+	now := time.Now()
+	frame.Updated = &now
+	// f may have been modified in place by the caller, the copy of the stored value has the old values
+	frame.RecordChange(d.events.frameValue(f), now, d.command)
+	*f = frame
+	d.sortFrames()
+	d.index.update(f)
+	d.frameYears[f.ID] = frameYear(f)
+	d.dirtyYears[year] = true
+	d.dirtyYears[frameYear(f)] = true
+
+	entry, err := newFramePutEntry(year, frame)
+	if err != nil {
+		return nil, err
 	}
-	return nil, fmt.Errorf("no frame with ID %s found", frame.ID)
+	d.events.frameChanged(model.EventUpdated, f.ID, nil, f)
+	return f, d.changedLocked(entry)
 }
 
 func (d *DataStore) RemoveFrame(id string) error {
+	defer d.notify()
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
-	for i, frame := range d.frames {
-		if frame.ID == id {
+	frame, err := d.findFrameLocked(id)
+	if err != nil {
+		return err
+	}
+
+	for i, f := range d.frames {
+		if f == frame {
 			d.frames = append(d.frames[:i], d.frames[i+1:]...)
-			return d.saveLocked()
+			break
 		}
 	}
-	return fmt.Errorf("frame %s not found", id)
+	d.index.remove(id)
+	year := d.frameYears[id]
+	delete(d.frameYears, id)
+	d.dirtyYears[year] = true
+	d.events.frameChanged(model.EventRemoved, frame.ID, nil, nil)
+	return d.changedLocked(newFrameRemoveEntry(year, id))
 }
 
-func (d *DataStore) FindFirstFrame(filter func(*model.Frame) bool) (*model.Frame, error) {
+// findFrameLocked returns the frame with the given ID. All years are read if it's not in memory.
+func (d *DataStore) findFrameLocked(id string) (*model.Frame, error) {
+	for _, all := range []bool{false, true} {
+		if all {
+			if err := d.loadAllYearsLocked(); err != nil {
+				return nil, err
+			}
+		}
+
+		if v, ok := d.index.values[id]; ok {
+			return v.frame, nil
+		}
+	}
+	return nil, fmt.Errorf("frame %s not found", id)
+}
+
+func (d *DataStore) FramesByProject(projectIDs ...string) model.FrameList {
+	d.tryLoadYears(d.shardYears)
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.index.byProjects(projectIDs...)
+}
+
+// FramesByTag returns the frames, which have at least one of the tags
+func (d *DataStore) FramesByTag(tagIDs ...string) model.FrameList {
+	d.tryLoadYears(d.shardYears)
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.index.byTags(tagIDs...)
+}
+
+// ActiveFrames returns the active frames. The years with active frames are always in memory.
+func (d *DataStore) ActiveFrames() model.FrameList {
 	d.mu.RLock()
 	defer d.mu.RUnlock()
 
-	for _, frame := range d.frames {
+	return d.index.activeFrames()
+}
+
+// FrameByID returns the frame with the given ID. All years are only read if it's not in memory.
+func (d *DataStore) FrameByID(id string) (*model.Frame, error) {
+	d.mu.RLock()
+	v, ok := d.index.values[id]
+	d.mu.RUnlock()
+	if ok {
+		return v.frame, nil
+	}
+
+	if err := d.loadYears(d.shardYears); err != nil {
+		return nil, err
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if v, ok := d.index.values[id]; ok {
+		return v.frame, nil
+	}
+	return nil, fmt.Errorf("frame %s not found", id)
+}
+
+func (d *DataStore) FindFirstFrame(filter func(*model.Frame) bool) (*model.Frame, error) {
+	d.tryLoadYears(d.shardYears)
+
+	// the filter may query the store, the frames are iterated without holding the lock
+	for _, frame := range d.copyFrames() {
 		if filter(frame) {
 			return frame, nil
 		}
@@ -507,11 +1124,12 @@ func (d *DataStore) FindFirstFrame(filter func(*model.Frame) bool) (*model.Frame
 }
 
 func (d *DataStore) FindFrames(filter func(*model.Frame) (bool, error)) ([]*model.Frame, error) {
-	d.mu.RLock()
-	defer d.mu.RUnlock()
+	if err := d.loadYears(d.shardYears); err != nil {
+		return nil, err
+	}
 
 	var result []*model.Frame
-	for _, frame := range d.frames {
+	for _, frame := range d.copyFrames() {
 		if ok, err := filter(frame); err != nil {
 			return nil, err
 		} else if ok {
@@ -521,6 +1139,13 @@ func (d *DataStore) FindFrames(filter func(*model.Frame) (bool, error)) ([]*mode
 	return result, nil
 }
 
+func (d *DataStore) copyFrames() []*model.Frame {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return append([]*model.Frame(nil), d.frames...)
+}
+
 func (d *DataStore) updateProjectsMapping() {
 	d.projectsMap = map[string]*model.Project{}
 	for _, p := range d.projects {
@@ -528,54 +1153,118 @@ func (d *DataStore) updateProjectsMapping() {
 	}
 }
 
+// backupLocked creates a backup of the data files before they're modified for the first time by the session.
+// Undo reverts all changes of a session at once, the following commits of the session don't need a backup.
+// Changes reverted by Undo can't be redone after the data was modified again.
 func (d *DataStore) backupLocked() error {
-	if d.backupPath == "" || d.Empty() {
+	if d.backupPath == "" || d.backedUp {
 		return nil
 	}
 
-	targetDir := filepath.Join(d.backupPath, fmt.Sprintf("tom-%s", time.Now().Format(time.RFC3339Nano)))
-	err := os.MkdirAll(targetDir, 0700)
-	if os.IsExist(err) {
-		return fmt.Errorf("backup directory already exists: %s", err.Error())
+	if _, err := d.createSnapshotLocked(d.backupPath, BackupInfo{Command: d.command, Session: d.session}); err != nil {
+		return err
+	}
+	d.backedUp = true
+	_ = os.RemoveAll(filepath.Join(d.backupPath, redoDirName))
+
+	removeOldBackups(d.backupPath, d.maxBackups, d.cipher)
+	return nil
+}
+
+// stageFile writes the file of the data directory with the next commit, it's encrypted with the cipher of the store.
+// The backup of the commit contains the previous version of the file.
+func (d *DataStore) stageFile(name string, data []byte) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.stagedFiles == nil {
+		d.stagedFiles = map[string][]byte{}
 	}
+	d.stagedFiles[name] = data
+}
 
-	for _, sourceFile := range []string{d.ProjectFile, d.FrameFile, d.TagFile} {
-		if err = util.CopyFile(sourceFile, filepath.Join(targetDir, filepath.Base(sourceFile)), false); err != nil {
-			// fixme cleanup?
+func (d *DataStore) writeStagedFilesLocked() error {
+	files := d.stagedFiles
+	d.stagedFiles = nil
+	for name, data := range files {
+		path := filepath.Join(d.path, name)
+		if data == nil {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		} else if err := d.cipher.writeFile(path, data); err != nil {
 			return err
 		}
 	}
+	return nil
+}
 
-	// remove backups > max backups
-	if d.maxBackups > 0 {
-		infos, err := ioutil.ReadDir(d.backupPath)
-		if err == nil && len(infos) > d.maxBackups {
-			// filter and validate
-			var matching []string
-			for _, info := range infos {
-				baseName := info.Name()
-				if info.IsDir() && strings.HasPrefix(baseName, "tom-") {
-					matching = append(matching, filepath.Join(d.backupPath, baseName))
-				}
-			}
+// lockExclusive acquires the exclusive lock of the data directory for an operation of several steps, e.g. Undo.
+// Other processes can't read or modify the data until the returned function is called.
+func (d *DataStore) lockExclusive() (func(), error) {
+	return d.lock.holdExclusive()
+}
 
-			// remove oldest backups first
-			if len(matching) > d.maxBackups {
-				// sort newest to oldest
-				sort.Slice(matching, func(i, j int) bool {
-					return strings.Compare(matching[i], matching[j]) >= 0
-				})
+func (d *DataStore) createSnapshot(parentDir string, info BackupInfo) (string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
 
-				for _, dirName := range matching[d.maxBackups:] {
-					_ = os.RemoveAll(dirName)
-				}
-			}
-		}
+	unlock, err := d.lock.lockShared()
+	if err != nil {
+		return "", err
 	}
+	defer unlock()
 
-	return nil
+	return d.createSnapshotLocked(parentDir, info)
+}
+
+func (d *DataStore) createSnapshotLocked(parentDir string, info BackupInfo) (string, error) {
+	info.Created = time.Now()
+	info.Origin = backupOrigin(d.path)
+	targetDir, err := newBackupDir(parentDir, info.Created)
+	if err != nil {
+		return "", err
+	}
+
+	// the data files are replaced when they're written, but the journal is modified in place
+	files := append([]string{d.ProjectFile, d.FrameFile, d.FrameIndexFile, d.TagFile, d.ClientFile, d.VersionFile, d.EncryptionFile}, d.shardFiles()...)
+	files = append(files, dataFilePaths(d.path)...)
+	if err := copyFiles(files, targetDir, true); err != nil {
+		return "", err
+	}
+	if err := copyFiles([]string{d.JournalFile}, targetDir, false); err != nil {
+		return "", err
+	}
+	return targetDir, writeBackupInfo(targetDir, info, d.cipher)
 }
 
 func (d *DataStore) Empty() bool {
-	return len(d.projects) == 0 && len(d.tags) == 0 && len(d.frames) == 0
+	return len(d.projects) == 0 && len(d.tags) == 0 && len(d.clients) == 0 && len(d.frames) == 0 && len(d.shardYears()) == 0
+}
+
+func (d *DataStore) replaceAll(projects []*model.Project, tags []*model.Tag, clients []*model.Client, frames []*model.Frame, backup bool) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	unlock, err := d.lock.lockExclusive()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if backup {
+		if err := d.backupLocked(); err != nil {
+			return err
+		}
+	}
+
+	d.projects = projects
+	d.tags = tags
+	d.clients = clients
+	d.frames = frames
+	d.index = newQueryIndex(frames)
+	d.resetYearsLocked()
+
+	d.updateInternalsLocked()
+	return d.saveLocked()
 }
@@ -102,7 +102,7 @@ func Test_Store(t *testing.T) {
 	dataStore := s.(*store.DataStore)
 	assert.FileExists(t, dataStore.ProjectFile)
 	assert.FileExists(t, dataStore.TagFile)
-	assert.FileExists(t, dataStore.FrameFile)
+	assert.FileExists(t, dataStore.FrameIndexFile)
 
 	// several backups have to exist at this points
 	files, err := ioutil.ReadDir(backupDir)
@@ -121,7 +121,10 @@ func TestBackups(t *testing.T) {
 	require.EqualValues(t, 0, countBackups(ctx.Store.BackupDirPath()))
 
 	for i := 1; i <= 20; i++ {
-		_, _, err := ctx.StoreHelper.GetOrCreateNestedProjectNames(fmt.Sprintf("project-%d", i))
+		// each command uses a new store, the backups of a command are removed together
+		s, err := store.NewStore(ctx.Store.DirPath(), ctx.Store.BackupDirPath(), ctx.Store.MaxBackups())
+		require.NoError(t, err)
+		_, err = s.AddProject(model.Project{Name: fmt.Sprintf("project-%d", i)})
 		require.NoError(t, err)
 
 		if i == 1 {
@@ -139,7 +142,9 @@ func TestBackups(t *testing.T) {
 	newStore, err := store.NewStore(dirs[len(dirs)-1], "", 1)
 	require.NoError(t, err)
 
-	assert.EqualValues(t, 20, len(ctx.Store.Projects()), "expected backup to contain latest set of projects")
+	current, err := store.NewStore(ctx.Store.DirPath(), "", 1)
+	require.NoError(t, err)
+	assert.EqualValues(t, 20, len(current.Projects()), "expected backup to contain latest set of projects")
 	assert.EqualValues(t, 19, len(newStore.Projects()), "expected backup to contain latest set of projects, 1 less than the live data")
 }
 
@@ -148,20 +153,30 @@ func TestBackupsBatchMode(t *testing.T) {
 	require.NoError(t, err)
 	defer test_setup.CleanupTestContext(ctx)
 
-	ctx.Store.StartBatch()
-
-	require.EqualValues(t, 0, countBackups(ctx.Store.BackupDirPath()))
-
-	for i := 1; i <= 20; i++ {
-		_, _, err := ctx.StoreHelper.GetOrCreateNestedProjectNames(fmt.Sprintf("project-%d", i))
-		require.NoError(t, err)
+	err = ctx.Store.Update(func(tx model.Store) error {
 		require.EqualValues(t, 0, countBackups(ctx.Store.BackupDirPath()))
-	}
 
-	ctx.Store.StopBatch()
+		for i := 1; i <= 20; i++ {
+			_, _, err := ctx.StoreHelper.GetOrCreateNestedProjectNames(fmt.Sprintf("project-%d", i))
+			require.NoError(t, err)
+			require.EqualValues(t, 0, countBackups(ctx.Store.BackupDirPath()))
+		}
+		return nil
+	})
+	require.NoError(t, err)
 	require.EqualValues(t, 1, countBackups(ctx.Store.BackupDirPath()))
 
+	// the following changes of the command don't create another backup
 	_, _, err = ctx.StoreHelper.GetOrCreateNestedProjectNames(fmt.Sprintf("project-%d", 21))
+	require.NoError(t, err)
+	require.EqualValues(t, 1, countBackups(ctx.Store.BackupDirPath()))
+
+	// the next command creates a backup
+	s, err := store.NewStore(ctx.Store.DirPath(), ctx.Store.BackupDirPath(), ctx.Store.MaxBackups())
+	require.NoError(t, err)
+	_, err = s.AddProject(model.Project{Name: "project-22"})
+	require.NoError(t, err)
+	require.EqualValues(t, 2, countBackups(ctx.Store.BackupDirPath()))
 
 	// open latest dir and check number of projects
 	dirs, err := sortedBackupDirs(ctx.Store.BackupDirPath())
@@ -169,8 +184,8 @@ func TestBackupsBatchMode(t *testing.T) {
 	newStore, err := store.NewStore(dirs[len(dirs)-1], "", 1)
 	require.NoError(t, err)
 
-	assert.EqualValues(t, 21, len(ctx.Store.Projects()), "expected backup to contain latest set of projects")
-	assert.EqualValues(t, 20, len(newStore.Projects()), "expected backup to contain latest set of projects, 1 less than the live data")
+	assert.EqualValues(t, 22, len(s.Projects()), "expected backup to contain latest set of projects")
+	assert.EqualValues(t, 21, len(newStore.Projects()), "expected backup to contain latest set of projects, 1 less than the live data")
 }
 
 func countBackups(path string) int {
@@ -208,3 +223,72 @@ func sortedBackupDirs(path string) ([]string, error) {
 
 	return dirs, nil
 }
+
+func TestUpdateRollback(t *testing.T) {
+	for _, options := range []store.Options{{Format: store.FormatJSON}, {Format: store.FormatJSON, Journal: true}, {Format: store.FormatSQLite}} {
+		t.Run(fmt.Sprintf("%s journal=%v", options.Format, options.Journal), func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "tom")
+			require.NoError(t, err)
+			defer os.RemoveAll(dir)
+
+			backupDir, err := ioutil.TempDir("", "tom-backup")
+			require.NoError(t, err)
+			defer os.RemoveAll(backupDir)
+
+			options.Dir = dir
+			options.BackupDir = backupDir
+			options.MaxBackups = 10
+			s, err := store.Open(options)
+			require.NoError(t, err)
+			defer store.CloseStore(s)
+
+			project, err := s.AddProject(model.Project{Name: "project"})
+			require.NoError(t, err)
+			backups := countBackups(backupDir)
+
+			failure := fmt.Errorf("failure")
+			err = s.Update(func(tx model.Store) error {
+				if _, err := tx.AddProject(model.Project{Name: "discarded"}); err != nil {
+					return err
+				}
+				project.Name = "renamed"
+				if _, err := tx.UpdateProject(*project); err != nil {
+					return err
+				}
+				start := time.Now()
+				if _, err := tx.AddFrame(model.Frame{ProjectId: project.ID, Start: &start}); err != nil {
+					return err
+				}
+				return failure
+			})
+			require.EqualValues(t, failure, err)
+
+			// nothing must be changed in memory or on disk
+			require.Len(t, s.Projects(), 1)
+			assert.EqualValues(t, "project", s.Projects()[0].Name)
+			assert.Empty(t, s.Frames())
+			assert.EqualValues(t, backups, countBackups(backupDir), "a discarded transaction must not leave a backup behind")
+
+			reopened, err := store.Open(options)
+			require.NoError(t, err)
+			defer store.CloseStore(reopened)
+			require.Len(t, reopened.Projects(), 1)
+			assert.EqualValues(t, "project", reopened.Projects()[0].Name)
+			assert.Empty(t, reopened.Frames())
+
+			// nested transactions aren't supported
+			err = s.Update(func(tx model.Store) error {
+				return tx.Update(func(model.Store) error { return nil })
+			})
+			assert.EqualValues(t, store.ErrNestedTransaction, err)
+
+			// the store is usable after a rollback
+			err = s.Update(func(tx model.Store) error {
+				_, err := tx.AddProject(model.Project{Name: "committed"})
+				return err
+			})
+			require.NoError(t, err)
+			assert.Len(t, s.Projects(), 2)
+		})
+	}
+}
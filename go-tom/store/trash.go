@@ -0,0 +1,760 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jansorg/tom/go-tom/model"
+)
+
+// removed data is kept in this subdirectory of the data directory
+const trashDirName = "trash"
+
+// TrashEntry is the data removed by a single command. It's kept in the trash until it's restored or until it expires.
+type TrashEntry struct {
+	ID       string           `json:"id"`
+	Removed  time.Time        `json:"removed"`
+	Projects []*model.Project `json:"projects,omitempty"`
+	Tags     []*model.Tag     `json:"tags,omitempty"`
+	Frames   []*model.Frame   `json:"frames,omitempty"`
+	// TaggedFrames maps the IDs of the removed tags to the IDs of the frames, which referenced them
+	TaggedFrames map[string][]string `json:"taggedFrames,omitempty"`
+	// ProjectNames maps the IDs of the removed projects and of the projects of the removed frames to their full names
+	ProjectNames map[string]string `json:"projectNames,omitempty"`
+}
+
+// Empty returns if the entry doesn't contain any data
+func (e TrashEntry) Empty() bool {
+	return len(e.Projects) == 0 && len(e.Tags) == 0 && len(e.Frames) == 0
+}
+
+// Changes returns the projects, tags and frames of the entry as changes of the given type
+func (e TrashEntry) Changes(changeType string) []Change {
+	var changes []Change
+	for _, p := range e.Projects {
+		changes = append(changes, Change{Type: changeType, Entity: journalProject, ID: p.ID, Name: e.projectName(p.ID)})
+	}
+	for _, t := range e.Tags {
+		changes = append(changes, Change{Type: changeType, Entity: journalTag, ID: t.ID, Name: t.Name})
+	}
+	for _, f := range e.Frames {
+		changes = append(changes, Change{Type: changeType, Entity: journalFrame, ID: f.ID, Name: e.projectName(f.ProjectId)})
+	}
+	return changes
+}
+
+func (e TrashEntry) projectName(id string) string {
+	if name, ok := e.ProjectNames[id]; ok {
+		return name
+	}
+	return id
+}
+
+// NewTrashEntry returns the entry to move the given projects, tags and frames of s into the trash
+func NewTrashEntry(s model.Store, projects []*model.Project, tags []*model.Tag, frames []*model.Frame) TrashEntry {
+	entry := TrashEntry{
+		ID:           model.NextID(),
+		Removed:      time.Now(),
+		ProjectNames: map[string]string{},
+	}
+
+	for _, p := range projects {
+		entry.Projects = append(entry.Projects, copyProject(p))
+		entry.ProjectNames[p.ID] = projectName(s, p.ID)
+	}
+	for _, t := range tags {
+		tag := *t
+		entry.Tags = append(entry.Tags, &tag)
+	}
+	for _, f := range frames {
+		entry.Frames = append(entry.Frames, copyFrame(f))
+		entry.ProjectNames[f.ProjectId] = projectName(s, f.ProjectId)
+	}
+
+	if len(tags) > 0 {
+		entry.TaggedFrames = map[string][]string{}
+		tagIDs := make([]string, len(tags))
+		for i, t := range tags {
+			tagIDs[i] = t.ID
+		}
+		for _, f := range s.FramesByTag(tagIDs...) {
+			for _, t := range tags {
+				if f.HasTag(t) {
+					entry.TaggedFrames[t.ID] = append(entry.TaggedFrames[t.ID], f.ID)
+				}
+			}
+		}
+	}
+	return entry
+}
+
+func copyProject(p *model.Project) *model.Project {
+	project := *p
+	project.Store = nil
+	project.FullName = nil
+	return &project
+}
+
+func copyFrame(f *model.Frame) *model.Frame {
+	frame := *f
+	frame.TagIDs = append([]string(nil), f.TagIDs...)
+	frame.Fields = nil
+	frame.SetFields(f.Fields)
+	return &frame
+}
+
+// MoveToTrash writes the entry into the trash and removes its data from s.
+// The entry is written first, the data isn't lost if the store fails to remove it.
+func MoveToTrash(s model.Store, entry TrashEntry) error {
+	dir, err := trashDir(s)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	path := filepath.Join(dir, entry.ID+".json")
+	if err := writeTrashEntry(s, path, entry); err != nil {
+		return err
+	}
+
+	err = s.Update(func(tx model.Store) error {
+		// removing everything at once is a lot faster than removing the values one by one
+		var projectIDs, tagIDs, frameIDs []string
+		for _, p := range entry.Projects {
+			projectIDs = append(projectIDs, p.ID)
+		}
+		for _, t := range entry.Tags {
+			tagIDs = append(tagIDs, t.ID)
+		}
+		for _, f := range entry.Frames {
+			frameIDs = append(frameIDs, f.ID)
+		}
+		resetProjects := removesAll(projectIDs, projectIDSet(tx))
+		resetTags := removesAll(tagIDs, tagIDSet(tx))
+		resetFrames := removesAll(frameIDs, frameIDSet(tx))
+		if resetProjects || resetTags || resetFrames {
+			if _, _, _, err := tx.Reset(resetProjects, resetTags, resetFrames); err != nil {
+				return err
+			}
+		}
+
+		if !resetFrames {
+			for _, f := range entry.Frames {
+				if err := tx.RemoveFrame(f.ID); err != nil {
+					return err
+				}
+			}
+		}
+		if !resetProjects {
+			for _, p := range entry.Projects {
+				if err := tx.RemoveProject(p.ID); err != nil {
+					return err
+				}
+			}
+		}
+		if !resetTags {
+			for _, t := range entry.Tags {
+				if err := tx.RemoveTag(t.ID); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		_ = os.Remove(path)
+	}
+	return err
+}
+
+// removesAll returns if ids are the IDs of all values of a store, stored contains the IDs of the values of the store
+func removesAll(ids []string, stored map[string]bool) bool {
+	if len(ids) == 0 || len(ids) != len(stored) {
+		return false
+	}
+	for _, id := range ids {
+		if !stored[id] {
+			return false
+		}
+	}
+	return true
+}
+
+// projectIDSet, tagIDSet and frameIDSet return the IDs of the values of s.
+// Looking up many IDs in the set is a lot faster than querying the store for each ID.
+func projectIDSet(s model.Store) map[string]bool {
+	result := map[string]bool{}
+	for _, p := range s.Projects() {
+		result[p.ID] = true
+	}
+	return result
+}
+
+func tagIDSet(s model.Store) map[string]bool {
+	result := map[string]bool{}
+	for _, t := range s.Tags() {
+		result[t.ID] = true
+	}
+	return result
+}
+
+func frameIDSet(s model.Store) map[string]bool {
+	result := map[string]bool{}
+	for _, f := range s.Frames() {
+		result[f.ID] = true
+	}
+	return result
+}
+
+// ListTrash returns the entries of the trash of s, sorted from oldest to newest
+func ListTrash(s model.Store) ([]TrashEntry, error) {
+	entries, _, err := readTrash(s)
+	return entries, err
+}
+
+// readTrash returns the entries of the trash and the paths of their files
+func readTrash(s model.Store) ([]TrashEntry, map[string]string, error) {
+	dir, err := trashDir(s)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil, nil
+	} else if err != nil {
+		return nil, nil, err
+	}
+
+	var entries []TrashEntry
+	paths := map[string]string{}
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+
+		path := filepath.Join(dir, file.Name())
+		data, err := storeCipher(s).readFile(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		var entry TrashEntry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			return nil, nil, fmt.Errorf("unable to read trash file %s: %s", path, err.Error())
+		}
+		entries = append(entries, entry)
+		paths[entry.ID] = path
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Removed.Before(entries[j].Removed)
+	})
+	return entries, paths, nil
+}
+
+// RestoreFromTrash restores trash entries and single projects, tags or frames of the trash, identified by ID.
+// The parent projects of restored projects, the projects and tags of restored frames and the tags of restored frames are restored as well.
+// Values, which exist in s, e.g. after an undo of the removal, are left untouched.
+// The IDs of the restored values are kept.
+func RestoreFromTrash(s model.Store, ids []string) ([]Change, error) {
+	entries, paths, err := readTrash(s)
+	if err != nil {
+		return nil, err
+	}
+
+	r := newTrashRestore(s, entries)
+	for _, id := range ids {
+		if err := r.add(id); err != nil {
+			return nil, err
+		}
+	}
+	if err := r.resolve(); err != nil {
+		return nil, err
+	}
+
+	changes := r.changes()
+	if len(changes) > 0 {
+		if err := r.apply(); err != nil {
+			return nil, err
+		}
+	}
+
+	// the restored values are removed from the trash
+	for _, entry := range entries {
+		remaining := r.remaining(entry)
+		if len(remaining.Projects) == len(entry.Projects) && len(remaining.Tags) == len(entry.Tags) && len(remaining.Frames) == len(entry.Frames) {
+			continue
+		}
+
+		path := paths[entry.ID]
+		if remaining.Empty() {
+			err = os.Remove(path)
+		} else {
+			err = writeTrashEntry(s, path, remaining)
+		}
+		if err != nil {
+			return changes, err
+		}
+	}
+	return changes, nil
+}
+
+// EmptyTrash removes all entries of the trash. It returns the number of removed entries.
+func EmptyTrash(s model.Store) (int, error) {
+	return removeTrashEntries(s, func(entry TrashEntry) bool {
+		return true
+	})
+}
+
+// ExpireTrash removes the entries, which were moved into the trash more than maxAge ago. It returns the number of removed entries.
+// A maxAge of 0 keeps all entries.
+func ExpireTrash(s model.Store, maxAge time.Duration) (int, error) {
+	if maxAge <= 0 {
+		return 0, nil
+	}
+
+	oldest := time.Now().Add(-maxAge)
+	return removeTrashEntries(s, func(entry TrashEntry) bool {
+		return entry.Removed.Before(oldest)
+	})
+}
+
+func removeTrashEntries(s model.Store, filter func(entry TrashEntry) bool) (int, error) {
+	entries, paths, err := readTrash(s)
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, entry := range entries {
+		if filter(entry) {
+			if err := os.Remove(paths[entry.ID]); err != nil && !os.IsNotExist(err) {
+				return removed, err
+			}
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+func trashDir(s model.Store) (string, error) {
+	if s.DirPath() == "" {
+		return "", fmt.Errorf("the store doesn't support a trash")
+	}
+	return filepath.Join(s.DirPath(), trashDirName), nil
+}
+
+func writeTrashEntry(s model.Store, path string, entry TrashEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return storeCipher(s).writeFile(path, data)
+}
+
+// convertTrash rewrites the files of the trash in the data directory with the cipher to
+func convertTrash(dataDir string, from, to *dataCipher) error {
+	dir := filepath.Join(dataDir, trashDirName)
+	files, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, file.Name())
+		data, err := from.readFile(path)
+		if err != nil {
+			return err
+		}
+		if err := to.writeFile(path, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// trashRestore collects the values to restore and the values they depend on
+type trashRestore struct {
+	store   model.Store
+	entries []TrashEntry
+
+	projects map[string]*model.Project
+	tags     map[string]*model.Tag
+	frames   map[string]*model.Frame
+	// tagged maps the IDs of frames to the IDs of the restored tags, which they referenced
+	tagged map[string][]string
+	// restored contains the IDs of all values, which are restored or removed from the trash
+	restored map[string]bool
+	// the IDs of the values of the store, they're read when they're needed for the first time
+	storedProjects map[string]bool
+	storedTags     map[string]bool
+	storedFrames   map[string]bool
+}
+
+func newTrashRestore(s model.Store, entries []TrashEntry) *trashRestore {
+	return &trashRestore{
+		store:    s,
+		entries:  entries,
+		projects: map[string]*model.Project{},
+		tags:     map[string]*model.Tag{},
+		frames:   map[string]*model.Frame{},
+		tagged:   map[string][]string{},
+		restored: map[string]bool{},
+	}
+}
+
+// add restores the entry or the value with the given ID
+func (r *trashRestore) add(id string) error {
+	for _, entry := range r.entries {
+		if entry.ID == id {
+			for _, p := range entry.Projects {
+				r.addProject(p)
+			}
+			for _, t := range entry.Tags {
+				r.addTag(entry, t)
+			}
+			for _, f := range entry.Frames {
+				r.addFrame(f)
+			}
+			return nil
+		}
+	}
+
+	if p := r.findProject(id); p != nil {
+		r.addProject(p)
+		return nil
+	}
+	if entry, t := r.findTag(id); t != nil {
+		r.addTag(entry, t)
+		return nil
+	}
+	if f := r.findFrame(id); f != nil {
+		r.addFrame(f)
+		return nil
+	}
+	return fmt.Errorf("%s wasn't found in the trash", id)
+}
+
+func (r *trashRestore) addProject(p *model.Project) {
+	r.restored[p.ID] = true
+	if !r.isStoredProject(p.ID) {
+		r.projects[p.ID] = copyProject(p)
+	}
+}
+
+func (r *trashRestore) addTag(entry TrashEntry, t *model.Tag) {
+	r.restored[t.ID] = true
+	if !r.isStoredTag(t.ID) {
+		tag := *t
+		r.tags[t.ID] = &tag
+	}
+	for _, frameID := range entry.TaggedFrames[t.ID] {
+		r.tagged[frameID] = append(r.tagged[frameID], t.ID)
+	}
+}
+
+func (r *trashRestore) addFrame(f *model.Frame) {
+	r.restored[f.ID] = true
+	if !r.isStoredFrame(f.ID) {
+		r.frames[f.ID] = copyFrame(f)
+	}
+}
+
+func (r *trashRestore) isStoredProject(id string) bool {
+	if r.storedProjects == nil {
+		r.storedProjects = projectIDSet(r.store)
+	}
+	return r.storedProjects[id]
+}
+
+func (r *trashRestore) isStoredTag(id string) bool {
+	if r.storedTags == nil {
+		r.storedTags = tagIDSet(r.store)
+	}
+	return r.storedTags[id]
+}
+
+func (r *trashRestore) isStoredFrame(id string) bool {
+	if r.storedFrames == nil {
+		r.storedFrames = frameIDSet(r.store)
+	}
+	return r.storedFrames[id]
+}
+
+// resolve adds the parent projects, the projects and the tags, which the restored values depend on
+func (r *trashRestore) resolve() error {
+	for changed := true; changed; {
+		changed = false
+		var parentIDs []string
+		for _, p := range r.projects {
+			if p.ParentID != "" {
+				parentIDs = append(parentIDs, p.ParentID)
+			}
+		}
+		for _, f := range r.frames {
+			parentIDs = append(parentIDs, f.ProjectId)
+		}
+
+		for _, id := range parentIDs {
+			if r.hasProject(id) {
+				continue
+			}
+			p := r.findProject(id)
+			if p == nil {
+				return fmt.Errorf("project %s wasn't found", id)
+			}
+			r.addProject(p)
+			changed = true
+		}
+	}
+
+	for _, f := range r.frames {
+		var tagIDs []string
+		for _, id := range f.TagIDs {
+			if !r.hasTag(id) {
+				if entry, t := r.findTag(id); t != nil {
+					r.addTag(entry, t)
+				} else {
+					// the tag was removed permanently
+					continue
+				}
+			}
+			tagIDs = append(tagIDs, id)
+		}
+		f.TagIDs = tagIDs
+	}
+	return nil
+}
+
+func (r *trashRestore) hasProject(id string) bool {
+	if _, ok := r.projects[id]; ok {
+		return true
+	}
+	return r.isStoredProject(id)
+}
+
+func (r *trashRestore) hasTag(id string) bool {
+	if _, ok := r.tags[id]; ok {
+		return true
+	}
+	return r.isStoredTag(id)
+}
+
+// findProject returns the most recently removed project of the trash with the given ID
+func (r *trashRestore) findProject(id string) *model.Project {
+	for i := len(r.entries) - 1; i >= 0; i-- {
+		for _, p := range r.entries[i].Projects {
+			if p.ID == id {
+				return p
+			}
+		}
+	}
+	return nil
+}
+
+func (r *trashRestore) findTag(id string) (TrashEntry, *model.Tag) {
+	for i := len(r.entries) - 1; i >= 0; i-- {
+		for _, t := range r.entries[i].Tags {
+			if t.ID == id {
+				return r.entries[i], t
+			}
+		}
+	}
+	return TrashEntry{}, nil
+}
+
+func (r *trashRestore) findFrame(id string) *model.Frame {
+	for i := len(r.entries) - 1; i >= 0; i-- {
+		for _, f := range r.entries[i].Frames {
+			if f.ID == id {
+				return f
+			}
+		}
+	}
+	return nil
+}
+
+func (r *trashRestore) newProjects() []*model.Project {
+	var result []*model.Project
+	for _, p := range r.projects {
+		result = append(result, p)
+	}
+	return result
+}
+
+func (r *trashRestore) newTags() []*model.Tag {
+	var result []*model.Tag
+	for _, t := range r.tags {
+		result = append(result, t)
+	}
+	return result
+}
+
+func (r *trashRestore) newFrames() []*model.Frame {
+	var result []*model.Frame
+	for _, f := range r.frames {
+		r.tagFrame(f)
+		result = append(result, f)
+	}
+	return result
+}
+
+// apply adds the restored values with their IDs to the store in a single transaction.
+// The frames of the store, which referenced the restored tags, reference them again.
+func (r *trashRestore) apply() error {
+	target, ok := r.store.(restorer)
+	if !ok {
+		return fmt.Errorf("the store doesn't support to restore values")
+	}
+
+	return r.store.Update(func(tx model.Store) error {
+		for _, p := range parentProjectsFirst(r.newProjects()) {
+			if _, err := target.addProject(*p); err != nil {
+				return err
+			}
+		}
+		for _, t := range r.newTags() {
+			if _, err := target.addTag(*t); err != nil {
+				return err
+			}
+		}
+
+		// the restored frames are tagged when they're added
+		tagged, err := tx.FindFrames(func(f *model.Frame) (bool, error) {
+			return len(r.tagged[f.ID]) > 0, nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, f := range tagged {
+			frame := copyFrame(f)
+			r.tagFrame(frame)
+			if _, err := tx.UpdateFrame(*frame); err != nil {
+				return err
+			}
+		}
+
+		for _, f := range r.newFrames() {
+			if _, err := target.addFrame(*f); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// parentProjectsFirst sorts the projects, so that the parent projects are added before their subprojects
+func parentProjectsFirst(projects []*model.Project) []*model.Project {
+	pending := map[string]bool{}
+	for _, p := range projects {
+		pending[p.ID] = true
+	}
+
+	var result []*model.Project
+	for len(pending) > 0 {
+		added := false
+		for _, p := range projects {
+			if pending[p.ID] && !pending[p.ParentID] {
+				result = append(result, p)
+				delete(pending, p.ID)
+				added = true
+			}
+		}
+		if !added {
+			// the parents form a cycle
+			for _, p := range projects {
+				if pending[p.ID] {
+					result = append(result, p)
+				}
+			}
+			break
+		}
+	}
+	return result
+}
+
+func (r *trashRestore) tagFrame(f *model.Frame) {
+	for _, id := range r.tagged[f.ID] {
+		if !containsString(f.TagIDs, id) {
+			f.TagIDs = append(f.TagIDs, id)
+		}
+	}
+}
+
+// changes returns the values, which are added to the store
+func (r *trashRestore) changes() []Change {
+	entry := TrashEntry{ProjectNames: map[string]string{}}
+	entry.Projects = r.newProjects()
+	entry.Tags = r.newTags()
+	for _, f := range r.frames {
+		entry.Frames = append(entry.Frames, f)
+	}
+
+	for _, e := range r.entries {
+		for id, name := range e.ProjectNames {
+			entry.ProjectNames[id] = name
+		}
+	}
+	for id := range entry.ProjectNames {
+		if _, err := r.store.ProjectByID(id); err == nil {
+			entry.ProjectNames[id] = projectName(r.store, id)
+		}
+	}
+
+	changes := entry.Changes(ChangeAdded)
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].Entity != changes[j].Entity {
+			return entityOrder(changes[i].Entity) < entityOrder(changes[j].Entity)
+		}
+		return changes[i].Name < changes[j].Name
+	})
+	return changes
+}
+
+func entityOrder(entity string) int {
+	switch entity {
+	case journalProject:
+		return 0
+	case journalTag:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// remaining returns the values of the entry, which weren't restored
+func (r *trashRestore) remaining(entry TrashEntry) TrashEntry {
+	result := entry
+	result.Projects = nil
+	result.Tags = nil
+	result.Frames = nil
+	for _, p := range entry.Projects {
+		if !r.restored[p.ID] {
+			result.Projects = append(result.Projects, p)
+		}
+	}
+	for _, t := range entry.Tags {
+		if !r.restored[t.ID] {
+			result.Tags = append(result.Tags, t)
+		}
+	}
+	for _, f := range entry.Frames {
+		if !r.restored[f.ID] {
+			result.Frames = append(result.Frames, f)
+		}
+	}
+	return result
+}
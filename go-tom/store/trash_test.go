@@ -0,0 +1,128 @@
+package store_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jansorg/tom/go-tom/model"
+	"github.com/jansorg/tom/go-tom/store"
+)
+
+func Test_Trash(t *testing.T) {
+	for _, options := range []store.Options{{Format: store.FormatJSON}, {Format: store.FormatJSON, Journal: true}, {Format: store.FormatSQLite}} {
+		t.Run(options.Format, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "tom-trash")
+			require.NoError(t, err)
+			defer os.RemoveAll(dir)
+
+			options.Dir = dir
+			options.BackupDir = filepath.Join(dir, "backup")
+			s, err := store.Open(options)
+			require.NoError(t, err)
+			defer store.CloseStore(s)
+
+			acme, err := s.AddProject(model.Project{Name: "acme"})
+			require.NoError(t, err)
+			web, err := s.AddProject(model.Project{Name: "web", ParentID: acme.ID})
+			require.NoError(t, err)
+			other, err := s.AddProject(model.Project{Name: "other"})
+			require.NoError(t, err)
+			tag, err := s.AddTag(model.Tag{Name: "review"})
+			require.NoError(t, err)
+
+			start := time.Date(2019, 1, 1, 10, 0, 0, 0, time.UTC)
+			end := start.Add(time.Hour)
+			webFrame, err := s.AddFrame(model.Frame{ProjectId: web.ID, Start: &start, End: &end, TagIDs: []string{tag.ID}})
+			require.NoError(t, err)
+			otherFrame, err := s.AddFrame(model.Frame{ProjectId: other.ID, Start: &start, End: &end, TagIDs: []string{tag.ID}})
+			require.NoError(t, err)
+
+			// the tag is removed first, the project later
+			tagEntry := store.NewTrashEntry(s, nil, []*model.Tag{tag}, nil)
+			require.NoError(t, store.MoveToTrash(s, tagEntry))
+			assert.Empty(t, s.Tags())
+
+			projectEntry := store.NewTrashEntry(s, []*model.Project{acme, web}, nil, []*model.Frame{webFrame})
+			require.NoError(t, store.MoveToTrash(s, projectEntry))
+			assert.Len(t, s.Projects(), 1)
+			assert.Len(t, s.Frames(), 1)
+
+			entries, err := store.ListTrash(s)
+			require.NoError(t, err)
+			require.Len(t, entries, 2)
+			assert.EqualValues(t, tagEntry.ID, entries[0].ID)
+			assert.ElementsMatch(t, []string{webFrame.ID, otherFrame.ID}, entries[0].TaggedFrames[tag.ID])
+			changes := entries[1].Changes(store.ChangeRemoved)
+			require.Len(t, changes, 3)
+			assert.EqualValues(t, "removed project acme/web", changes[1].String())
+			assert.EqualValues(t, "removed frame "+webFrame.ID+" of project acme/web", changes[2].String())
+
+			// a single frame is restored with its projects and its tag
+			changes, err = store.RestoreFromTrash(s, []string{webFrame.ID})
+			require.NoError(t, err)
+			assert.Len(t, changes, 4)
+			assert.Len(t, s.Projects(), 3)
+			restoredWeb, err := s.ProjectByID(web.ID)
+			require.NoError(t, err)
+			assert.EqualValues(t, acme.ID, restoredWeb.ParentID, "the project hierarchy must be restored")
+			assert.EqualValues(t, "acme/web", restoredWeb.GetFullName("/"))
+			assert.EqualValues(t, []string{tag.ID}, findFrame(t, s, webFrame.ID).TagIDs)
+			assert.EqualValues(t, []string{tag.ID}, findFrame(t, s, otherFrame.ID).TagIDs, "the tag must be assigned again")
+
+			// the restored values are removed from the trash
+			entries, err = store.ListTrash(s)
+			require.NoError(t, err)
+			assert.Empty(t, entries)
+			_, err = store.RestoreFromTrash(s, []string{webFrame.ID})
+			assert.Error(t, err)
+
+			// expired and all entries
+			require.NoError(t, store.MoveToTrash(s, store.NewTrashEntry(s, nil, nil, []*model.Frame{findFrame(t, s, otherFrame.ID)})))
+			removed, err := store.ExpireTrash(s, time.Hour)
+			require.NoError(t, err)
+			assert.EqualValues(t, 0, removed)
+			removed, err = store.EmptyTrash(s)
+			require.NoError(t, err)
+			assert.EqualValues(t, 1, removed)
+			entries, err = store.ListTrash(s)
+			require.NoError(t, err)
+			assert.Empty(t, entries)
+		})
+	}
+}
+
+func Test_TrashRemoveAll(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tom-trash")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	s, err := store.Open(store.Options{Dir: dir})
+	require.NoError(t, err)
+	defer store.CloseStore(s)
+
+	p, err := s.AddProject(model.Project{Name: "acme"})
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		start := time.Date(2019+i, 1, 1, 10, 0, 0, 0, time.UTC)
+		_, err = s.AddFrame(model.Frame{ProjectId: p.ID, Start: &start, End: &start})
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, store.MoveToTrash(s, store.NewTrashEntry(s, s.Projects(), s.Tags(), s.Frames())))
+	assert.Empty(t, s.Projects())
+	assert.Empty(t, s.Frames())
+
+	entries, err := store.ListTrash(s)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	_, err = store.RestoreFromTrash(s, []string{entries[0].ID})
+	require.NoError(t, err)
+	assert.Len(t, s.Projects(), 1)
+	assert.Len(t, s.Frames(), 3)
+}
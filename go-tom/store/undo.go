@@ -0,0 +1,131 @@
+package store
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jansorg/tom/go-tom/model"
+)
+
+var ErrNothingToUndo = fmt.Errorf("there's nothing to undo")
+var ErrNothingToRedo = fmt.Errorf("there's nothing to redo")
+
+func undoTarget(s model.Store) (snapshotter, error) {
+	target, ok := s.(snapshotter)
+	if !ok {
+		return nil, fmt.Errorf("the store doesn't support undo")
+	}
+	if s.BackupDirPath() == "" {
+		return nil, fmt.Errorf("undo needs backups, but no backup directory is configured")
+	}
+	return target, nil
+}
+
+// Undo restores the data of s as it was before the last command which modified it.
+// The current data is kept to be restored by Redo.
+// The info of the backup of the undone command and the changes made by the command are returned.
+func Undo(s model.Store) (BackupInfo, []Change, error) {
+	target, err := undoTarget(s)
+	if err != nil {
+		return BackupInfo{}, nil, err
+	}
+
+	// other processes must not modify the data or the backups until the undo is complete
+	unlock, err := target.lockExclusive()
+	if err != nil {
+		return BackupInfo{}, nil, err
+	}
+	defer unlock()
+
+	backups, err := ListBackups(s)
+	if err != nil {
+		return BackupInfo{}, nil, err
+	}
+	// labeled backups aren't created by a modification
+	groups := sessions(automaticBackups(backups))
+	if len(groups) == 0 {
+		return BackupInfo{}, nil, ErrNothingToUndo
+	}
+
+	// the oldest backup of the last session contains the data before the command
+	session := groups[len(groups)-1]
+	undone := session[0]
+
+	changes, err := diffBackup(undone.Dir, s, false)
+	if err != nil {
+		return BackupInfo{}, nil, err
+	}
+
+	redoDir := filepath.Join(s.BackupDirPath(), redoDirName)
+	if err := os.MkdirAll(redoDir, 0700); err != nil {
+		return BackupInfo{}, nil, err
+	}
+	if _, err := target.createSnapshot(redoDir, BackupInfo{Command: undone.Command, Session: undone.Session}); err != nil {
+		return BackupInfo{}, nil, err
+	}
+
+	if err := restoreBackup(s, undone.Dir); err != nil {
+		return BackupInfo{}, nil, err
+	}
+	for _, backup := range session {
+		if err := os.RemoveAll(backup.Dir); err != nil {
+			return BackupInfo{}, nil, err
+		}
+	}
+	return undone, changes, nil
+}
+
+// Redo reapplies the changes of the last command which was reverted by Undo.
+// The info of the redone command and the reapplied changes are returned.
+func Redo(s model.Store) (BackupInfo, []Change, error) {
+	target, err := undoTarget(s)
+	if err != nil {
+		return BackupInfo{}, nil, err
+	}
+
+	unlock, err := target.lockExclusive()
+	if err != nil {
+		return BackupInfo{}, nil, err
+	}
+	defer unlock()
+
+	snapshots, err := listBackups(filepath.Join(s.BackupDirPath(), redoDirName), storeCipher(s))
+	if err != nil {
+		return BackupInfo{}, nil, err
+	}
+	if len(snapshots) == 0 {
+		return BackupInfo{}, nil, ErrNothingToRedo
+	}
+	redone := snapshots[len(snapshots)-1]
+
+	changes, err := diffBackup(redone.Dir, s, true)
+	if err != nil {
+		return BackupInfo{}, nil, err
+	}
+
+	// the redone command can be undone again
+	if _, err := target.createSnapshot(s.BackupDirPath(), BackupInfo{Command: redone.Command, Session: model.NextID()}); err != nil {
+		return BackupInfo{}, nil, err
+	}
+	removeOldBackups(s.BackupDirPath(), s.MaxBackups(), storeCipher(s))
+
+	if err := restoreBackup(s, redone.Dir); err != nil {
+		return BackupInfo{}, nil, err
+	}
+	return redone, changes, os.RemoveAll(redone.Dir)
+}
+
+// diffBackup returns the changes from the backup in dir to s or, if reverse is true, the changes from s to the backup
+func diffBackup(dir string, s model.Store, reverse bool) ([]Change, error) {
+	backup, err := OpenBackup(s, dir)
+	if err != nil {
+		return nil, err
+	}
+	defer CloseStore(backup)
+
+	if reverse {
+		return Diff(s, backup), nil
+	}
+	return Diff(backup, s), nil
+}
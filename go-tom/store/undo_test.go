@@ -0,0 +1,127 @@
+package store_test
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jansorg/tom/go-tom/model"
+	"github.com/jansorg/tom/go-tom/store"
+)
+
+func testUndoRedo(t *testing.T, options store.Options) {
+	// each command uses a new store
+	open := func(command string) model.Store {
+		options.Command = command
+		s, err := store.Open(options)
+		require.NoError(t, err)
+		return s
+	}
+
+	s := open("tom create project p")
+	p, err := s.AddProject(model.Project{Name: "p"})
+	require.NoError(t, err)
+	store.CloseStore(s)
+
+	s = open("tom start p")
+	start := time.Now()
+	frame, err := s.AddFrame(model.Frame{ProjectId: p.ID, Start: &start})
+	require.NoError(t, err)
+	_, err = s.AddTag(model.Tag{Name: "tag"})
+	require.NoError(t, err)
+	store.CloseStore(s)
+
+	s = open("tom undo")
+	info, changes, err := store.Undo(s)
+	require.NoError(t, err)
+	assert.EqualValues(t, "tom start p", info.Command)
+	require.Len(t, changes, 2)
+	assert.EqualValues(t, "added tag tag", changes[0].String())
+	assert.EqualValues(t, store.Change{Type: store.ChangeAdded, Entity: "frame", ID: frame.ID, Name: "p"}, changes[1])
+	assert.Empty(t, s.Frames(), "all changes of the command must be reverted")
+	assert.Empty(t, s.Tags(), "all changes of the command must be reverted")
+	assert.Len(t, s.Projects(), 1, "the changes of the previous command must be kept")
+	store.CloseStore(s)
+
+	s = open("tom redo")
+	info, changes, err = store.Redo(s)
+	require.NoError(t, err)
+	assert.EqualValues(t, "tom start p", info.Command)
+	assert.Len(t, changes, 2)
+	assert.Len(t, s.Frames(), 1)
+	assert.Len(t, s.Tags(), 1)
+
+	_, _, err = store.Redo(s)
+	assert.Equal(t, store.ErrNothingToRedo, err)
+	store.CloseStore(s)
+
+	// the redone command can be undone again
+	s = open("tom undo")
+	info, _, err = store.Undo(s)
+	require.NoError(t, err)
+	assert.EqualValues(t, "tom start p", info.Command)
+	assert.Empty(t, s.Frames())
+	store.CloseStore(s)
+
+	// a new change removes the undone changes
+	s = open("tom create tag other")
+	_, err = s.AddTag(model.Tag{Name: "other"})
+	require.NoError(t, err)
+	_, _, err = store.Redo(s)
+	assert.Equal(t, store.ErrNothingToRedo, err)
+
+	info, changes, err = store.Undo(s)
+	require.NoError(t, err)
+	assert.EqualValues(t, "tom create tag other", info.Command)
+	require.Len(t, changes, 1)
+	assert.EqualValues(t, "added tag other", changes[0].String())
+
+	info, _, err = store.Undo(s)
+	require.NoError(t, err)
+	assert.EqualValues(t, "tom create project p", info.Command)
+	assert.Empty(t, s.Projects())
+
+	_, _, err = store.Undo(s)
+	assert.Equal(t, store.ErrNothingToUndo, err)
+	store.CloseStore(s)
+}
+
+func Test_UndoRedo(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tom")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	backupDir, err := ioutil.TempDir("", "tom-backup")
+	require.NoError(t, err)
+	defer os.RemoveAll(backupDir)
+
+	testUndoRedo(t, store.Options{Dir: dir, BackupDir: backupDir, MaxBackups: 10})
+}
+
+func Test_UndoRedoJournal(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tom")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	backupDir, err := ioutil.TempDir("", "tom-backup")
+	require.NoError(t, err)
+	defer os.RemoveAll(backupDir)
+
+	testUndoRedo(t, store.Options{Dir: dir, BackupDir: backupDir, MaxBackups: 10, Journal: true, JournalCompactSize: 100})
+}
+
+func Test_UndoRedoSQLite(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tom")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	backupDir, err := ioutil.TempDir("", "tom-backup")
+	require.NoError(t, err)
+	defer os.RemoveAll(backupDir)
+
+	testUndoRedo(t, store.Options{Format: store.FormatSQLite, Dir: dir, BackupDir: backupDir, MaxBackups: 10})
+}
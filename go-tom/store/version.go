@@ -0,0 +1,438 @@
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/jansorg/tom/go-tom/model"
+	"github.com/jansorg/tom/go-tom/util"
+)
+
+// rawValue is a project, tag or frame as it's stored on disk
+type rawValue = map[string]interface{}
+
+// rawData is the data of a store without a mapping to the model types.
+// Migrations modify the raw data because the model types are only able to read the latest version.
+type rawData struct {
+	projects []rawValue
+	tags     []rawValue
+	clients  []rawValue
+	frames   []rawValue
+}
+
+// migration upgrades data to version
+type migration struct {
+	version     int
+	description string
+	migrate     func(data *rawData) error
+}
+
+// migrations must be sorted by version. Migrations are only appended, existing migrations must never be modified.
+var migrations = []migration{
+	{version: 1, description: "sort the tag IDs of frames", migrate: sortFrameTagIDs},
+	{version: 2, description: "store the frames in one file per year", migrate: splitFramesByYear},
+	{version: 3, description: "add clients, the history, custom fields, billable flags and breaks of frames, nested tags and budgets", migrate: addClientsAndFrameDetails},
+}
+
+// FormatVersion is the latest version of the data format
+var FormatVersion = migrations[len(migrations)-1].version
+
+// Migration describes a migration of the data format
+type Migration struct {
+	Version     int
+	Description string
+}
+
+// Migrations returns the migrations of the data format, sorted by version
+func Migrations() []Migration {
+	var result []Migration
+	for _, m := range migrations {
+		result = append(result, Migration{Version: m.version, Description: m.description})
+	}
+	return result
+}
+
+// versioned is implemented by the stores which support versioned data
+type versioned interface {
+	formatVersion() (int, error)
+}
+
+// Version returns the version of the data format used by s
+func Version(s model.Store) (int, error) {
+	if v, ok := s.(versioned); ok {
+		return v.formatVersion()
+	}
+	return 0, fmt.Errorf("the store doesn't support versioned data")
+}
+
+func checkVersion(version int) error {
+	if version > FormatVersion {
+		return fmt.Errorf("the data was written by a newer version of tom using data format version %d. This version of tom supports up to version %d", version, FormatVersion)
+	}
+	return nil
+}
+
+// migrateRawData applies the migrations newer than version to data
+func migrateRawData(data *rawData, version int) error {
+	if err := checkVersion(version); err != nil {
+		return err
+	}
+
+	for _, m := range migrations {
+		if m.version <= version {
+			continue
+		}
+		if err := m.migrate(data); err != nil {
+			return fmt.Errorf("error migrating data to version %d (%s): %s", m.version, m.description, err.Error())
+		}
+	}
+	return nil
+}
+
+func decodeRawValue(data []byte) (rawValue, error) {
+	// numbers are kept as they are, e.g. to keep the precision of money amounts
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value rawValue
+	err := decoder.Decode(&value)
+	return value, err
+}
+
+func decodeRawValues(data []byte) ([]rawValue, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var values []rawValue
+	err := decoder.Decode(&values)
+	return values, err
+}
+
+// readRawFile returns the values of a JSON data file
+func readRawFile(path string, c *dataCipher) ([]rawValue, error) {
+	if !fileExists(path) {
+		return nil, nil
+	}
+
+	data, err := c.readFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return decodeRawValues(data)
+}
+
+// decodeRaw unmarshals the migrated values into target
+func decodeRaw(values []rawValue, target interface{}) error {
+	if values == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
+
+// applyEntry applies a journal entry to the raw data
+func (r *rawData) applyEntry(e journalEntry) error {
+	var values *[]rawValue
+	switch e.Type {
+	case journalProject:
+		values = &r.projects
+	case journalTag:
+		values = &r.tags
+	case journalClient:
+		values = &r.clients
+	case journalFrame:
+		values = &r.frames
+	default:
+		return fmt.Errorf("unknown journal type %s", e.Type)
+	}
+
+	switch e.Action {
+	case journalReset:
+		*values = nil
+	case journalPut, journalRemove:
+		for i, v := range *values {
+			if v["id"] == e.ID {
+				*values = append((*values)[:i], (*values)[i+1:]...)
+				break
+			}
+		}
+
+		if e.Action == journalPut {
+			value, err := decodeRawValue(e.Data)
+			if err != nil {
+				return err
+			}
+			*values = append(*values, value)
+		}
+	default:
+		return fmt.Errorf("unknown journal action %s", e.Action)
+	}
+	return nil
+}
+
+// version 1: HasTag uses a binary search, but the tag IDs of imported frames weren't always sorted
+func sortFrameTagIDs(data *rawData) error {
+	for _, frame := range data.frames {
+		ids, ok := frame["tags"].([]interface{})
+		if !ok {
+			continue
+		}
+
+		sort.SliceStable(ids, func(i, j int) bool {
+			return strings.Compare(fmt.Sprintf("%v", ids[i]), fmt.Sprintf("%v", ids[j])) < 0
+		})
+	}
+	return nil
+}
+
+// version 2: the frames are stored in one file per year. The files are written when the migrated data is saved.
+func splitFramesByYear(*rawData) error {
+	return nil
+}
+
+// version 3: clients and new properties of projects, tags and frames were added.
+// The old data is valid without them, but older versions of tom must not drop them when they write the data.
+func addClientsAndFrameDetails(*rawData) error {
+	return nil
+}
+
+type versionFile struct {
+	Version int `json:"version"`
+}
+
+func (d *DataStore) formatVersion() (int, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return d.version, nil
+}
+
+// readVersionLocked returns the version of the data files and if it was read from the version file.
+// Data without version file was written before the data format was versioned.
+func (d *DataStore) readVersionLocked() (int, bool, error) {
+	if !fileExists(d.VersionFile) {
+		for _, file := range append([]string{d.ProjectFile, d.TagFile, d.FrameFile, d.JournalFile}, d.shardFiles()...) {
+			if fileExists(file) {
+				return 0, false, nil
+			}
+		}
+		return FormatVersion, false, nil
+	}
+
+	data, err := ioutil.ReadFile(d.VersionFile)
+	if err != nil {
+		return 0, false, err
+	}
+
+	var value versionFile
+	if err := json.Unmarshal(data, &value); err != nil {
+		return 0, false, fmt.Errorf("invalid version file %s: %s", d.VersionFile, err.Error())
+	}
+	return value.Version, true, nil
+}
+
+func (d *DataStore) writeVersionLocked() error {
+	if d.versionStored && d.version == FormatVersion {
+		return nil
+	}
+
+	data, err := json.Marshal(versionFile{Version: FormatVersion})
+	if err != nil {
+		return err
+	}
+	if err := util.WriteFileAtomic(d.VersionFile, data, 0600); err != nil {
+		return err
+	}
+
+	d.version = FormatVersion
+	d.versionStored = true
+	return nil
+}
+
+// readMigratedLocked reads the data files and the journal of an old version and migrates the data to the latest version
+func (d *DataStore) readMigratedLocked() error {
+	var raw rawData
+	var err error
+
+	if raw.projects, err = readRawFile(d.ProjectFile, d.cipher); err != nil {
+		return err
+	}
+	if raw.tags, err = readRawFile(d.TagFile, d.cipher); err != nil {
+		return err
+	}
+	if raw.clients, err = readRawFile(d.ClientFile, d.cipher); err != nil {
+		return err
+	}
+	if raw.frames, err = readRawFile(d.FrameFile, d.cipher); err != nil {
+		return err
+	}
+	for _, file := range d.shardFiles() {
+		frames, err := readRawFile(file, d.cipher)
+		if err != nil {
+			return err
+		}
+		raw.frames = append(raw.frames, frames...)
+	}
+
+	entries, _, err := readJournal(d.JournalFile, d.cipher)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if err := raw.applyEntry(e); err != nil {
+			return err
+		}
+	}
+
+	if err := migrateRawData(&raw, d.version); err != nil {
+		return err
+	}
+
+	if err := decodeRaw(raw.projects, &d.projects); err != nil {
+		return err
+	}
+	if err := decodeRaw(raw.tags, &d.tags); err != nil {
+		return err
+	}
+	if err := decodeRaw(raw.clients, &d.clients); err != nil {
+		return err
+	}
+	return decodeRaw(raw.frames, &d.frames)
+}
+
+// upgrade writes the data, which was migrated to the latest version of the data format when it was read.
+// A backup of the data is created first.
+func (d *DataStore) upgrade() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	unlock, err := d.lock.lockExclusive()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	// another process may have upgraded the data in the meantime
+	if err := d.refreshLocked(nil); err != nil {
+		return err
+	}
+	if d.version == FormatVersion {
+		return nil
+	}
+
+	if d.backupPath != "" {
+		info := BackupInfo{Command: fmt.Sprintf("upgrade of the data format from version %d to %d", d.version, FormatVersion), Session: d.session}
+		if _, err := d.createSnapshotLocked(d.backupPath, info); err != nil {
+			return err
+		}
+	}
+	return d.saveLocked()
+}
+
+// the version of the data format of a SQLite database is stored as user_version
+func (d *SQLiteStore) formatVersion() (int, error) {
+	var version int
+	err := d.db.QueryRow("PRAGMA user_version").Scan(&version)
+	return version, err
+}
+
+func (d *SQLiteStore) setFormatVersionLocked(executor dbExecutor, version int) error {
+	_, err := executor.Exec(fmt.Sprintf("PRAGMA user_version = %d", version))
+	return err
+}
+
+// upgradeLocked migrates the data of an old version to the latest version of the data format.
+// A backup of the database is created first.
+func (d *SQLiteStore) upgradeLocked() error {
+	version, err := d.formatVersion()
+	if err != nil {
+		return err
+	}
+	if err := checkVersion(version); err != nil {
+		return err
+	}
+	if version == FormatVersion {
+		return nil
+	}
+
+	var count int
+	if err := d.db.QueryRow("SELECT (SELECT COUNT(*) FROM projects) + (SELECT COUNT(*) FROM tags) + (SELECT COUNT(*) FROM clients) + (SELECT COUNT(*) FROM frames)").Scan(&count); err != nil {
+		return err
+	}
+	if count == 0 {
+		// a new database
+		return d.setFormatVersionLocked(d.db, FormatVersion)
+	}
+
+	if d.backupPath != "" {
+		info := BackupInfo{Command: fmt.Sprintf("upgrade of the data format from version %d to %d", version, FormatVersion), Session: d.session}
+		if _, err := d.createSnapshotLocked(d.backupPath, info); err != nil {
+			return err
+		}
+	}
+
+	var raw rawData
+	for table, values := range map[string]*[]rawValue{"projects": &raw.projects, "tags": &raw.tags, "clients": &raw.clients, "frames": &raw.frames} {
+		rows, err := d.db.Query("SELECT data FROM " + table + " ORDER BY rowid")
+		if err != nil {
+			return err
+		}
+		err = scanJSONRows(rows, func(data []byte) error {
+			value, err := decodeRawValue(data)
+			if err != nil {
+				return err
+			}
+			*values = append(*values, value)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := migrateRawData(&raw, version); err != nil {
+		return err
+	}
+
+	var projects []*model.Project
+	var tags []*model.Tag
+	var clients []*model.Client
+	var frames []*model.Frame
+	if err := decodeRaw(raw.projects, &projects); err != nil {
+		return err
+	}
+	if err := decodeRaw(raw.tags, &tags); err != nil {
+		return err
+	}
+	if err := decodeRaw(raw.clients, &clients); err != nil {
+		return err
+	}
+	if err := decodeRaw(raw.frames, &frames); err != nil {
+		return err
+	}
+
+	tx, err := d.db.Begin()
+	if err != nil {
+		return err
+	}
+	d.batch = tx
+
+	err = d.replaceAllLocked(projects, tags, clients, frames)
+	if err == nil {
+		err = d.setFormatVersionLocked(tx, FormatVersion)
+	}
+	d.batch = nil
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,138 @@
+package store_test
+
+import (
+	"database/sql"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jansorg/tom/go-tom/model"
+	"github.com/jansorg/tom/go-tom/store"
+)
+
+// data of tom before the data format was versioned
+const unversionedProjects = `[{"id":"p1","parent":"","name":"project","properties":{"hourlyRate":{"amount":12345678901234567,"code":"EUR"}}}]`
+const unversionedTags = `[{"id":"t1","name":"a"},{"id":"t2","name":"b"}]`
+const unversionedFrames = `[{"id":"f1","project":"p1","start":"2019-01-01T10:00:00Z","end":"2019-01-01T12:00:00Z","tags":["t2","t1"]}]`
+
+func writeUnversionedData(t *testing.T, dir string) {
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "projects.json"), []byte(unversionedProjects), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "tags.json"), []byte(unversionedTags), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "frames.json"), []byte(unversionedFrames), 0600))
+}
+
+func Test_VersionMigration(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tom")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	backupDir, err := ioutil.TempDir("", "tom-backup")
+	require.NoError(t, err)
+	defer os.RemoveAll(backupDir)
+
+	writeUnversionedData(t, dir)
+
+	s, err := store.NewStore(dir, backupDir, 10)
+	require.NoError(t, err)
+
+	version, err := store.Version(s)
+	require.NoError(t, err)
+	assert.EqualValues(t, store.FormatVersion, version)
+	assert.FileExists(t, filepath.Join(dir, "version.json"))
+
+	require.Len(t, s.Frames(), 1)
+	assert.EqualValues(t, []string{"t1", "t2"}, s.Frames()[0].TagIDs)
+	assert.True(t, s.Frames()[0].HasTag(&model.Tag{ID: "t2"}))
+	assert.EqualValues(t, 12345678901234567, s.Projects()[0].HourlyRate().Amount())
+
+	// a backup of the old data is created first
+	backups, err := store.ListBackups(s)
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+	assert.Contains(t, backups[0].Command, "upgrade")
+
+	backup, err := store.OpenBackup(s, backups[0].Dir)
+	require.NoError(t, err)
+	version, err = store.Version(backup)
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, version, "a backup must not be modified")
+	assert.EqualValues(t, []string{"t1", "t2"}, backup.Frames()[0].TagIDs, "a backup must be migrated in memory")
+}
+
+func Test_VersionMigrationClients(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tom")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// data of version 2, which already contains clients
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "version.json"), []byte(`{"version":2}`), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "clients.json"), []byte(`[{"id":"c1","name":"acme"}]`), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "projects.json"), []byte(`[{"id":"p1","name":"project","client":"c1"}]`), 0600))
+
+	s, err := store.NewStore(dir, "", 0)
+	require.NoError(t, err)
+	version, err := store.Version(s)
+	require.NoError(t, err)
+	assert.EqualValues(t, store.FormatVersion, version)
+	require.Len(t, s.Clients(), 1, "the clients must be kept by the upgrade")
+	assert.EqualValues(t, "acme", s.Clients()[0].Name)
+	store.CloseStore(s)
+
+	s, err = store.NewStore(dir, "", 0)
+	require.NoError(t, err)
+	defer store.CloseStore(s)
+	assert.Len(t, s.Clients(), 1)
+}
+
+func Test_VersionTooNew(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tom")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	writeUnversionedData(t, dir)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "version.json"), []byte(`{"version":1000}`), 0600))
+
+	_, err = store.NewStore(dir, "", 0)
+	assert.Error(t, err)
+}
+
+func Test_VersionMigrationSQLite(t *testing.T) {
+	jsonDir, err := ioutil.TempDir("", "tom")
+	require.NoError(t, err)
+	defer os.RemoveAll(jsonDir)
+
+	dir, err := ioutil.TempDir("", "tom")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	s, err := store.NewSQLiteStore(dir, "", 0)
+	require.NoError(t, err)
+	version, err := store.Version(s)
+	require.NoError(t, err)
+	assert.EqualValues(t, store.FormatVersion, version, "a new database must use the latest version")
+
+	writeUnversionedData(t, jsonDir)
+	source, err := store.OpenBackup(s, jsonDir)
+	require.NoError(t, err)
+	require.NoError(t, store.Migrate(source, s))
+	store.CloseStore(s)
+
+	// turn it into an unversioned database
+	db, err := sql.Open("sqlite", filepath.Join(dir, "tom.db"))
+	require.NoError(t, err)
+	_, err = db.Exec("UPDATE frames SET data = ?", `{"id":"f1","project":"p1","start":"2019-01-01T10:00:00Z","end":"2019-01-01T12:00:00Z","tags":["t2","t1"]}`)
+	require.NoError(t, err)
+	_, err = db.Exec("PRAGMA user_version = 0")
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	s, err = store.NewSQLiteStore(dir, "", 0)
+	require.NoError(t, err)
+	defer store.CloseStore(s)
+	require.Len(t, s.Frames(), 1)
+	assert.EqualValues(t, []string{"t1", "t2"}, s.Frames()[0].TagIDs)
+}
@@ -20,15 +20,36 @@ type Helper struct {
 	query query.StoreQuery
 }
 
+// GetOrCreateTag returns the top-level tag with the name, it's created if it doesn't exist
 func (s *Helper) GetOrCreateTag(name string) (*model.Tag, bool, error) {
+	return s.getOrCreateTag(name, "")
+}
+
+// GetOrCreateNestedTag returns the tag with the full name, e.g. meeting/client. Missing tags of the hierarchy are created.
+func (s *Helper) GetOrCreateNestedTag(fullName string) (*model.Tag, bool, error) {
+	var tag *model.Tag
+	var err error
+
+	created := false
+	parentID := ""
+	for _, name := range strings.Split(fullName, model.TagDelimiter) {
+		if tag, created, err = s.getOrCreateTag(name, parentID); err != nil {
+			return nil, created, err
+		}
+		parentID = tag.ID
+	}
+	return tag, created, nil
+}
+
+func (s *Helper) getOrCreateTag(name string, parentID string) (*model.Tag, bool, error) {
 	existing, err := s.store.FindFirstTag(func(tag *model.Tag) bool {
-		return tag.Name == name
-	});
+		return tag.Name == name && tag.ParentID == parentID
+	})
 	if err == nil {
 		return existing, false, nil
 	}
 
-	tag, err := s.store.AddTag(model.Tag{Name: name})
+	tag, err := s.store.AddTag(model.Tag{Name: name, ParentID: parentID})
 	return tag, true, err
 }
 
@@ -150,33 +171,40 @@ func (s *Helper) MoveProject(project *model.Project, newParentID string) (*model
 	return project, nil
 }
 
-func (s *Helper) RemoveProject(project *model.Project) (int, int, error) {
+// ProjectRemoval returns the projects and frames, which are removed with the project: the project, its subprojects and all of their frames
+func (s *Helper) ProjectRemoval(project *model.Project) ([]*model.Project, []*model.Frame, error) {
 	if project == nil {
-		return 0, 0, fmt.Errorf("project undefined")
+		return nil, nil, fmt.Errorf("project undefined")
 	}
 
 	// collect all sub projects
 	projects, err := s.query.CollectProjectAndSubprojects(project.ID)
 	if err != nil {
-		return 0, 0, err
+		return nil, nil, err
 	}
 
-	removedProjects := 0
-	removedFrames := 0
+	var frames []*model.Frame
 	for _, p := range projects {
-		frames := s.query.FramesByProject(p.ID, true)
-		for _, f := range frames {
-			if err := s.store.RemoveFrame(f.ID); err != nil {
-				return 0, 0, err
-			}
-			removedFrames++
-		}
+		frames = append(frames, s.query.FramesByProject(p.ID, false)...)
+	}
+	return projects, frames, nil
+}
 
+func (s *Helper) RemoveProject(project *model.Project) (int, int, error) {
+	projects, frames, err := s.ProjectRemoval(project)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for _, f := range frames {
+		if err := s.store.RemoveFrame(f.ID); err != nil {
+			return 0, 0, err
+		}
+	}
+	for _, p := range projects {
 		if err := s.store.RemoveProject(p.ID); err != nil {
 			return 0, 0, err
 		}
-		removedProjects++
 	}
-
-	return removedProjects, removedFrames, nil
+	return len(projects), len(frames), nil
 }
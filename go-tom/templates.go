@@ -1,8 +1,8 @@
 // Code generated by go-bindata. DO NOT EDIT.
 // sources:
-// reports/html/commons.gohtml (10.317kB)
-// reports/html/default.gohtml (7.426kB)
-// reports/html/timelog.gohtml (3.504kB)
+// reports/html/commons.gohtml (13.652kB)
+// reports/html/default.gohtml (8.641kB)
+// reports/html/timelog.gohtml (4.156kB)
 
 package tom
 
@@ -71,7 +71,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _reportsHtmlCommonsGohtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xdc\x5a\x6d\x6f\xdb\x38\xf2\x7f\xdf\x4f\x31\x70\xfe\x0b\x34\x81\x65\x3b\x69\xd3\x7f\xab\xa6\xc1\x76\xfb\x70\x5b\xa0\xbd\x3d\xd4\x5d\x1c\x70\xef\x28\x71\x6c\xb1\xa1\x48\x81\xa4\xe2\xb8\x86\xbf\xfb\x81\x7a\xb0\x25\x8a\xb2\xe5\x6c\xb1\x2f\xae\x02\x6a\x89\x1c\xce\xfc\x66\x38\x4f\xa2\xb2\xd9\x50\x5c\x30\x81\x30\x4a\xa5\xc0\xf5\x67\xa6\xcd\x68\xbb\x7d\x02\x00\xb0\xd9\x28\x22\x96\x08\x93\xea\xb9\x1c\x5b\x48\x95\x12\xf3\xc5\x12\xdb\x99\x9b\x48\xdd\x56\xd4\x28\xe8\x76\xfb\xa4\xfe\x7d\xb2\xe7\x4c\x73\x45\x0c\x93\x62\xcf\x78\xc5\x4c\xe2\xf0\x0d\x60\x7a\xb1\x94\x66\x9d\x61\x08\x4b\x66\x92\x3c\x9a\xc4\x32\x9d\x7e\x27\x42\x4b\xb5\x9c\x1a\x99\x4e\x97\x32\xb0\x3f\x94\x18\xfc\xc6\x52\x9c\xbc\xaf\xf8\xce\xf3\xf4\x62\x0a\x41\x8b\x1d\x5b\xc0\xe4\x93\xfe\x2a\x73\x41\x91\xfe\x07\x95\x6c\xcc\xda\x7f\x37\x3a\x23\x02\x62\x4e\xb4\x7e\xb3\x03\x18\xfc\x40\x25\x47\xb7\x9b\x4d\xca\x44\xcd\x1c\x26\xff\x40\xb3\xdd\xde\x4c\xed\x82\xdb\x86\x04\xe4\x1a\x7d\x4c\x87\x2e\x2f\xac\x34\xd0\x72\xff\x66\x26\xf9\xf0\x40\xe2\xc6\xde\xfc\x9f\x4e\xe4\xaa\x18\x83\xf0\x0d\x28\xcc\xa4\x32\x7f\x64\x96\x58\x4f\xe6\xf5\x54\x8d\x42\x83\xe5\xfb\xbf\x62\xfb\x1a\x34\x5b\x40\xc3\x0a\x81\x23\xa5\x90\x14\xa9\xdb\x9f\x23\x6d\xbf\x5d\x3f\x61\xf7\xff\x82\x02\x9b\x0d\xb0\xcb\x97\x02\x46\x95\x79\x61\x17\x5c\xb0\xdd\xfa\x14\x2a\x78\x0f\xd6\x6a\x88\x53\x1a\x45\xe2\x3b\xa4\x9f\xc4\x42\xfe\xec\x88\xb6\xff\x7d\x10\x46\xad\xe7\xa8\x18\xea\x8e\x67\x4d\x2f\x1c\x05\xbf\x30\xb1\xdd\x5e\x4c\x8f\x10\x91\x87\x0e\x51\x8b\xe0\xed\xfd\xf2\xb8\xda\xf3\x3c\x4d\x89\x5a\xef\x55\x3e\xa2\xdf\x52\x1a\x96\x62\xfd\x53\x06\xe8\xe4\x2b\xea\x9c\x9b\xdf\xf2\xf8\x0e\x4d\xa5\x5d\xc1\xed\xc6\x90\x88\x63\xbd\xc9\xba\x12\xb5\xdf\xae\x1b\x13\x49\xba\xbe\x6d\x05\xd9\xe4\x93\xfe\x97\x92\xdf\x31\xae\xf8\xb9\xee\x68\xd4\x6d\xd7\x9f\x0c\xbd\xdd\x6c\x4a\x0f\xaa\x16\x8f\xac\x73\x18\xea\xa5\xad\x01\x19\x66\x38\x5a\xef\x9a\x7c\xb3\x77\xbe\x15\x37\xd3\xa6\xbc\xae\x6b\xb1\x05\x08\x69\x9c\x44\xf5\xb5\xb4\xca\x7b\x62\xf0\x23\xe3\x06\xd5\x57\x5b\x71\x26\x1f\xd2\xcc\xac\xe1\x54\x7d\xac\xf3\x40\x51\xb2\xc2\x61\x3a\xa5\x18\x14\xe4\x56\xb1\x41\xb8\xbe\x30\xc1\x52\xc2\xe7\x46\x31\xb1\x1c\x64\x04\xae\x11\x2a\xcd\x0b\x6e\x0d\xfd\xfe\x56\xf5\x1a\xc2\x4f\x57\xa2\xb5\x93\x1d\x9c\x2d\x8c\x65\x6a\x00\x2b\xdc\x8f\xd2\x41\xd8\xc9\x58\xf3\x3c\xfd\x63\x31\xcf\xa3\x5d\xe5\x72\x99\xb8\xe8\xd8\x62\x50\xf1\x3b\xc9\xd4\x65\x3e\x36\x47\x95\x19\xa2\x50\x7d\xd7\xca\xc5\x8f\x08\x1e\x22\xa8\x47\xd1\xda\xe0\x93\xdf\x89\x7e\x4f\x18\x5f\x57\x03\xa7\x6a\x5c\xac\x7d\xac\xc6\x1d\x8a\x12\xb4\xc1\x34\xe3\xc4\x38\x15\xa3\x28\x4a\x07\xa0\x56\xe6\xf8\x59\x06\xfa\x53\x74\x4c\xb4\x1b\x7a\x9c\x91\x72\xf1\xf7\x9a\xa9\x0f\xee\xa3\x0d\xd5\x35\xd2\x9c\x70\x3c\x3d\x4a\xbe\x49\x43\x38\x90\x54\xe6\xc2\x9c\x14\x1f\x7b\x8d\xf7\xef\x1c\x30\x29\x40\x4c\xaa\xb6\xe6\x11\x79\x69\xda\x28\x91\x37\xd3\xa2\x9e\xde\xfa\xea\x78\x2c\xd3\x54\x8a\x77\xf3\x79\x5d\xc9\x6f\xb4\x59\x5b\xda\x9a\x53\xa8\xa4\x34\xb0\x69\xc9\x0e\x82\x85\x14\x26\xd0\xec\x07\x86\x70\x79\x95\x99\xd7\xbe\xe9\x05\x49\x19\x5f\x87\x30\xd2\x6b\x6d\x30\x0d\x72\x36\x1a\x43\x40\xb2\x8c\x63\x50\x0e\x8d\xe1\x37\xce\xc4\xdd\x17\x12\xcf\x8b\xe7\x8f\x52\x98\x31\x8c\xe6\xb8\x94\x08\x7f\x7e\x1a\x8d\xe1\xab\x8c\xa4\x91\x63\xf8\x1d\xf9\x3d\x1a\x16\x93\x31\xbc\x55\x8c\xf0\x31\x68\x22\x74\xa0\x51\xb1\xc5\x18\x46\x6f\x2d\x53\x78\x27\xb9\x54\xf0\x21\x95\xdf\xad\xa4\x1d\x1b\xcf\xc8\x7c\x9d\x46\x92\x8f\x5c\xd8\x45\x95\x68\x63\xff\x9c\xc7\x8c\x12\x78\x27\x85\x96\x1c\x47\x63\xf8\x22\x05\x89\xe5\x18\x52\x29\xa4\xce\x48\x8c\xfd\x4c\x56\xc8\x96\x89\x09\x41\xd8\x97\x44\xfe\xfa\x89\x43\x18\x5b\xb8\x21\x44\x9c\xc4\x77\x2e\x93\x68\x59\x4f\xaf\x12\x66\x3c\x32\x0c\xc7\x9a\xe2\xec\xd9\xe5\x8b\xeb\xe8\xb9\x4b\xa3\xe4\x2a\xc0\x7b\x14\x35\xd9\x3d\x51\x4f\xf7\x8c\xcf\x7d\xe4\x92\xd2\x1d\x53\xc4\x8e\xd4\x62\x2a\xc8\x45\xae\x91\x86\x70\x46\xd0\x5e\x1d\xe4\x52\x51\x54\x15\x2d\x2f\x0d\x50\x8a\x6e\x2e\x3f\xef\x58\xa3\xea\xf2\xda\x68\xfd\x50\x6b\xd2\xbd\x91\x3a\xba\xb9\xf6\xb2\xfe\x1f\xac\x18\x35\x49\x08\x97\xb3\xd9\x2f\x1d\x83\x16\x04\x94\x18\x72\x9c\xca\x24\x43\x40\xee\x68\xa3\xe5\xb0\x0d\xd8\x2d\xa8\xdd\x26\x92\x9c\xf6\x12\x59\x07\x2b\xe3\x6f\x36\x79\xa9\x30\xed\xb5\x67\x73\x3f\x6a\x0c\xad\xbd\x6e\x00\xd9\xee\x99\xfc\x9a\x22\x65\x04\x9e\x66\x0a\x17\xa8\x74\xb5\x79\x3a\x4e\x30\xc5\x10\x28\x51\x77\xe7\x4e\x52\xf0\x25\x0a\x68\x3a\xfa\xd9\x82\xd8\xeb\xb5\x87\x62\xbf\x93\x67\x97\xc4\x5e\x3e\xa2\xe3\x4e\x0f\x5e\x4f\xbe\x7a\x61\xaf\x3e\xca\x66\x88\x9c\x5d\xbf\xb2\x97\x8f\xd4\xf1\xfd\x97\x33\x7b\x79\x55\x79\x94\xff\x43\x8f\x63\x9f\x3d\x9f\xd9\xcb\x4b\x3d\xdc\x1b\xa1\xc7\x23\xcf\xfe\xff\x99\xbd\x0e\x63\x39\xc1\x7f\xa0\xf0\xa1\xfd\xdd\xee\x36\x31\x29\x77\x5c\xa3\x51\x41\x4a\xa6\xbb\x01\x87\xe1\x51\xed\x22\x12\xdf\x2d\x95\xad\x94\x83\x52\x5d\x2b\xbf\x37\x44\x97\x43\xee\xc6\x18\x7c\x30\x81\x42\x41\xd1\xbe\x20\x84\x20\x33\xc3\x52\xf6\x03\x3f\xe3\x92\x45\x8c\x33\xb3\xf6\x86\x4f\xf9\x02\xdb\xd6\xb8\x4a\x2c\xa5\xc8\x46\x46\x72\xd5\xd9\x19\x9c\x93\x4c\x63\x08\xf5\x5d\x4b\xd0\x5e\x52\x32\x06\x43\x1d\x51\x9c\x09\x0c\x92\x2a\x8f\x5c\x4e\xae\xae\x8b\x0c\xd1\xa4\xc8\x08\xa5\x85\x42\xb3\x72\x16\x2e\x3b\x24\xcd\x1a\xef\x9f\x74\x0b\x9c\xcf\x10\xc9\xa4\x88\x5b\x0b\xb2\xbc\x73\xa0\x76\x80\xcc\x7a\xf8\xb8\xd6\xb4\x75\x31\x28\x2a\x70\x17\xc0\x6e\xe7\x08\x67\x4b\x11\x02\xc7\x85\xd3\xa3\xdc\xa3\xb2\xed\x04\xaf\x29\x22\x69\x8c\x4c\xfd\xa2\x5d\xeb\xba\x6b\x8d\xcc\x7a\x30\x23\xa1\x5d\xe4\x7d\xfe\xea\xc6\xe7\xa1\x38\x68\xc7\xbe\xcf\xc3\x9b\xa1\xd5\xa9\x1a\xbe\x05\xf5\x66\x3a\x4b\xca\x61\x7f\x91\x98\xec\xcb\xe6\x71\x5f\xdf\x17\xd7\x83\xcc\x24\xa5\x60\x54\x28\xac\x6e\x09\xe3\xf4\xe9\x95\x38\x07\x43\xc7\xad\x79\xdb\xdb\x7a\xa8\x86\x5a\xba\x9d\xf7\x4f\x85\x13\x5c\x0e\x03\x54\xd0\x9d\x02\xe9\x48\x49\xae\xe4\xa5\xc4\x28\xf6\x00\x86\x86\x42\x9a\xa7\x21\x27\xda\x94\x22\xcf\xc7\x2e\x49\xd2\x21\xf1\x07\x5f\xa0\xaa\x4c\xd1\x0a\xf3\xa6\xe8\x92\x63\xe0\x0b\xe0\xde\x7e\xa9\x08\x40\x79\x8f\x6a\xc1\xe5\x2a\x04\xe4\x9c\x65\x9a\xe9\x36\x51\x3d\x1f\x3c\x84\x90\x30\x4a\x51\x1c\x8e\xd3\x94\x51\xca\xb1\xc7\x3e\x89\xf5\xaf\x0e\xbe\x63\x89\xa2\x52\xe0\xd5\xab\x5f\xfc\x6c\x0b\xc9\x81\x4d\x21\x0e\xe7\xfe\x0c\xd3\x5d\x5e\x58\xf8\xc0\xfa\x62\xbe\x47\x2d\x8f\xd1\xdb\xc9\x60\xdf\x18\x1d\x0a\xec\x6e\x3f\xd9\xcc\xf0\x93\x59\xef\xe6\x47\xc5\x81\xaa\x86\x5b\x3f\x96\x16\x97\x17\x9d\x4a\x91\x12\xb5\x64\x22\x28\x13\x6c\x08\x93\xeb\x5e\x39\x55\xcb\x71\x48\xd5\xd6\x5b\xc2\xc0\x3e\xc0\xed\xaa\xce\xbd\xdb\x4f\x72\x23\x7d\x85\x38\x84\x67\xd9\x03\x68\xc9\x19\x75\xf9\x35\x3a\xa3\x73\x9f\xce\x21\xcc\x60\x06\xcf\xfa\x8b\xda\x4e\xe3\x4e\x89\xd9\x55\xc5\x83\xe6\x8a\x4c\x71\xe8\xb0\xf1\xd9\xdb\xc8\xec\x50\x40\xdb\x7c\xb3\x63\x60\x7b\x08\x67\xc4\x05\x54\x29\x5b\x72\x75\x0c\xd2\x6d\x79\x7b\x12\x58\x2c\xf9\x4e\xc4\xb8\xfd\xe8\x17\x67\xa3\xea\x2f\xc8\x2b\x0e\x54\x6c\x56\x64\xa9\xd7\x69\xdb\x5d\xa0\xfb\xf6\x3f\xa0\x48\x3a\xaf\xfa\xe7\xfd\x1d\x88\x13\xdf\xd0\x4d\x4c\x2b\x45\xfc\x6d\x44\xa9\x46\x60\x9b\x09\x54\x95\x36\xd5\xd3\xf0\x84\x72\x54\x60\x47\xc5\x76\xba\x68\x27\xa4\x14\xfb\x36\x6e\x40\x62\xea\xb0\x7a\x24\x9f\x13\x10\x97\x19\xcc\x1b\x28\x65\x90\x5e\x1d\x0a\x52\x43\x94\x09\x28\xb1\x9e\x54\xde\x5b\xd4\xc5\x83\xcc\x02\x8f\x6f\xa5\x4c\xd4\xa7\x08\xdd\x74\x38\x6c\x13\xee\x89\x62\x44\x98\x40\xe4\x29\x2a\x16\x87\xf6\x85\x22\xe7\x44\xd9\x01\xed\x85\x39\xbd\x00\x26\x74\xc6\x14\x52\x88\xd6\x90\x18\x93\xe9\x70\x3a\x8d\xb5\x0e\x8c\x62\xf1\x9d\x2e\x3e\xc7\x69\xc1\xb2\x0c\x8d\xb6\xe3\xd3\x4c\xd9\x26\xc8\x04\x5c\x8a\x65\x90\x2b\xae\x83\x85\x92\x69\x10\x29\x24\x77\xb6\x2b\x90\xb9\x09\xe4\x22\x88\xa5\x30\x84\x09\x54\x53\xb8\x98\xee\xcd\x22\xa4\x41\x3d\x86\x09\x45\x1d\x2b\x56\x9c\x9f\x3a\x76\x98\x5e\xc0\xb7\x04\x35\x02\x51\x08\x06\xe3\x44\xd8\x4a\xce\xd7\xb6\x31\x06\x4d\xac\x0d\xa3\xdc\x40\xae\xd1\xb6\xdf\x49\x93\x3b\x34\x5b\x03\x6b\xa3\x10\x0a\x58\xc1\x4a\x2a\xa7\x88\xd9\x11\x0f\x49\x8b\x26\x48\x75\x31\x5c\xea\x56\x13\x12\xee\x74\x02\xd3\x0b\xf8\x24\xb4\xb1\x5d\xbb\x05\x65\x12\xa6\x41\x48\x11\x68\x43\x04\x25\x8a\x82\x14\x18\xba\x30\xbb\x7c\x3d\x00\xa6\x17\xf0\x96\x52\x0d\x04\x92\x75\x96\xa0\x80\x55\x82\xd6\x28\x09\x16\xeb\xcb\x85\x7a\x0c\x6c\x01\x3a\xcf\x32\xa9\x0c\x52\x78\xfa\x4f\x59\x1e\x90\x9e\xbb\x32\xad\x3e\x25\x23\xed\xab\x5c\x41\x2a\x7f\x1c\x9c\x5f\x61\x74\xc7\xcc\x21\x92\x9e\xa9\x66\x5c\xb4\xfe\x7a\xc0\xd9\x7a\x99\x91\x98\x99\xb5\x7d\xab\xbb\x6e\xae\x86\xe2\x38\xba\x3a\x62\x2e\x9f\x8a\x07\x28\x8e\x9b\xde\x8c\x32\xc5\x84\x69\x7c\x1c\xf8\x35\x23\xcb\x4e\x78\x55\x71\x7b\x79\x9d\xa6\x70\x35\x4b\xfd\x15\xae\xe8\xc8\x07\x1f\x5b\x37\xcf\x2a\x2e\x0f\xf4\x37\x2f\xfb\x16\x5d\x8d\x21\x79\x36\x86\xe4\xf9\x81\xc5\xcf\x7b\x16\x1f\x38\x2c\xe8\x76\xd5\xd6\x20\xa5\xbb\x05\x4c\x68\x46\x31\x04\x72\x2f\x99\x3f\xf1\x19\xb7\x42\x1c\x5f\xed\x50\x91\x85\xb1\x3d\x50\xaf\x17\x94\xef\xb8\xbd\x42\x22\x5c\x48\x35\x58\xc8\x41\xa2\x03\x78\x29\xd3\x19\x27\xeb\xb0\xb4\x64\x55\x1b\x03\xdb\x0b\xf6\xbc\x98\x7b\x9c\xe3\x27\x81\x6e\x4a\x59\x74\x0f\x44\x4f\x96\x32\x5c\x6b\xdb\xbf\xf5\xab\x7c\xac\x89\xf7\x01\xeb\x24\x85\x01\xda\x43\x33\xc0\x7d\xdf\x9b\x72\x6d\x64\xda\xf8\xde\x54\xfd\xb1\x4c\xfb\x33\xdc\xbb\x9a\xaa\xf9\x49\xcb\xf9\x30\x55\x2e\x9e\xb4\x3e\x7a\x35\x48\x76\xb2\x8f\x0b\xf9\xc8\x38\x1e\x15\xc4\x04\x67\x02\xdf\xcd\xe7\x70\x54\x64\xf5\xfb\xdf\x00\x00\x00\xff\xff\x2e\x4f\x2f\x3c\x4d\x28\x00\x00")
+var _reportsHtmlCommonsGohtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xdc\x5a\x5f\x6f\xdb\x38\x12\x7f\xef\xa7\x20\x9c\x5d\xa0\x09\x2c\x39\x49\xff\x6c\xd7\x75\x83\xed\xdf\xdb\x02\xdb\xee\xa2\x4e\x71\xc0\xbd\x51\xe6\x58\xe2\x86\x22\x05\x92\x4a\xe2\x0a\xfa\xee\x07\x52\x92\x2d\x51\x94\x2d\xb7\xb9\x7d\xb8\xca\x68\x24\x72\x38\xf3\x9b\xe1\xcc\x70\x48\xa9\x28\x08\xac\x29\x07\x34\x49\x05\x87\xcd\x1f\x54\xe9\x49\x59\x3e\x42\x08\xa1\xa2\x90\x98\xc7\x80\xc2\xfa\xd9\xfc\x8a\x62\x2d\x64\x8a\xf5\x27\x43\x6c\x7a\x16\x91\xbc\xaa\xa9\x81\x93\xb2\x7c\xd4\xfc\x7d\xb4\xe3\x4c\x72\x89\x35\x15\x7c\xc7\xf8\x8e\xea\xc4\xe1\x1b\xa0\xd9\x59\x2c\xf4\x26\x83\x39\x8a\xa9\x4e\xf2\x28\x5c\x89\x74\xf6\x37\xe6\x4a\xc8\x78\xa6\x45\x3a\x8b\x45\x60\xfe\x10\xac\xe1\x9a\xa6\x10\xbe\xab\xf9\x2e\xf3\xf4\x6c\x86\x82\x0e\x3b\xba\x46\xe1\x47\xf5\x45\xe4\x9c\x00\xf9\x0f\x48\xd1\xea\x35\xbf\x85\xca\x30\x47\x2b\x86\x95\x7a\xb5\x05\x18\x7c\x03\x29\x26\x57\x45\x91\x52\xde\x30\x47\xe1\xbf\x40\x97\xe5\x62\x66\x06\x5c\xb5\x24\x00\x53\xe0\x63\x3a\x76\xb8\xb5\xd6\x48\xcb\xfd\x9b\xea\xe4\xfd\x3d\x5e\xb5\xe6\xe6\x27\x95\x88\x3b\xdb\x86\xe6\xaf\x90\x84\x4c\x48\xfd\x67\x66\x44\xaa\x70\xd9\x74\x35\x28\x14\x2a\xcb\x47\xff\x37\xb6\x6f\x40\xd3\x35\x6a\x59\xa1\x8d\xa1\xf9\x67\xbc\xf3\x61\xa4\xed\xa6\xeb\x01\x66\xff\x07\x14\x28\x0a\x44\x2f\x5e\x70\x34\xa9\xcd\x8b\x1a\x8d\x26\xa8\x2c\x7d\x0a\x59\xde\xa3\xb5\xda\x3e\x37\xf7\x1e\xa7\xd4\x12\xaf\x6e\x80\x7c\xe4\x6b\xf1\xd0\x11\x6d\x5c\xeb\x3d\xd7\x72\xb3\x04\x49\x41\xf5\x3c\x6b\x76\xe6\x28\xf8\x89\xf2\xb2\x3c\x9b\x1d\x20\xc2\xf7\x3d\xa2\x0e\xc1\xeb\xdb\x78\x84\xda\x38\xde\xa9\x3b\x5e\xb7\x54\x10\x60\xe1\x35\x8e\x5b\xca\x74\x66\x54\xe3\x78\xd2\xd8\xef\xad\x60\x42\x96\x25\x52\x7a\xc3\xe0\xd5\x24\xc2\xab\x9b\x58\x9a\x89\x0e\x56\xa6\x67\x8e\x8a\x22\x2c\xcb\x49\x8d\xae\x19\xf5\x0e\xd4\x4a\x52\x1b\xfb\x65\x89\x34\xd5\x66\x6c\x87\xf2\xea\xa4\x28\x34\x8e\x3f\xe3\xd4\xa6\xf3\xc6\x19\x8c\x1e\x3d\x3d\xa3\x7c\x75\x03\xfa\xda\x70\xf9\x1e\x7d\xab\x4c\x14\x7e\x01\x95\x33\xfd\xc6\xf2\x6a\x69\x6e\x38\x55\xa0\xaf\x71\x5c\x96\x45\xa1\x21\xcd\x18\xd6\xb5\x7d\x0d\xb8\x26\xb6\x8a\x22\xb4\x20\xcc\x1d\x70\x62\x59\x14\x45\x0f\xee\x32\x4f\x53\x2c\x37\xa3\xa1\xc6\x42\xd3\x14\x9a\x3f\xc3\x68\x2d\xdc\x85\xc6\x11\x83\x66\xa6\x54\x2d\x6a\x17\x45\x0b\x1d\x09\xb2\xd9\x3d\x17\x05\x5d\x87\x1f\xd5\x5f\x52\xfc\x0d\xab\x9a\x5f\x0d\xac\xb9\x16\x5a\x5e\x75\x1a\xcc\x6f\xa1\xc9\x55\x51\x54\x81\x5d\x0f\x9e\x98\x98\xd5\xc4\x4b\xbb\x75\x1d\x63\x9f\xc9\xd5\xce\x52\xfd\x11\x8b\x59\x5b\x5e\x63\xbf\xdd\x33\x5d\x23\x2e\xb4\xb3\x7e\x7c\xb1\x4f\xe1\x3b\xac\xe1\x03\x65\x1a\xe4\x17\x53\x08\x84\xef\xd3\x4c\x6f\xd0\xb1\xfa\x98\x98\x46\xb6\x92\x98\x8f\xd3\x29\x85\xc0\x92\x1b\xc5\x46\xe1\xfa\x44\x39\x4d\x31\x5b\x6a\x49\x79\x3c\xca\x08\x4c\x01\xaa\x35\xb7\xdc\x5a\xfa\xfd\xa3\xea\xb5\x84\x1f\xaf\x44\x67\x26\x7b\x38\x3b\x18\xab\x8c\x8d\x8c\x6d\xfd\x28\x1d\x84\xbd\x85\x64\x99\xa7\x7f\xae\x97\x79\xd4\xb4\x28\x97\x89\x8b\x8e\xae\x3d\x35\xc9\x1b\xca\x98\x0d\xa8\x63\x8d\xbc\x1d\x38\xac\xc1\x18\x2d\x1a\x36\x4d\x4b\xb3\x3a\xef\x37\xf5\x28\x84\x9f\x05\x0f\xa2\x87\x41\xf9\x59\xf0\xff\x21\xd0\xaf\x9a\x32\xfa\xcd\x4e\xeb\x51\x10\xab\xba\xff\x73\x9e\x46\x20\x51\xd8\xe2\x52\x96\x3f\x1f\xeb\xac\x03\xee\xd1\x2f\x59\x8f\xd2\xcc\x0e\x47\xfa\xa0\xaf\x8f\x99\x83\xe6\xae\x53\x41\x7d\x87\x92\x98\x13\x8f\xa2\xd7\x35\xc6\xf0\x77\xac\xde\x61\xca\x36\x75\xc3\xb1\x1a\xdb\xb1\xdf\xab\x71\x8f\xc2\xfc\x3a\x0b\x72\xab\xce\xb3\x85\xec\x1e\xa8\xb5\x39\x1e\xca\x40\x5f\x79\x2d\x66\x67\xa2\xaf\xdc\x2f\x79\xa4\x91\x72\xfe\xcf\x9a\xe9\x2b\x7f\x60\x43\xf5\x8d\xb4\xc4\x0c\x8e\x8f\x92\x6b\xa1\x31\x43\x38\x15\x39\xd7\x47\x98\xa1\xad\xf1\xee\xa4\x00\x85\x16\x44\x58\x6f\x46\xca\xf2\x58\xd5\x16\xb3\x56\x05\xb5\x98\xd9\x72\xeb\xca\x57\x7d\xbf\xc9\x49\x0c\x5a\xed\xca\xbc\x9f\x08\x30\x9a\x52\x0d\xb2\xbf\xff\xad\xeb\x84\xba\x92\x7a\xd7\x10\x6e\xab\x3a\x42\x6f\x1b\xed\xa2\x9a\xef\x0e\xe2\x22\xb9\xdc\x1a\x6b\x27\x75\x31\x4b\x2e\xdb\x6a\xd8\x25\x1c\xd5\xa3\x5b\x0a\x7d\x67\xb9\x5c\x09\x7a\x93\x4b\xfe\x4e\xdc\xf1\x56\xc1\xdc\x5c\xf5\x86\xff\x77\x91\x4b\x65\x14\xae\x47\x84\xb6\xc1\x4f\x5b\x9d\xd1\xb4\x68\x6d\x83\x43\xdb\xad\x70\xed\x43\x20\x08\xa9\x35\xf3\xb8\xff\x42\x27\x80\xbd\x1e\xe3\x71\xbc\x7a\xc0\x96\x7d\x12\xdc\x51\x02\x93\xee\x96\x76\x57\xc5\xd6\x13\x66\x72\xcd\x87\x9c\x31\xbb\x5d\xd9\xcd\xf3\x76\xdf\xd2\x3d\x40\xa8\x90\x06\xb7\x98\xe5\x15\x97\x4a\xdb\x2d\xf5\x62\xa6\x93\xc3\xc8\x4c\x59\x66\x14\x03\x39\xd9\xce\x7e\xb3\x08\x54\x51\x32\xc0\xc4\x06\xe7\x6e\x6e\xca\xd2\x4b\x35\x46\xdc\x17\x48\x31\xe5\x94\xc7\x07\xe4\x75\x23\xc8\x8f\xc5\x37\xd5\x03\x58\x6c\x34\xf7\xc0\xbc\xb6\x09\x62\x2f\x92\x51\x7c\x7e\x5c\xa9\x43\x66\xfb\xaa\x80\x0c\x32\xef\x66\x9f\xe6\xdf\x62\x36\xe8\xc4\xdd\xfd\x9c\x1b\xef\xe1\x27\xc1\x75\x32\x34\xc9\x0b\x2d\x9b\xc3\xaf\xf7\xf7\x2b\x00\x9b\x11\x1d\x37\x85\xba\x63\xbb\x33\xf7\x72\xda\x25\xed\xf0\x2f\x90\x54\x90\xc3\xdb\x83\x03\xd9\xbb\x53\xdd\x6c\x97\xa6\xfd\x6c\xc6\xbb\xf6\x18\xa1\x5b\x4f\x68\x9a\x0e\x8b\x1f\x76\x8a\xe3\xbc\xdd\x81\x67\x3d\x75\x72\xe5\x9c\x65\xdb\x95\xec\x10\xa6\x51\x9c\xb6\x9a\xd6\xb8\x7e\x4c\x4d\x8f\x5d\x9d\x5a\x5c\x01\xf9\x0b\xe4\x0a\xb8\xc6\x31\x78\xcb\xf1\xfd\xe1\x30\x84\xa0\xb3\x38\x37\xd7\x76\x91\xf6\x8d\x5e\xcc\x08\xbd\xf5\xae\xdf\x2b\x91\xa6\x82\xbf\x5d\x2e\x9b\x15\x7c\x61\xcf\xb8\x76\x6c\xe6\x52\x08\x8d\x8a\x8e\xac\x20\x58\x0b\xae\x03\x45\xbf\xc1\x1c\x5d\x5c\x66\xfa\xa5\xaf\x7b\x8d\x53\xca\x36\x73\x34\x51\x1b\xa5\x21\x0d\x72\x3a\x99\xa2\x00\x67\x19\x83\xa0\x6a\x9a\xa2\x37\x8c\xf2\x9b\x4f\x78\xb5\xb4\xcf\x1f\x04\xd7\x53\x34\x59\x42\x2c\x00\x7d\xfd\x38\x99\xa2\x2f\x22\x12\x5a\x4c\xd1\xef\xc0\x6e\x41\xd3\x15\x9e\xa2\xd7\x92\x62\x36\x45\x0a\x73\x15\x28\x90\x74\x3d\x45\x93\xd7\x86\x29\xb2\xa7\x74\xe8\x7d\x2a\xfe\xa6\x93\x16\x1b\x4f\xcb\x72\x93\x46\x82\x4d\x5c\xd8\xa6\x04\x75\xb0\xff\x91\xaf\x28\xc1\xe8\xad\xe0\x4a\x30\x98\x4c\xd1\x27\xc1\xf1\x4a\x4c\x51\x2a\xb8\x50\x19\x5e\xc1\x30\x93\x3b\xa0\x71\xa2\xe7\x88\x1b\xb7\x60\x2f\x1f\x39\x84\xf5\xd1\x61\xc4\xf0\xea\xc6\x65\x12\xc5\x4d\xf7\x5d\x42\xb5\x47\x86\x66\xd0\x50\x9c\x3c\xb9\x78\xfe\x2c\x7a\xea\xd2\x48\x71\x17\xc0\x2d\xf0\x86\xec\x16\xcb\xc7\x3b\xc6\xa7\x3e\x72\x41\xb6\x07\x9a\x27\x00\x3d\xa9\xb6\x2b\xc8\x79\xae\x80\xcc\xd1\x09\x06\x73\xb9\x44\x91\x90\x04\x64\xc5\x26\x60\x95\x01\x2a\xd1\xed\xe1\xa7\x3d\x6b\xd4\x87\x78\x5d\xb4\x7e\xa8\x0d\x69\x14\x0f\xea\xe6\x8c\xb0\xa1\x61\x6a\x1c\x9d\xcc\xd1\xc5\xf9\xf9\xcf\x2e\xcb\x8a\x80\x60\x8d\x0f\x53\xe9\x64\x0c\xc8\x2d\x6d\x14\x0f\x81\x1c\x18\xd0\xb8\x4d\x24\x18\x19\x24\x32\x5e\x5a\xc5\xdf\x79\xf8\x42\x42\x3a\x68\xcf\xf6\x7c\x34\x18\x3a\x73\xdd\x02\x52\xd7\xe1\xe6\xf7\x5b\x0a\x84\x62\xf4\x38\x93\xb0\x06\xa9\xea\xc9\x53\xab\x04\x52\x98\x23\x82\xe5\xcd\xa9\x93\x14\x7c\x89\xa2\xe3\xe8\x27\x6b\x6c\xae\xae\x4a\xae\xbb\x9f\x5c\x60\x73\xf9\x88\x0e\x3b\xbd\xdf\x93\x2f\x9f\x9b\x6b\x88\xb2\x1d\x22\x27\xcf\x7e\x35\x97\x8f\xd4\xf1\xfd\x17\xe7\xe6\xf2\x11\x7e\x9f\xff\x0f\x39\xf6\xc9\xd3\x73\x73\x79\xa9\xb7\xbe\x70\xd0\x1b\x87\x3c\xf2\xe4\x97\x27\xe6\xda\x8f\xe5\x08\xff\x31\xbf\xd2\xe7\x4d\x89\x4e\x99\xe3\x1a\xad\x15\xa4\x32\xcf\xb6\xc1\x61\x78\x50\xbb\xfe\xdb\x98\xbd\x91\xd6\xc9\xef\x2d\xd1\x55\xca\x77\x27\x46\xc3\xbd\x0e\x24\x70\x02\xe6\x10\x7b\x8e\x44\xa6\x69\x4a\xbf\xc1\x1f\x10\xd3\x88\x32\xaa\x37\x2f\x7d\x0a\x5b\x5b\x3b\x1a\xd7\x89\xa5\x12\xd9\xca\x48\xae\x3a\x5b\x83\x33\x9c\x29\x98\xa3\xe6\xae\x23\x68\x7b\xaf\x93\x29\xd2\xc4\x11\xc5\x28\x37\x05\x79\xe5\x7d\x17\xe1\xe5\x33\x9b\x21\x9a\x5e\x73\x65\x98\x10\xab\xd0\x79\xd5\x8b\x2e\x7a\x24\xad\x19\x1a\xe8\x74\x17\x38\x9f\x21\x92\xd0\xc6\xad\x01\x59\xdd\xa1\x62\x3f\x90\xf3\x01\x3e\xce\x38\xbb\x2e\x06\x76\x05\xee\x03\xd8\xce\x1c\x66\x34\xe6\x73\xc4\x60\xed\xd4\x28\xb7\x20\x4d\x39\xc1\x1a\x8a\x48\x68\x2d\x52\xbf\x68\xd7\xba\xee\x58\x2d\x32\xff\x40\xb3\x25\xea\x23\x1f\xf2\x57\x37\x3e\x1d\xbf\xf0\xd3\x0e\x7a\x78\x3b\xb4\x7a\xab\xc6\xe9\x9e\xc9\x74\x86\x54\xcd\xa7\x5e\x05\xc3\xdd\xb2\x79\xd8\xd7\x77\x8b\xeb\x5e\x66\xe6\x90\x43\xcb\x39\x37\x79\x2d\xa1\x8c\x3c\xbe\xe4\xa7\x48\x93\x69\xa7\xdf\xec\x06\x3d\x54\x63\x2d\xdd\xcd\xfb\xc7\xc2\x09\x2e\xc6\x01\x0a\x2e\x8e\x84\xe4\x4b\xa9\x7d\x44\x29\xd6\x92\xde\x23\x4d\xe6\x5c\xe8\xc7\x73\x86\x95\xae\x2c\x75\x3a\x75\x49\x92\x1e\x89\x3f\xf8\x02\x59\x67\x8a\x4e\x98\xb7\x45\x57\x42\x03\x5f\x00\x0f\xd6\x4b\x36\x00\xc5\x2d\xc8\x35\x13\x77\x73\x04\x8c\xd1\x4c\x51\xd5\x25\x6a\xfa\x83\xfb\x39\x4a\x28\x21\xc0\x5f\xee\x8d\xb5\x94\x12\xc2\xba\xa9\xb0\xb9\x0d\x8d\xb7\x52\x02\xc7\x26\x8a\x5a\x81\x5f\x7f\xfd\xd9\xcf\xd6\x66\x88\xc0\xa4\x10\x54\x8c\xcc\x30\xfd\xe1\xd6\xc2\x7b\xc6\xdb\x7e\x3f\x03\x9f\xd1\x3b\xbe\xd3\x2a\x8c\xf6\x05\x76\xbf\x9e\x6c\x67\xf8\xf0\x7c\x70\xf2\xab\x2f\x0f\x14\xba\xf2\x63\xe9\x70\x79\xde\xe1\x62\x7e\x29\x96\x31\xe5\x41\x95\x60\xe7\x28\x7c\x36\x28\xa7\x2e\x39\xf6\xa9\xda\x54\x25\x3e\x65\x87\x42\xcb\xad\xaa\x4e\xbd\xd3\x8f\x73\x2d\x1c\x7e\x76\x21\x9e\xa3\x27\xd9\x3d\x52\x82\x51\xe2\xf2\x6b\x55\x46\x0e\xcf\x4a\xe7\x39\x3a\x47\xe7\xe8\xc9\xf0\xa2\xb6\xd5\x58\x13\x7f\x60\x1e\x30\x57\xa4\xed\x4b\x83\xc2\x67\x6f\x2d\xb2\x7d\x01\x6d\x52\xe0\x96\x81\xa9\x21\x9c\x16\x17\x50\xad\x6c\xc5\xd5\x31\x48\xdb\x10\x55\xc9\x7b\xea\x17\xba\x12\x6c\x2b\x62\xda\x7d\xf4\x8b\x33\x41\xf7\x03\xf2\xec\x81\x92\xc9\x8a\xe6\x53\x87\xe2\x50\x15\xe8\xee\xfe\x4f\x5f\xf6\x47\x38\x8b\xa4\xb3\xd5\x3f\x7d\x39\x36\xbe\x3d\x89\xe9\x4e\x62\x7f\x19\x11\xb6\x4f\x70\x6b\x6d\xea\x27\x54\x3c\x98\xc0\x03\xe9\xa2\x8d\xc7\x1a\x6a\x60\xe2\x46\x24\xa6\x1e\xab\xef\xe4\x73\x04\xe2\x2a\x83\xa1\x62\x30\x48\x2f\xf7\x05\xa9\xc6\x52\x07\x04\x6f\xa6\xcd\xbd\x41\x6d\x1f\x44\x16\x78\x7c\x2b\xa5\xbc\x39\x45\xe8\xa7\xc3\x71\x93\x70\x8b\x25\xc5\x5c\x07\x3c\x4f\x41\xd2\xd5\x1c\x69\x1c\xe5\x0c\x4b\xd3\xa0\xbc\x30\x67\x67\x88\x72\x95\x51\x09\x04\x45\x1b\x94\x68\x9d\xa9\xf9\x6c\xb6\x52\x2a\xd0\x92\xae\x6e\x94\x7d\xd3\xa5\x38\xcd\x32\xd0\xca\xb4\xcf\x32\x69\x8a\x20\x1d\x30\xc1\xe3\x20\x97\x4c\x05\x6b\x29\xd2\x20\x92\x80\x6f\x28\x8f\x03\x91\xeb\x40\xac\x83\x95\xe0\x1a\x53\x0e\x72\x86\xce\x66\x5b\x79\x21\x17\x1a\xd4\x14\x85\x64\xf7\x95\x9b\x63\x87\xd9\x19\xba\x4e\x40\x01\xc2\x12\x90\x86\x55\xc2\xcd\x4a\xce\x36\xa6\x30\x46\x0a\x1b\x1b\x46\xb9\x46\xb9\x02\x14\x09\x9d\xb4\xb9\x77\x4a\x03\x13\x19\x73\x64\x61\x05\x77\x42\xb6\xe6\xd8\xfc\x4c\x8b\x87\xa4\x43\x13\xa4\xca\x8e\xac\x74\x6b\x08\x31\x73\x2a\x81\xd9\x19\xfa\xc8\x95\x36\x55\xbb\x01\xa5\x13\xaa\x10\x17\x3c\x50\x1a\x73\x82\x25\x41\x82\xc3\xdc\x85\xd9\xe7\xeb\x01\x30\x3b\x43\xaf\x09\x51\x08\xa3\x64\x93\x25\xc0\xd1\x5d\x02\xc6\x28\x09\x20\x43\x5d\x01\x52\x53\xf3\xf1\x93\xca\x33\xf3\xea\x11\x08\x7a\xfc\x59\x54\x07\xa4\xa7\xae\x4c\xa3\x4f\xc5\x48\xf9\x56\xae\x20\x15\xdf\xf6\xf6\xdf\x41\x74\x43\xf5\x3e\x92\x81\xae\x76\x5c\x54\x98\x87\x8b\x01\x95\x62\xc6\x40\x76\xf9\x8a\x0c\xaf\xa8\xde\x98\xed\xde\x2f\xcf\xfc\x7c\x3b\xdf\x02\xa3\x62\x68\xf8\xc0\x68\x8d\xe3\xa1\xb5\xf4\x1c\x9d\x87\x4f\xdc\x78\xac\x17\x13\x89\x09\xcd\x95\xe1\x7b\xf9\x0c\xd2\x71\x85\xc5\xbe\x63\x90\xbd\x61\xde\xc6\xdb\x7e\x4d\x8a\x8a\xe1\xe4\xe6\x56\xae\x1e\x1e\xcd\x3b\xac\xfe\xea\x5d\xc3\x3e\x59\x3d\xbf\x7c\x71\xf9\xa2\xcd\xc4\xfc\xbf\x98\xd5\x67\xfe\xad\x17\x00\xc8\x9e\xff\xbd\x9a\x64\x92\xf2\xf6\xeb\xe6\xdf\x32\x1c\xc3\x40\x22\xbd\x78\x96\xa6\xe8\xf2\x3c\x4d\xbd\x28\xed\x16\xa9\x3b\x70\xcf\x7b\x84\xd6\xc0\xe4\x62\xd8\xc7\x2e\x5e\x0c\x0d\xba\x9c\xa2\xe4\xc9\x14\x25\x4f\xf7\x0c\x7e\x9a\xe9\x63\x4f\x6f\xfa\xdb\x1c\x63\x90\x2a\xfe\x03\xca\x15\x25\x30\x47\xf8\x56\x50\xff\x4a\xa4\x25\x2a\x8e\x1c\xed\x50\xe1\xb5\x06\xb9\x27\x2c\xed\x1b\xd4\x61\x21\x11\xac\x85\x1c\x2d\x64\x2f\xd1\x1e\xbc\x84\xaa\x8c\xe1\x8d\x5d\xb6\x58\xf3\x6a\x38\x30\x31\xe4\x8f\x02\xed\x71\x8e\x07\x02\xdd\x96\xb2\xee\x9f\x50\x1f\x2d\x65\xbc\xd6\x26\x3d\x0c\xab\x7c\x68\x57\xe5\x03\xd6\x99\xf3\x91\xda\x77\x02\xdc\xf7\x02\x30\x57\x5a\xa4\xad\x17\x80\xf5\xd7\xeb\xdd\x2f\x77\xde\x36\x54\x35\x91\xef\x4d\xa1\xb9\xec\xd7\xed\x3b\x8a\x46\x6e\xfb\x7d\xe4\x08\x21\x1f\x28\x83\x36\x1b\xaf\x20\xca\xcd\x29\xe7\xdb\xe5\x12\x1d\x14\x59\x14\xc0\x49\x59\xfe\x77\x00\xa3\x14\x1a\x15\x54\x35\x00\x00")
 
 func reportsHtmlCommonsGohtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -86,12 +86,12 @@ func reportsHtmlCommonsGohtml() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "reports/html/commons.gohtml", size: 10317, mode: os.FileMode(0644), modTime: time.Unix(1658243185, 0)}
-	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x58, 0x8, 0xfe, 0x51, 0x34, 0x84, 0xe2, 0x7b, 0x42, 0x1, 0xe3, 0xda, 0xe8, 0x81, 0x12, 0x0, 0xb6, 0xbe, 0x3e, 0x74, 0x8f, 0x23, 0x49, 0xbb, 0xa4, 0x35, 0x52, 0x52, 0x49, 0x1d, 0xe5, 0x52}}
+	info := bindataFileInfo{name: "reports/html/commons.gohtml", size: 13652, mode: os.FileMode(0644), modTime: time.Unix(1792325107, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xb5, 0x78, 0x5a, 0x0, 0x42, 0x28, 0xad, 0x96, 0xde, 0x21, 0x90, 0x30, 0x20, 0x41, 0x94, 0x3b, 0x94, 0xdd, 0xd4, 0xad, 0x19, 0xf3, 0x93, 0xad, 0x47, 0x5d, 0x8f, 0x37, 0x2f, 0xfc, 0x37, 0x34}}
 	return a, nil
 }
 
-var _reportsHtmlDefaultGohtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xdc\x59\x5d\x8f\xa3\x36\x17\xbe\xcf\xaf\x38\x2f\x9a\x8b\x99\xd5\x0b\x68\xee\x56\x2b\x26\x52\x77\xa6\xdb\x56\xda\x6d\xa5\x49\xb6\xbd\x76\xc0\x09\xee\x02\x4e\xb1\xd9\xd9\x08\xe5\xbf\x57\xb6\xc1\x60\xb0\x09\xc9\xa4\x5a\xb5\x57\x09\xd8\x3e\x1f\xcf\xf9\x7a\x9c\xd4\xb5\x0f\xe1\x9b\x1d\xe5\x87\x3d\x7e\x07\x3b\xc2\xd3\x6a\x13\xc4\x34\x0f\xff\x44\x05\xa3\xe5\x2e\xe4\x34\x0f\x77\xd4\x17\x1f\x25\xde\xd3\x92\x07\xcf\x98\x55\x19\x7f\x5f\xc5\x5f\x30\x7f\x13\x82\x7f\x3c\x2e\x16\x75\x9d\xe0\x2d\x29\x30\x78\x6b\xb4\xc9\xb0\x5a\xf4\x8e\xc7\x05\x00\xc0\x75\x74\x28\x49\x37\x1b\xf9\x0e\xde\x3d\x40\xd0\xbd\x64\x29\x7d\xf9\xf1\x1b\x8a\xe5\x7b\x25\xe2\xb7\x3d\x27\xb4\x60\xc1\xaa\x5d\x7a\xaa\x4a\x24\x5f\xc1\xe0\x5c\xbe\xe7\x87\xf1\xb9\x67\x65\xc8\xaa\xdd\x21\xbc\x3c\xd7\x99\x94\xe7\x99\x92\x1a\x36\x62\x4d\x5f\xe8\x9e\xb3\x91\x66\x68\x35\x45\x5c\x40\x09\x71\x86\x18\x7b\xf0\xe4\x83\x4f\x93\xc4\x5b\xca\x55\xb5\x23\xc5\x28\xe9\x3f\x97\xdd\x43\xb3\x41\x9f\x4f\xfd\x17\x92\x60\x6f\x19\xb1\x3d\x2a\xf4\x5b\xc2\x33\xec\x2d\xeb\x3a\x58\x8b\x6f\xc7\x63\x14\x8a\xe5\x65\x14\xf2\xd4\x14\x55\xd7\x64\x0b\xd2\x62\x89\xc9\x0a\x65\x98\x35\x9e\x38\x34\xe6\xb4\xc0\x07\x5f\x58\x88\x4b\xa1\x82\xdc\xbf\x2d\xc0\xfb\x21\xa7\x55\x21\x52\x43\xaa\x80\xba\xc6\x45\x32\x90\x33\x50\xf5\xb9\x58\x97\x28\xfe\x82\x87\xdb\x86\x0e\x92\x1c\x8f\xb4\x3d\x21\x92\x1d\xa0\x2a\x7c\xae\x44\xb4\x7a\x07\xfa\x4e\xda\xf0\x5a\x0b\xce\x55\x7f\x52\x6a\x93\xcc\x0e\x81\xc2\x76\x5d\x13\x16\xa3\x67\x06\x73\x56\x40\x55\xdd\x21\x23\xac\x16\x85\x63\x1f\xe7\xf8\xa9\x84\x27\xd3\xde\x9a\xa2\xa3\xb0\x5f\x05\x62\xff\xa0\x46\x36\x34\x39\x74\xcf\x75\x5d\xa2\x62\x87\xa1\x69\x2c\xc1\x63\x4a\xb2\x44\x35\x9e\x21\x24\x12\x35\x5a\xc2\x6d\x41\x39\x04\xb2\x2b\xac\x68\x55\xc6\xf8\x0e\xba\x4e\x62\x75\xb2\x1c\x23\xa2\x16\x12\xa3\xf4\x78\xb2\x5c\x58\x37\x9e\x15\xaf\xc4\x88\x97\xc0\x92\xe3\x7c\x9f\x21\x8e\x41\xbd\xfa\x48\x18\xf7\x20\x90\x52\x82\x67\x5a\x15\x89\xc8\x6c\xa5\xde\xae\xdd\x1e\x3c\x8b\x65\x53\xb5\x3a\xb0\x4e\xc4\xdb\x34\xae\x29\x92\x5f\x8a\x2d\xf5\x20\xf8\x09\x73\x59\x3b\x3d\x91\x57\x31\xf1\xea\x06\x9e\x61\xde\xf9\x2a\x75\xea\x43\xd0\xd6\xfc\x8c\x4c\x99\xaa\x7d\x07\x2c\x53\x39\x05\x17\xe4\x55\xa3\xde\x8d\x09\x9c\x08\x9b\x03\x9a\x9c\x14\x2d\x10\x1d\x24\x22\x16\x27\x15\x3a\x7b\x50\x38\xac\xcf\xe1\x4e\x4b\x8b\x31\x9b\x48\xc4\xb7\x94\x72\x63\x10\xb7\x76\x97\xf4\xc5\x67\xd5\x86\x53\x8e\x32\x6f\x34\x9d\x75\xa3\x5b\xcb\x75\x77\x37\x9f\x8c\xd3\xb0\x47\xc3\xb0\x53\x5b\x03\xd5\x76\xbc\x71\x1f\x10\x66\xcd\x1f\xcb\x30\x7b\x76\x39\x2a\xa9\x35\xc4\x5e\xf1\x17\x0f\xeb\xab\xdb\x75\xa6\x55\x03\x7d\x30\x50\xda\x4f\xe4\x56\x53\x3f\x9f\xff\xd1\xc9\x7e\x8d\x8c\xe9\x0a\xce\x9e\x2f\x57\xc0\xa0\xaf\xe2\xbc\xa1\xdf\xd5\x63\x14\x4a\xea\xbc\x5c\xe8\x26\xdc\x5d\x56\x3e\x21\x5e\x92\x6f\xff\xe1\xdb\x8a\xed\x0e\x91\x20\x8e\x40\x5f\x27\x9a\x6f\xb9\x44\xe2\xc2\xbb\x85\x3a\xec\x37\x97\x89\x8b\x2f\x18\x2f\x84\xa7\x3a\x0d\x3e\x90\x92\xf1\x5f\x69\x21\xdd\x91\xa4\xcc\x9a\xed\xd7\x8a\x95\x29\x55\x51\xc2\x29\x2e\xe8\x48\xf0\x5e\x6e\xf7\x68\xdd\x4c\x42\x7c\xc9\x45\xc0\x3e\x37\xfe\x55\x1c\xd8\x9e\x46\x20\x3f\x1e\xbc\x0e\xc8\x31\xa8\x8e\x31\x3f\x3f\x7a\x60\xe3\x18\xce\x9d\x4a\xfa\x98\x9b\xfd\x41\x78\x2a\x8b\xd6\x20\x69\x6e\x85\x97\xd2\x44\x0d\x54\x4c\x33\xcd\x29\xa0\xeb\xab\x9a\x66\xd8\xb0\x9b\xe0\x60\x4e\x4a\x35\xc9\x1e\x7d\x30\x66\x91\xb5\x92\x0c\xfb\x37\xa5\xd9\x1a\xea\x1a\xac\x97\x3c\x38\xaa\x58\x9f\x66\x7a\xb2\x93\x5c\x44\x2e\xed\x45\xf9\x3d\x99\xa0\xbd\x97\xce\xa1\x87\x4d\xed\x92\xff\xdf\xc4\x22\xe3\xc5\x70\x70\x37\xd1\x53\x55\x51\xd7\x37\x6d\x20\x84\x20\x56\xe5\xf2\xc0\xef\x28\xab\x30\x6b\xe5\xc2\x0d\x99\x3b\xeb\x1d\x0e\xeb\x33\x32\x84\x75\x7d\x6b\x30\x81\xa4\x17\xec\xbb\xe9\x40\x9f\x97\x87\xaf\xca\x41\xc3\xac\x19\x39\x38\x71\xdf\xb8\xf8\x17\x20\x70\x94\xbe\x0b\xe3\x39\x34\xd3\x3a\x55\xe7\x61\xfa\x3a\x3c\xa7\x08\x9f\x1d\x56\x2b\x50\xd3\x93\x6f\x1e\x11\xfc\x2e\x14\xf0\x4c\x2a\x97\x90\xaf\x2d\xcc\x4a\xa6\xd7\x9f\xe0\x64\x0b\xa8\x48\x2c\x74\x52\x71\x5c\xf9\xb3\x3c\x83\x5b\xc2\xd4\xb3\x2e\x65\x39\xc8\x3b\x7b\xee\xee\x46\x03\xbf\x1b\x78\x06\x5d\xd6\x12\xcc\xf6\x98\x31\x2c\x92\x07\xff\xd5\xc5\x17\xef\x79\x0a\xf7\xc3\xeb\x58\x5f\x70\xff\x4f\x83\xf6\xdc\x48\xac\x8d\x89\x04\x2e\xbe\xa1\x01\xb9\x9d\x46\xf7\xae\x61\x32\x4f\x88\xe3\x67\xd1\x48\x95\xc4\x21\x0a\x3a\xa1\x7a\x41\x90\x2d\x1a\x64\xf7\x95\xd4\x44\x8b\x10\xf9\x9b\x90\xaf\xb3\x49\xdf\xd8\x37\x7b\xbc\xd9\x54\x13\x3d\x45\xb8\xaf\xc2\x95\xfa\x31\x6b\xc3\x15\x38\x7f\x76\x73\xb5\xbf\x11\x36\x53\x53\xb6\xd9\xae\x6b\x36\xfa\x5f\x42\x63\x51\x98\x90\xf2\x3c\x5b\x2e\x22\xf1\x01\x19\x2a\x76\xb2\xfb\x88\x2f\xef\x11\xc3\x20\x39\x4f\xd4\xf1\xde\x28\xc7\x1c\x41\x9c\xa2\x92\x61\xfe\xe0\x7d\x5e\x7f\xf0\xdf\x36\x78\x36\x37\x0f\x33\x51\x1e\x2b\xc6\x69\xde\x60\xd8\x1b\xe7\xe2\xc5\xb2\xae\x21\x00\x39\x8d\xe5\xe3\x02\x86\xe4\xad\x0f\x54\x4c\xf3\x9c\x16\x8f\xab\x55\xd7\x5e\x7a\x8b\x52\x4d\xb3\x18\x85\xca\xde\x48\x71\x8a\xc5\x55\xfe\x6b\x9a\xfc\x9f\xa9\xbd\x74\xc9\x1f\x0c\x7a\x2e\xb7\xc5\x1a\xa5\xf7\x22\x95\x84\xab\xe9\xbd\xd1\x39\x47\xe7\x9e\x30\x8b\x4b\x22\x65\xeb\xd3\xfb\x36\x2b\x93\x6e\x51\xe4\x26\x43\x5b\xfc\xf3\xfa\xd3\x47\x90\x92\xf7\x86\x60\xf3\xf7\x8b\x2a\xcf\x51\x79\x00\x0b\x72\xcd\x92\x07\x4d\xef\x95\xae\x2a\x29\xd6\x34\xd5\xbb\xa2\x50\xc1\x1b\x49\xcc\x96\x7f\x07\x00\x00\xff\xff\xb9\x68\xd8\x77\x02\x1d\x00\x00")
+var _reportsHtmlDefaultGohtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xdc\x59\x6f\x6f\xa4\x36\x13\x7f\xbf\x9f\x62\x1e\x94\x47\x4a\x4e\x5d\x50\xde\x9d\x4e\x04\xa9\xb9\xf4\xda\x4a\x77\xa9\x94\xe4\xda\xd7\xde\xc5\xbb\xb8\x07\x78\x0b\xe6\x72\x29\xe2\xbb\x57\x36\x36\x60\x63\x0c\x9b\x6c\x5a\xb5\xd9\x95\x36\x60\x7b\xfe\xfc\x3c\xf3\x9b\xc1\xd4\xf5\x1a\x82\x37\x7b\xca\x9e\x0e\xf8\x1d\xec\x09\x4b\xaa\x8d\xbf\xa5\x59\xf0\x3b\xca\x4b\x5a\xec\x03\x46\xb3\x60\x4f\xd7\xfc\xa7\xc0\x07\x5a\x30\xff\x0e\x97\x55\xca\xae\xab\xed\x17\xcc\xde\x04\xb0\x6e\x9a\xd5\xaa\xae\x63\xbc\x23\x39\x06\xef\x01\x6d\x52\xdc\x0e\x7a\x4d\xb3\x02\x00\x38\x8d\x8e\x56\xd2\xd9\x46\xdc\x83\x77\x57\xe0\xf7\x37\xcb\x84\x3e\xfe\xf0\x0d\x6d\xc5\xfd\x56\xc4\x2f\x07\x46\x68\x5e\xfa\xf7\x6a\xe8\xa6\x2a\x90\xb8\x05\xc6\xba\xec\xc0\x9e\xc6\xeb\xee\x84\x94\x76\x39\x9f\xc1\xbd\x3c\xd6\x99\x84\x65\x69\x2b\x35\x90\x62\x75\x5f\xe8\x81\x95\x23\xcd\xa0\x34\x85\x8c\x43\x09\xdb\x14\x95\xe5\x95\x27\x2e\xd6\x34\x8e\xbd\x48\x8c\xf2\x6f\xc8\x12\x8c\xe2\xe1\x75\xd1\x5f\xc8\x09\xdd\xfa\x64\xfd\x48\x62\xec\x45\x61\x79\x40\x79\x77\x97\xb0\x14\x7b\x51\x5d\x33\x9c\x1d\x52\xc4\x30\x78\x2d\xc0\x0f\x62\x80\x63\x1c\x06\x7c\x41\x14\x06\x2c\xd1\x85\xd7\x35\xd9\x81\xf0\x41\xa0\x74\x8f\x52\x5c\x36\x8d\x36\xc5\xb0\x21\xa3\x39\x7e\x5a\x73\x9b\x71\xc1\x95\x92\xcb\xb7\x39\x78\xdf\x67\xb4\xca\x99\xc7\x35\xb1\x24\x82\xba\xc6\x79\xdc\x34\x2e\x55\x9f\xf3\x87\x02\x6d\xbf\xe0\xd8\xad\x8e\x91\x0c\x8f\xb4\xdd\x20\x92\x3e\x41\x95\xaf\x59\x2b\x42\xe9\x35\xf4\xcd\xda\xf0\x52\x0b\x5e\xa8\xfe\x9a\xa4\x29\x8f\x88\x67\xe8\x57\x4b\xad\xaa\x97\x08\xb8\xa5\xf9\x7a\xe3\x12\x62\xb3\x7f\x4e\xa8\x4a\xcf\x09\x81\xa7\x72\xfe\x33\x23\x29\xf9\xd3\xa5\x69\x0a\xfa\x8e\x63\x2c\x5a\x17\xa6\xc2\xa2\x74\x10\x3a\x00\x69\x49\x61\x51\x38\x36\x73\x89\xfb\xad\xf0\xb8\x2a\x96\x23\x10\x06\x43\x56\x09\x83\x11\xe7\x6c\x68\xfc\xd4\x5f\xd7\x75\x81\xf2\x3d\x06\x49\xd4\xfe\xfb\x84\xa4\x71\x5b\x0f\x4c\x48\x04\x6a\xb4\x80\xf3\x9c\x32\xf0\x05\xcb\xde\xd3\xaa\xd8\xe2\x0b\x89\xb6\xe4\x5d\x35\x7f\x92\xe6\xd4\x27\x64\xf1\x0c\x95\xb1\x38\x5a\x59\x97\x1e\xb5\x83\xb1\xb6\x83\x3a\x7d\x8a\x5b\x1f\x49\xc9\x3c\xf0\x05\x25\xfa\x77\xb4\xca\x63\xce\x55\x61\xc0\x06\xc0\xb9\x30\x77\x58\xe6\xe2\x3e\xc3\x3a\x9e\xfd\xba\x71\x92\x74\x7e\xce\x77\xd4\x03\xff\x47\xcc\x04\x17\x0d\x44\x9e\xc4\xc4\x93\x1b\x78\x84\x79\x4b\xec\x73\xd0\xc7\xac\x81\x5d\xea\x80\xaf\xc4\x28\xe6\x72\x99\xb7\x54\xe6\x2d\xcd\x8f\x11\xeb\xf4\x7a\xa9\x4e\x53\xd1\xeb\x40\xb8\xa3\x45\x86\xd8\x6d\x95\x6d\x70\x01\xfe\x80\x86\x9b\xe6\xff\xcf\x74\x70\x9e\x97\x27\xac\x77\x65\xf7\x73\x32\x5c\xaa\x9f\x76\x63\xe8\x8a\xba\x76\x28\x55\x98\x65\x24\x57\x9b\xd3\x6f\x13\xcf\x8a\x59\x85\x53\xca\x74\x32\xb7\xcd\x34\xaf\xc3\xc0\x20\xf8\x90\xed\x28\x65\xc3\xeb\x42\xd9\x5d\xd0\xc7\x75\x59\x6d\x18\x65\x28\x1d\xb4\xa9\xb2\x30\x75\x45\xe8\x41\x8c\xdb\x6b\xcf\xec\x3e\x99\xf5\x13\xcc\x2a\x6a\xdd\x28\x55\x8d\xc6\x8c\x9c\x44\xa6\xc7\x2e\xd2\x05\x63\xde\x74\xbd\x9d\xe0\x34\x65\x88\x9d\x7b\x67\x4a\xb1\xc5\xb2\xd7\xb2\xeb\x65\x56\x29\x7a\x98\x31\x0b\x0c\xdb\x86\xf1\xae\x0c\x52\xa2\x86\xe1\x6f\xb3\xea\x19\xa2\x2d\x64\x3b\x25\xdd\xe6\xf3\x33\x34\xce\xab\x39\x05\x8c\x1a\xd5\x2a\xd5\x23\xc6\xfd\x3b\x1b\xdf\x53\x24\x6d\xcf\x79\xf6\x94\x3d\xc1\x96\x0c\x55\x38\xc1\x19\xf5\xc4\x3d\x25\x86\x81\x78\x52\x8f\x56\x6a\xcd\xe0\x6c\xe4\x13\x62\x05\xf9\xf6\x1f\x3e\x1c\xb1\x1d\x59\xc4\x88\x21\xe8\x4e\x2f\xe4\x7f\x99\x40\xe2\x99\x47\x19\xed\xe2\x35\x13\x7d\xfd\xc4\x79\x86\x2f\x0e\x30\x9c\xa7\x17\x8f\x84\x25\x5d\x18\x7c\x20\x45\xc9\x6e\x69\x2e\xdc\x11\xcf\x2c\x4d\xa3\xcd\x3f\xed\x5e\x0d\xff\xd4\x13\x93\xeb\x51\xc9\x82\x82\x11\xdb\xbd\xc3\xa6\xa7\xd3\xd9\x3d\xbe\x37\x2d\xdf\x55\xba\xff\x55\x8f\x88\xf6\x30\x02\xf1\x73\xe5\xf5\x40\x8e\x41\xb5\x0a\x3c\x66\xf7\xac\x6d\xde\xe4\x4c\xfe\xb5\xb5\xec\xbf\x11\x96\x88\x7c\xd6\x7a\xf7\x69\x85\xb3\x4d\xe2\x1c\x50\x5b\x9a\x76\x6d\x1d\xf4\xbc\xda\x75\x7a\x36\xec\xac\x32\xf9\x57\x67\x61\x8d\x7e\x1d\x4e\xf0\xcc\xd3\x6a\x11\xac\x1d\xb3\xf9\x37\xdc\x14\x3a\x35\xd4\x35\x58\xcf\x40\xa0\x69\x1a\xb3\x38\x68\x66\x75\x55\x81\x4b\x73\x39\x36\x4e\x27\xf5\x67\x4f\x4a\x3d\x6d\x6c\x32\x5e\xaf\x19\xb7\x26\xc1\xa2\x0e\x5d\xe6\x2e\xf9\xee\x6c\xcb\x23\x9e\x9f\x5f\x4f\x93\xe8\x5c\x56\xd4\xf5\x99\xda\x08\x2e\xa8\xac\x32\xb1\xe0\x57\x94\x56\xb8\x54\x72\xe1\x8c\x58\x96\x5a\x6b\xfd\x84\xc3\xea\x23\x02\x22\xaa\xeb\xf3\xe1\x66\x77\x16\xf0\xae\xec\xc2\xbd\xd1\xc7\xc5\xe1\x8b\x62\xf0\x2c\x3e\x2e\x06\xcd\x58\x99\x8e\x3d\xdb\x4c\x3b\xf1\xc3\x44\xea\x4f\x61\x6c\x78\x20\xc3\x62\x26\xc7\x97\x63\xfa\x32\x3c\x2d\xd6\xcc\xc0\x6a\x05\xca\x5d\xf9\x96\x35\x82\xff\x48\x0b\x78\x64\x2b\x17\x93\xaf\x0a\xe6\x16\xb9\xc1\x7e\x8b\xb2\x8c\xf2\xd8\x90\xc5\xfb\xc1\xb6\xc7\x15\x6f\x01\x4b\x38\x27\x65\x7b\xad\xd0\x6f\xcf\x7a\x7b\x7b\x2e\x2e\xa4\x91\xb6\x82\xa7\xb5\xcb\x9d\x84\xc1\x82\xba\xc6\x69\x89\x81\xec\x00\xff\xa1\xc6\xfd\x1b\x7c\x60\x09\x5c\x82\x43\xf0\xf0\x1d\xa5\x5a\x37\x12\x3b\x5a\x4f\x76\xb2\x03\x31\x46\x34\x40\xce\xdd\xe8\x5e\xc8\x4e\xe6\x06\x31\x7c\xc7\x89\xb4\x95\x68\xa2\xa0\xfe\x86\x9b\x20\x8a\x2c\x08\xf6\xe5\xe5\xaa\x17\xc1\x69\x21\x26\x5f\xa3\x95\xb1\xd8\x9a\xe7\x56\xdf\xec\xfb\x5d\xba\x48\x54\xb3\xca\x9b\x39\x75\x77\x91\xd6\x71\xdd\xd3\x50\x8f\xda\x40\x15\xe7\x4b\xdc\xe7\x1f\x0b\x5a\xe6\xd4\xe1\xb5\x9c\xae\x6e\xad\xc2\xff\xc5\x74\xcb\x53\x15\xf8\xeb\xdd\x68\x15\xf2\x1f\x48\x51\xbe\x17\x3d\x06\xff\xe7\x1a\x95\xfc\x99\xdd\xe3\x83\x5d\x27\x1c\x66\x98\x21\xd8\x26\xa8\x28\x31\xbb\xf2\x3e\x3f\x7c\x58\xbf\x95\x08\xcb\x67\x11\x3d\x74\xde\x57\x25\xa3\x99\x6c\xa8\x3a\xcb\x42\x01\x78\x54\xd7\xe0\x03\x87\xb6\xbd\x94\x52\xa4\x85\x26\x50\x5b\x9a\x65\x34\x7f\x7f\x7f\xdf\x13\xce\x60\x50\xa8\x91\x83\x61\xc0\x79\x3f\x5a\x85\xed\x91\xdf\xea\x24\x2f\xbb\x9d\x2f\xba\xd5\x63\x98\x38\xf3\x18\xb8\xac\xd2\x37\x4c\x2e\x79\xb0\x73\x57\x93\x4b\x8d\x4b\x47\xeb\x6e\x70\xb9\x2d\x88\x90\xdd\xad\x3e\xa8\x38\x8d\xfb\x41\x1e\xad\x25\xda\xe1\x9f\x1e\x3e\x7d\x6c\xe3\xf3\xa0\x09\xd6\x4f\x34\xaa\x2c\x43\xc5\x13\x58\x90\x93\x43\x1e\x48\x36\x6e\x9a\x49\x29\xd7\x55\xbc\xc7\xac\xb4\x49\x91\x43\xde\x60\xb5\x35\xc8\x3b\x1d\x61\xd0\x6e\x4e\x18\x24\x2c\x4b\xa3\xbf\x06\x00\x74\x5a\xd6\x00\xc1\x21\x00\x00")
 
 func reportsHtmlDefaultGohtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -106,12 +106,12 @@ func reportsHtmlDefaultGohtml() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "reports/html/default.gohtml", size: 7426, mode: os.FileMode(0644), modTime: time.Unix(1629542127, 0)}
-	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x94, 0x79, 0x5f, 0xcb, 0xd8, 0xe6, 0x63, 0xf, 0x46, 0xed, 0xe6, 0x4b, 0x1, 0x49, 0xb9, 0xd5, 0x52, 0xa2, 0x4b, 0x10, 0x45, 0x97, 0x3, 0x5f, 0x7f, 0x29, 0xcc, 0x5, 0x25, 0x86, 0x39, 0xb8}}
+	info := bindataFileInfo{name: "reports/html/default.gohtml", size: 8641, mode: os.FileMode(0644), modTime: time.Unix(1792324746, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xb3, 0xb0, 0x78, 0xd2, 0x41, 0x37, 0xcd, 0xb9, 0x85, 0xdf, 0x82, 0xbb, 0xbc, 0x79, 0xb8, 0x6c, 0x12, 0xfa, 0x37, 0x8e, 0xbd, 0x7f, 0x18, 0x35, 0x3, 0xf1, 0x27, 0x1e, 0x2b, 0x69, 0x32, 0x7}}
 	return a, nil
 }
 
-var _reportsHtmlTimelogGohtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x57\xdd\xae\xa3\x36\x10\xbe\xcf\x53\x4c\xd1\x5e\xec\x1e\x29\xa0\xd3\xaa\xd2\x6a\xc5\xc9\xc5\x9e\x9f\xb6\x52\xff\xb4\x49\x1f\xc0\xc1\x93\xe0\x2e\xb6\x91\x3d\xec\x69\x84\xf2\xee\x95\x6d\x20\x90\x00\xc9\xd9\x9b\x00\x1e\xcf\x37\x7f\xdf\xd8\x93\xba\x5e\x42\x72\xb7\xd7\x74\x28\xf1\x13\xec\x05\xe5\xd5\x36\xce\xb4\x4c\xfe\x65\xca\x6a\xb3\x4f\xf6\x9a\x84\xc4\xf6\x61\xb0\xd4\x86\xe2\xcf\x55\xf6\x15\xe9\x8b\xff\xb8\x4b\x60\x79\x3c\x2e\x16\x75\xcd\x71\x27\x14\x42\x14\xa4\xd1\xf1\xb8\x00\x00\xb8\x6a\x81\xb4\x4c\xf6\x7a\xe9\x1e\x0d\xfc\x17\xb4\x55\x41\x01\xa6\x81\x0f\x48\xef\xb6\x7e\x0d\x3e\x3d\x40\x7c\x5a\xb4\xb9\x7e\x7d\xfe\x8f\x65\x7e\x3d\x40\xfc\x55\x92\xd0\xca\xc6\xeb\x56\xf4\x54\x19\xe6\x97\xe0\x4c\x4f\x96\x74\xb8\xd4\x0b\xa1\x05\x75\xb7\x63\xa8\xb4\x26\x5d\x6e\x84\xc4\x59\xbd\x6e\x93\x4b\x8e\x53\x4e\xb9\xf8\x06\x59\xc1\xac\x7d\x88\x42\x1c\xd1\xca\x0b\x02\xb2\xd8\x41\x13\x5e\xdc\x37\x39\xd8\xa0\x0d\xbc\x57\x9a\x86\x1b\xd7\xba\x32\x19\x7e\x80\x53\x3c\x67\xaa\xde\x38\xb1\x6d\x81\xad\x79\xff\xb1\xe4\x8c\x18\x84\x57\xcd\x79\xcf\x99\xa1\x62\x8e\x8c\x4f\xc9\xcc\xb8\x20\x38\x9c\xdc\xa1\xdc\x22\xe7\xc8\x81\x04\x15\x08\xa4\xe1\x2b\x62\xd9\x7c\x31\xc5\x83\x75\x0b\xa4\xf7\x48\x39\x1a\x10\x0a\x4a\x23\x14\x21\x87\xbf\x9f\x5e\xec\x5d\x32\x12\x4a\xcf\x33\xc8\x74\x61\x4b\xa6\x1e\xa2\x9f\xa3\x2e\x36\x07\x1e\xad\x3a\xb2\xc4\x1b\xb7\x70\x3c\xa6\x09\xe5\x13\x61\x24\x53\x71\xcc\x06\xe8\xed\x07\x9b\x9c\x11\x2e\x5d\x9e\xd0\x38\xcb\xe2\xfe\xa3\x82\xe8\x89\x11\x46\x73\x76\x6f\x01\x59\x13\x33\x74\x15\x25\xb0\xa7\x4f\xcd\xe3\xf1\x1a\xf2\xb3\xe2\x2d\x6e\x5d\xa3\xe2\xd7\x32\xdd\x66\x57\x8e\x44\xda\x34\xd7\x5b\xa2\xa5\x7c\xf9\x2a\x38\x9e\x40\xfe\xd4\x84\x76\x16\x61\xa6\x4e\xc9\x2c\x4b\xb7\x9a\x1f\xc6\x65\x75\x6d\x98\xda\x63\xd7\x50\x2f\x86\x49\xb4\xcd\x63\x36\x23\x33\xc4\x08\x1b\x78\x1b\xa9\x75\x25\x5c\x72\x76\x70\xb1\xee\xb4\x91\x8c\x1c\x35\x20\xf6\xb5\xf5\x01\x4f\x78\x3e\x09\xe6\xaa\x70\x42\xf3\xa7\xcc\xed\x68\x63\x64\x99\x55\xb8\x70\x41\x97\x8d\x07\x57\xd5\xbc\xad\xf8\x37\xeb\x2c\x95\x38\xc7\xb1\x51\x2d\xa1\xf6\x05\x3e\xb1\xb1\x03\x6d\x5a\xb7\x9f\x94\xe7\x59\x5e\x0f\xf5\xb0\xb0\xb7\x64\xe2\xdc\x90\xab\xe5\x77\x18\xbb\x69\xef\xad\xfb\x6e\x29\xfa\x75\xa4\x5e\x8d\x5b\x82\x49\xa1\xda\xe6\x86\xf7\x46\x57\x8a\x23\xef\x16\xe2\xee\xad\x69\x9f\x0f\x6f\x24\xb3\xf2\x2d\xbf\xaa\xeb\xd8\x37\xff\x35\xed\xe9\xfe\x9f\x8b\x2e\x4d\x66\xfa\x3f\xa5\x9d\xd6\xd4\x31\xbb\xda\x92\x26\x56\x4c\x5e\x85\xa6\xdd\x69\xf4\xeb\xf2\xca\x6e\x38\xbf\xa2\xc6\xfa\xee\xa7\x96\x76\x3f\x36\x21\x9c\xce\xc3\x8d\xc7\x7e\xc3\x89\x9a\xe9\xa2\xf3\x09\x5c\x01\xfd\x4f\xcf\xcd\x61\x39\xbb\xea\xc5\xbf\x20\xdd\x60\x66\xf5\x9d\x07\xb3\x4b\xf0\xa5\x30\x4d\xfc\xd5\xbf\x3a\x9b\x71\x86\x65\x1c\xed\xc9\xfe\x18\x35\x7d\xd7\x73\xf1\xed\x1c\x7b\x78\xd2\x3f\xe6\xa2\xe0\x61\xc4\x1c\x3b\xe8\xeb\x9a\x50\x96\x85\x3b\xa7\xdb\x79\xb6\x1b\x39\x67\xdc\xed\xbe\x1b\x0f\xda\xa5\xc5\x5b\xa6\xe0\x9c\x64\x11\xc6\xca\xa4\x99\x2b\x9b\x21\xb8\xae\xdf\xe9\x92\xec\xc5\xd8\xe9\x47\xcc\xf4\x07\xae\x33\x07\x0f\x4e\x7f\xb5\x48\xdd\x03\x0a\xa6\xf6\x8e\x7a\xfe\xe5\x33\xb3\x6e\x1c\x8d\x9c\xb0\xbb\x2e\x53\x89\xc4\x20\xcb\x99\xb1\x48\x0f\xd1\x3f\x9b\x97\xe5\xc7\x86\xd2\x75\xfd\x2a\x28\x07\x6f\x34\x7e\xac\x2c\x69\xd9\x24\x78\x71\x22\x86\x5b\x70\x2d\xec\x29\xe4\x3f\x16\xfd\x64\x2c\xce\xb3\x99\x69\x29\xb5\x7a\x5c\xaf\x4f\x7f\x10\x7a\x42\x6f\xa4\x11\xa6\x49\xf0\x32\x0d\xfd\xbb\x98\x72\xa7\x1d\xe9\xd3\xfc\xbe\x75\x24\xbf\x1f\xe4\xfe\x42\xef\x09\x6d\x66\x84\xcf\x5e\xa7\x5d\x76\xe3\xd2\x49\xe8\xb8\x65\xd9\x0e\x7f\xdd\xfc\xf1\x3b\x78\xe4\x72\x00\xec\x3a\xda\xc3\xfa\x89\xbf\x92\x92\x99\x03\x8c\xc4\xd5\x88\x22\x68\xfe\xdb\xf8\x62\x76\x28\x23\x54\xeb\xb6\xa5\x49\x88\x3e\xf5\xb4\x58\xfd\x1f\x00\x00\xff\xff\x3a\xcd\xb5\xcf\xb0\x0d\x00\x00")
+var _reportsHtmlTimelogGohtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x57\x5d\x8e\xdb\x36\x10\x7e\xf7\x29\x58\x61\x1f\x92\x45\x2c\x21\x2d\x0a\x04\x01\xd7\x40\xf7\xaf\x2d\xd0\xb4\x45\xec\x1e\x80\x36\xc7\x12\xbb\x92\x28\x90\xe3\x6c\x17\x04\x81\x9e\xa0\x2f\x7d\xed\xe9\x72\x92\x82\x14\x69\xc9\x2b\x59\xf6\xae\x25\x58\x12\x87\xf3\xcd\x0f\x67\x86\x1c\x63\xe6\x24\xbb\xcc\x25\x3e\x35\xf0\x91\xe4\x02\x8b\xdd\x3a\xdd\xc8\x2a\xfb\x93\xd5\x5a\xaa\x3c\xcb\x25\x8a\x0a\xe2\x43\x41\x23\x15\xa6\xd7\xbb\xcd\x03\xe0\x67\xff\x71\x99\x91\xb9\xb5\xb3\x99\x31\x1c\xb6\xa2\x06\x92\xb4\xd4\xc4\xda\x19\x21\x84\x9c\x94\x80\xb2\xca\x72\x39\x77\x8f\x00\xff\x19\xf4\xae\xc4\x16\x26\xc0\xb7\x48\x17\x6b\x3f\x46\x3e\x5e\x91\xb4\x1b\xd4\x85\x7c\xbc\xfb\x8b\x6d\xfc\x78\x0b\xf1\x5b\x83\x42\xd6\x3a\x5d\x46\xd2\xed\x4e\x31\x3f\x44\x9e\xf1\x55\x0d\x3e\x0d\xf9\x5a\xd3\x5a\x76\x37\xe3\x90\x69\x89\xb2\x59\x89\x0a\x26\xf9\xf6\x93\x3a\xde\x8a\xa9\x87\x3b\x2e\x10\xf8\x51\xce\x4f\xdd\x14\xe7\x54\x67\x36\xe5\xe2\x0b\xd9\x94\x4c\xeb\xab\xa4\xb5\x3f\x59\x78\x44\x77\x1b\x23\xb6\x24\xb8\x25\xed\xab\x1a\x7f\x7e\x82\x54\xe4\x4d\x2d\xf1\x70\xe2\x52\xee\xd4\x06\xde\x92\xce\x0f\xcf\x58\xdd\x4d\x91\xad\x4b\x88\xe2\xfd\xc7\x9c\x33\x64\xa4\x7d\x95\x9c\x27\x8b\x01\x93\xbb\x29\x16\xc0\xf8\x31\x9a\x1a\x27\xb8\xcb\x98\xec\x12\xaa\x35\x70\x0e\x9c\xa0\xc0\x12\x08\x4a\xf2\x00\xd0\x84\x2f\x56\xf3\x56\xba\x26\x28\x73\xc0\x02\x14\x11\x35\x69\x94\xa8\x9d\xdb\x7e\xbf\xbd\xd7\x97\x99\xb5\x47\x05\x50\x2c\xc8\x46\x96\xba\x61\xf5\x55\xf2\x7d\xb2\xb7\xcd\x81\x27\x0b\x63\x10\xaa\xa6\x64\x08\x24\x78\x7b\xe5\x09\xd1\x77\xd6\xd2\x0c\x8b\x23\x76\x65\xa8\x5e\x61\xb1\x57\xa8\x55\x82\x33\x84\xb9\x73\x1c\x28\xa7\x8a\x78\xff\xa1\x26\xc9\x2d\x43\x48\xa6\xe4\x9e\x03\xb2\x44\xa6\xf0\x24\x8a\x8f\x96\x83\x18\xb7\xf6\x14\xf2\x5d\xcd\x23\xae\x31\x50\xf3\x53\xae\x8f\xee\xae\x86\x50\x31\x4b\x4f\xea\xd9\x07\x2a\xe6\x8f\x82\x43\x07\xf2\xab\x44\xd0\x93\x08\x13\xeb\x94\x4d\x86\xed\x5a\xf2\xa7\x71\x9a\x31\x8a\xd5\x39\xec\x33\xec\x5e\xb1\x0a\x74\x78\x4c\x7a\x64\x22\x30\xdc\x4d\x91\x47\x4b\xb5\x5b\xc2\x39\x67\x4f\xce\xd6\xad\x54\x15\x43\x17\x1a\x24\xf5\x6b\xeb\x0d\xe6\x2f\x04\x73\xab\xd0\xa1\xf9\x72\x75\x3e\xda\x58\xb0\x4c\x32\x0c\x54\x90\x4d\xd0\xe0\x24\x9b\x97\x95\xfe\xac\x9d\xa4\x06\xa6\x62\x6c\x94\x4b\xd4\x79\x09\xb7\x6c\xac\xc2\x1d\xfb\x1d\x3a\xe5\x6e\x32\xae\xfb\x97\x31\x50\xea\x73\x3c\xf1\x5c\x90\x5b\xcb\x57\x08\x3b\x6b\xee\xb9\xf3\xce\x59\xf4\xd3\x48\xbd\x35\x8e\x01\x56\x89\x3a\x26\x37\x79\xa3\xe4\xae\xe6\xc0\xf7\x03\xe9\xfe\x2d\xa4\xcf\xdb\x17\x06\x73\xed\x53\x7e\x61\x4c\xea\x93\xdf\xda\x98\x8e\x5b\x97\x7f\x2b\x96\x6b\x77\x5e\x20\xfd\xda\x8e\x2c\x4f\xdc\x60\xb0\xc7\x47\x8a\xdb\x59\xfa\x9b\x74\xda\x6e\xd6\x3f\x6c\x11\x94\x0b\x3c\x6b\x09\x75\xdb\x46\x14\x0b\x9e\x9c\xb4\xfb\xd2\x55\x12\x0b\x50\xe0\x66\x8e\x8d\x08\x24\x8f\x4c\x13\xdd\xc6\x6d\x62\x6d\xb2\xf8\xfa\xdf\x3f\x34\x73\x38\x27\xeb\x65\xbc\xdc\x19\xea\x51\x60\x41\xd2\x6b\x05\xec\x41\x9f\xc1\x32\x38\x39\x78\xc6\xae\x4a\xb6\x40\x89\xb5\x1f\xbb\xda\x25\xde\x91\x8b\xb5\x1b\x0f\x07\x2c\xef\x94\x0b\x61\xed\xbb\xb8\xec\x07\x49\xd1\xce\x8d\xf5\xe2\xeb\xdf\xff\x1a\xe3\x95\x0c\xe3\x77\x03\x86\xbe\xbb\xbd\xd7\x69\xc6\xc5\x97\xc5\x59\xe6\x9f\x11\x75\x93\x11\x73\xbc\xe6\x07\x5d\x46\x69\x34\x9b\xa8\xf9\x14\xb7\x52\x62\x74\xb0\xde\xad\x51\x22\x2b\x8f\x9e\x87\x54\x9c\xa9\xe4\xe3\xfc\xc4\xec\xc1\x39\x65\xac\xd6\x7e\x17\x4b\xcd\xb7\xc1\x84\x6e\x75\x57\x1e\xfb\x05\xbb\xe8\x46\x96\x7b\x9d\x88\x4b\x5a\xff\xd7\x53\xf3\x30\x85\xf7\x19\x9b\xfe\x08\x78\x86\x98\xc5\x2b\x37\x63\xe7\xe0\x21\x91\x66\xfe\xfc\xb7\x98\x4d\x2d\xe3\x68\x1d\xee\x67\xc4\x0b\x0e\x7c\x83\x28\x7d\xbe\xdd\xdf\x14\xa2\xe4\x6d\xc3\x32\x96\x9c\x7d\x19\xa1\x3b\xda\x37\x30\x13\xfa\xef\xbf\x83\x06\x71\x68\xf6\x92\x9e\xaa\xc0\xaa\x6c\x5b\x8d\x2c\xf4\x1a\xa1\xa5\x32\xe6\x42\x36\xa8\x07\xad\x88\xeb\x90\x66\xf4\x1b\x2e\x37\x0e\x9e\x38\xfe\xc5\x8c\xba\x07\x29\x59\x9d\xbb\x3a\xe7\x5f\xae\x99\x06\xe2\xea\xd9\x8c\x76\x67\x26\x5a\x01\x32\xb2\x29\x98\xd2\x80\x57\xc9\x1f\xab\xfb\xf9\x87\x10\xe3\xb1\x3a\x38\xa1\xe9\xcd\x4e\xa3\xac\xfc\xd1\xba\x67\x33\xf5\x6b\xe2\xea\xb8\x8f\x29\xff\x11\x78\x83\xe5\xcf\xbd\xb9\x91\x55\x25\xeb\x9b\xe5\xb2\x6b\x37\x7b\x44\x2f\x24\x10\x69\xd6\x6a\x49\xdb\x84\x9e\x1d\x53\x27\x36\x88\xb4\x78\x1f\x15\x29\xde\x1f\xf8\x7e\xc0\x77\x0b\x7a\xa3\x84\x77\xee\x9e\xbb\x89\x61\xc6\x3b\xa2\xcb\x4f\xcd\xb6\xf0\xd3\xea\xd3\x2f\x6e\xfd\x69\xd6\x1c\x00\xbb\x14\xf7\xb0\xae\xef\x5c\xee\xaa\x8a\xa9\x27\x32\x62\x57\x20\x25\x24\x74\xca\xd6\x1e\x45\xb9\xde\xf1\x1c\x50\x8f\xa1\x04\x52\x72\xc8\x3d\x12\xa8\x7b\x21\x34\x6b\x7d\x47\xb3\x02\xab\x72\xf1\xff\x00\x40\x9a\xac\x47\x3c\x10\x00\x00")
 
 func reportsHtmlTimelogGohtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -126,8 +126,8 @@ func reportsHtmlTimelogGohtml() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "reports/html/timelog.gohtml", size: 3504, mode: os.FileMode(0644), modTime: time.Unix(1658243232, 0)}
-	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xfb, 0x32, 0xa2, 0xf5, 0x26, 0xe8, 0x5e, 0x69, 0x3, 0xb3, 0xaf, 0x65, 0x40, 0x9c, 0x62, 0xcf, 0x4b, 0xd, 0x97, 0x9a, 0x4e, 0xa8, 0xf4, 0x52, 0x0, 0x24, 0xfa, 0x3f, 0x4, 0x88, 0xd0, 0xd0}}
+	info := bindataFileInfo{name: "reports/html/timelog.gohtml", size: 4156, mode: os.FileMode(0644), modTime: time.Unix(1792325107, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x3a, 0x23, 0x7c, 0xc3, 0xd, 0x23, 0xf8, 0x52, 0x2c, 0xff, 0xda, 0x5e, 0xdb, 0x88, 0xd0, 0x6f, 0xb7, 0xe9, 0xbd, 0x10, 0x7d, 0x5a, 0xfd, 0xe6, 0x13, 0x3c, 0xe4, 0xe0, 0xf3, 0x14, 0xd1, 0x39}}
 	return a, nil
 }
 
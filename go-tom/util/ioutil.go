@@ -2,7 +2,9 @@ package util
 
 import (
 	"io"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 )
 
 func CopyFile(source, target string, overwrite bool) error {
@@ -44,3 +46,43 @@ func CopyFile(source, target string, overwrite bool) error {
 
 	return nil
 }
+
+// WriteFileAtomic replaces the file at path with data.
+// The data is written into a temporary file first, which is then renamed to path.
+// An interrupted write never leaves a partially written file behind at path.
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmpName)
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmpName)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmpName)
+		return err
+	}
+	if err := os.Chmod(tmpName, perm); err != nil {
+		_ = os.Remove(tmpName)
+		return err
+	}
+	return os.Rename(tmpName, path)
+}
+
+// LinkOrCopyFile creates a hard link of source at target. The file is copied if a link can't be created.
+// It must only be used for files, which are replaced but never modified in place.
+func LinkOrCopyFile(source, target string) error {
+	if err := os.Link(source, target); err == nil {
+		return nil
+	}
+	return CopyFile(source, target, false)
+}
@@ -0,0 +1,177 @@
+// Package workspace manages named workspaces. Each workspace has its own data directory, backup directory
+// and configuration file. The workspaces are stored in a registry file, which also records the active workspace.
+package workspace
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/jansorg/tom/go-tom/util"
+)
+
+// Default is the name of the workspace, which uses the data and backup directory of the configuration.
+// It always exists and can't be removed.
+const Default = "default"
+
+// RegistryFilename is the name of the registry file in the configuration directory
+const RegistryFilename = "workspaces.json"
+
+var ErrNotFound = errors.New("workspace not found")
+
+var validName = regexp.MustCompile(`^[\p{L}\p{N}_-][\p{L}\p{N}_.-]*$`)
+
+// Workspace is a named data directory
+type Workspace struct {
+	Name string `json:"name"`
+	// DataDir and BackupDir are empty for the default workspace
+	DataDir   string `json:"dataDir,omitempty"`
+	BackupDir string `json:"backupDir,omitempty"`
+}
+
+// IsDefault returns if w is the default workspace
+func (w Workspace) IsDefault() bool {
+	return w.Name == Default
+}
+
+// Registry is the list of the workspaces, which were created by the user
+type Registry struct {
+	path       string
+	Active     string      `json:"active,omitempty"`
+	Workspaces []Workspace `json:"workspaces"`
+}
+
+// Load reads the registry file at path. An empty registry is returned if the file doesn't exist.
+func Load(path string) (*Registry, error) {
+	registry := &Registry{path: path}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return registry, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, registry); err != nil {
+		return nil, fmt.Errorf("unable to read %s: %s", path, err.Error())
+	}
+	return registry, nil
+}
+
+// Save writes the registry file
+func (r *Registry) Save() error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return util.WriteFileAtomic(r.path, data, 0600)
+}
+
+// All returns the default workspace, followed by the other workspaces sorted by name
+func (r *Registry) All() []Workspace {
+	result := []Workspace{{Name: Default}}
+	result = append(result, r.Workspaces...)
+	sort.SliceStable(result[1:], func(i, j int) bool {
+		return result[i+1].Name < result[j+1].Name
+	})
+	return result
+}
+
+// Find returns the workspace with the given name
+func (r *Registry) Find(name string) (Workspace, error) {
+	if name == Default {
+		return Workspace{Name: Default}, nil
+	}
+	for _, w := range r.Workspaces {
+		if w.Name == name {
+			return w, nil
+		}
+	}
+	return Workspace{}, fmt.Errorf("%s: %s", ErrNotFound.Error(), name)
+}
+
+// ActiveWorkspace returns the active workspace. The default workspace is returned if the active workspace was removed.
+func (r *Registry) ActiveWorkspace() Workspace {
+	if w, err := r.Find(r.Active); err == nil {
+		return w
+	}
+	return Workspace{Name: Default}
+}
+
+// Create adds a new workspace and creates its directories. An empty dataDir defaults to the subdirectory workspaces/<name>
+// of the directory of the registry, an empty backupDir to the subdirectory backup of the data directory.
+func (r *Registry) Create(name, dataDir, backupDir string) (Workspace, error) {
+	if !validName.MatchString(name) {
+		return Workspace{}, fmt.Errorf("invalid workspace name '%s'. Letters, digits, '_', '-' and '.' are allowed", name)
+	}
+	if _, err := r.Find(name); err == nil {
+		return Workspace{}, fmt.Errorf("workspace %s already exists", name)
+	}
+
+	if dataDir == "" {
+		dataDir = filepath.Join(filepath.Dir(r.path), "workspaces", name)
+	}
+	if backupDir == "" {
+		backupDir = filepath.Join(dataDir, "backup")
+	}
+	var err error
+	if dataDir, err = filepath.Abs(dataDir); err != nil {
+		return Workspace{}, err
+	}
+	if backupDir, err = filepath.Abs(backupDir); err != nil {
+		return Workspace{}, err
+	}
+
+	for _, w := range r.Workspaces {
+		if w.DataDir == dataDir {
+			return Workspace{}, fmt.Errorf("%s is the data directory of workspace %s", dataDir, w.Name)
+		}
+	}
+
+	for _, dir := range []string{dataDir, backupDir} {
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return Workspace{}, err
+		}
+	}
+
+	w := Workspace{Name: name, DataDir: dataDir, BackupDir: backupDir}
+	r.Workspaces = append(r.Workspaces, w)
+	return w, r.Save()
+}
+
+// Use makes the workspace the active workspace
+func (r *Registry) Use(name string) error {
+	if _, err := r.Find(name); err != nil {
+		return err
+	}
+
+	r.Active = name
+	if name == Default {
+		r.Active = ""
+	}
+	return r.Save()
+}
+
+// Remove removes the workspace from the registry. The directories of the workspace aren't removed.
+// The default and the active workspace can't be removed.
+func (r *Registry) Remove(name string) (Workspace, error) {
+	if name == Default {
+		return Workspace{}, fmt.Errorf("the default workspace can't be removed")
+	}
+	if name == r.ActiveWorkspace().Name {
+		return Workspace{}, fmt.Errorf("workspace %s is active. Use another workspace before removing it", name)
+	}
+
+	for i, w := range r.Workspaces {
+		if w.Name == name {
+			r.Workspaces = append(r.Workspaces[:i], r.Workspaces[i+1:]...)
+			return w, r.Save()
+		}
+	}
+	return Workspace{}, fmt.Errorf("%s: %s", ErrNotFound.Error(), name)
+}
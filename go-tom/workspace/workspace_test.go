@@ -0,0 +1,69 @@
+package workspace
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Registry(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tom-workspaces")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, RegistryFilename)
+	registry, err := Load(path)
+	require.NoError(t, err)
+	assert.EqualValues(t, []Workspace{{Name: Default}}, registry.All())
+	assert.True(t, registry.ActiveWorkspace().IsDefault())
+
+	client, err := registry.Create("client", "", "")
+	require.NoError(t, err)
+	assert.EqualValues(t, filepath.Join(dir, "workspaces", "client"), client.DataDir)
+	assert.EqualValues(t, filepath.Join(dir, "workspaces", "client", "backup"), client.BackupDir)
+	assert.DirExists(t, client.BackupDir)
+
+	personalDir := filepath.Join(dir, "personal")
+	_, err = registry.Create("personal", personalDir, filepath.Join(dir, "personal-backup"))
+	require.NoError(t, err)
+
+	_, err = registry.Create("client", "", "")
+	assert.Error(t, err, "names must be unique")
+	_, err = registry.Create(Default, "", "")
+	assert.Error(t, err, "the default workspace always exists")
+	_, err = registry.Create("other", personalDir, "")
+	assert.Error(t, err, "data directories must not be shared")
+	_, err = registry.Create("a/b", "", "")
+	assert.Error(t, err)
+	_, err = registry.Create("a,b", "", "")
+	assert.Error(t, err)
+
+	require.NoError(t, registry.Use("personal"))
+	assert.Error(t, registry.Use("unknown"))
+
+	// the registry is saved by every change
+	registry, err = Load(path)
+	require.NoError(t, err)
+	assert.EqualValues(t, "personal", registry.ActiveWorkspace().Name)
+	all := registry.All()
+	require.Len(t, all, 3)
+	assert.EqualValues(t, []string{Default, "client", "personal"}, []string{all[0].Name, all[1].Name, all[2].Name})
+
+	_, err = registry.Remove("personal")
+	assert.Error(t, err, "the active workspace must not be removed")
+	_, err = registry.Remove(Default)
+	assert.Error(t, err)
+
+	require.NoError(t, registry.Use(Default))
+	assert.Empty(t, registry.Active)
+	removed, err := registry.Remove("personal")
+	require.NoError(t, err)
+	assert.EqualValues(t, personalDir, removed.DataDir)
+	assert.DirExists(t, personalDir, "the data of a removed workspace must be kept")
+	_, err = registry.Find("personal")
+	assert.Error(t, err)
+}
@@ -0,0 +1,317 @@
+// Package worktime defines the target working time, e.g. the hours of an employment contract.
+// The targets are stored in a data file of the store, each workspace has its own targets.
+// The file is encrypted with the data and changes of the targets are reverted by Undo.
+package worktime
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jansorg/tom/go-tom/model"
+	"github.com/jansorg/tom/go-tom/store"
+)
+
+// Filename is the name of the data file, which stores the targets
+const Filename = "worktime.json"
+
+func init() {
+	store.RegisterDataFile(Filename)
+}
+
+// DateFormat is the format of the first and the last day of a validity period
+const DateFormat = "2006-01-02"
+
+var ErrNotFound = fmt.Errorf("target not found")
+
+// DefaultWeekdays are the working days of a target, which doesn't define them
+var DefaultWeekdays = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// Target is the working time, which is expected in a validity period. Either the daily or the weekly hours are defined.
+// The weekly hours are distributed evenly on the working days without an override.
+type Target struct {
+	ID string `json:"id"`
+	// From is the first day and Until is the last day of the validity period. The period is open if a value is nil.
+	From  *time.Time `json:"from,omitempty"`
+	Until *time.Time `json:"until,omitempty"`
+	// DailyHours are the hours of each working day, WeeklyHours the hours of a week
+	DailyHours  float64 `json:"dailyHours,omitempty"`
+	WeeklyHours float64 `json:"weeklyHours,omitempty"`
+	// Weekdays are the working days, e.g. "mon". Monday to Friday are the working days if it's empty.
+	Weekdays []string `json:"weekdays,omitempty"`
+	// Overrides are the hours of single weekdays, e.g. 6 hours on "fri". A weekday with an override is a working day.
+	Overrides map[string]float64 `json:"overrides,omitempty"`
+}
+
+// ParseWeekdays parses a list of weekdays. Each value is the abbreviation of a weekday, e.g. "mon", or a range of weekdays, e.g. "mon-fri".
+func ParseWeekdays(values []string) ([]string, error) {
+	var result []string
+	added := map[string]bool{}
+	for _, value := range values {
+		first, last := value, value
+		if i := strings.Index(value, "-"); i > 0 {
+			first, last = value[:i], value[i+1:]
+		}
+
+		start, err := ParseWeekday(first)
+		if err != nil {
+			return nil, err
+		}
+		end, err := ParseWeekday(last)
+		if err != nil {
+			return nil, err
+		}
+
+		for day := start; ; day = (day + 1) % 7 {
+			if name := weekdayName(day); !added[name] {
+				added[name] = true
+				result = append(result, name)
+			}
+			if day == end {
+				break
+			}
+		}
+	}
+	return result, nil
+}
+
+// ParseWeekday parses the abbreviation of a weekday, e.g. "mon"
+func ParseWeekday(value string) (time.Weekday, error) {
+	day, ok := weekdayNames[strings.ToLower(strings.TrimSpace(value))]
+	if !ok {
+		return time.Sunday, fmt.Errorf("unknown weekday %s. Possible values: mon,tue,wed,thu,fri,sat,sun", value)
+	}
+	return day, nil
+}
+
+func weekdayName(day time.Weekday) string {
+	return strings.ToLower(day.String()[:3])
+}
+
+// Validate returns an error if the values of the target are invalid
+func (t Target) Validate() error {
+	if t.DailyHours < 0 || t.WeeklyHours < 0 {
+		return fmt.Errorf("the hours of a target must not be negative")
+	} else if t.DailyHours > 0 && t.WeeklyHours > 0 {
+		return fmt.Errorf("either daily or weekly hours are allowed")
+	} else if t.DailyHours == 0 && t.WeeklyHours == 0 && len(t.Overrides) == 0 {
+		return fmt.Errorf("daily or weekly hours undefined")
+	} else if t.From != nil && t.Until != nil && t.Until.Before(*t.From) {
+		return fmt.Errorf("the last day of a target must not be before its first day")
+	}
+
+	if _, err := ParseWeekdays(t.Weekdays); err != nil {
+		return err
+	}
+
+	var overrideHours float64
+	for name, hours := range t.Overrides {
+		if _, err := ParseWeekday(name); err != nil {
+			return err
+		} else if hours < 0 {
+			return fmt.Errorf("the hours of a target must not be negative")
+		}
+		overrideHours += hours
+	}
+	if t.WeeklyHours > 0 && overrideHours > t.WeeklyHours {
+		return fmt.Errorf("the hours of the overrides exceed the weekly hours")
+	}
+	return nil
+}
+
+// Contains returns if the day is in the validity period of the target
+func (t Target) Contains(day time.Time) bool {
+	date := startOfDay(day)
+	return (t.From == nil || !date.Before(startOfDay(*t.From))) &&
+		(t.Until == nil || !date.After(startOfDay(*t.Until)))
+}
+
+// Hours returns the target hours of the weekday
+func (t Target) Hours(day time.Weekday) float64 {
+	if hours, ok := t.Overrides[weekdayName(day)]; ok {
+		return hours
+	}
+	if !t.isWorkingDay(day) {
+		return 0
+	}
+	if t.DailyHours > 0 {
+		return t.DailyHours
+	}
+
+	// the weekly hours, which aren't defined by overrides, are distributed on the other working days
+	hours := t.WeeklyHours
+	days := 0
+	for _, d := range t.workingDays() {
+		if override, ok := t.Overrides[weekdayName(d)]; ok {
+			hours -= override
+		} else {
+			days++
+		}
+	}
+	if days == 0 {
+		return 0
+	}
+	return hours / float64(days)
+}
+
+// Duration returns the target time of the day. It's 0 if the day isn't in the validity period.
+func (t Target) Duration(day time.Time) time.Duration {
+	if !t.Contains(day) {
+		return 0
+	}
+	return time.Duration(t.Hours(day.Weekday()) * float64(time.Hour))
+}
+
+// WeekdayNames returns the names of the working days, which have no override
+func (t Target) WeekdayNames() []string {
+	var result []string
+	for _, day := range t.workingDays() {
+		if _, ok := t.Overrides[weekdayName(day)]; !ok {
+			result = append(result, weekdayName(day))
+		}
+	}
+	return result
+}
+
+func (t Target) isWorkingDay(day time.Weekday) bool {
+	for _, d := range t.workingDays() {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+func (t Target) workingDays() []time.Weekday {
+	if len(t.Weekdays) == 0 {
+		return DefaultWeekdays
+	}
+
+	var result []time.Weekday
+	for _, name := range t.Weekdays {
+		if day, err := ParseWeekday(name); err == nil {
+			result = append(result, day)
+		}
+	}
+	return result
+}
+
+// Targets is the list of the targets of a data directory
+type Targets struct {
+	store   model.Store
+	Targets []Target `json:"targets"`
+}
+
+// Load reads the targets of the store. An empty list is returned if no targets were saved.
+func Load(s model.Store) (*Targets, error) {
+	targets := &Targets{store: s}
+
+	data, err := store.ReadDataFile(s, Filename)
+	if os.IsNotExist(err) {
+		return targets, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, targets); err != nil {
+		return nil, fmt.Errorf("unable to read %s: %s", Filename, err.Error())
+	}
+	return targets, nil
+}
+
+// Save writes the targets into the store
+func (t *Targets) Save() error {
+	data, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return err
+	}
+	return store.WriteDataFile(t.store, Filename, data)
+}
+
+// Empty returns if no targets are defined
+func (t *Targets) Empty() bool {
+	return len(t.Targets) == 0
+}
+
+// Add validates the target and adds it with a new ID
+func (t *Targets) Add(target Target) (Target, error) {
+	if err := target.Validate(); err != nil {
+		return Target{}, err
+	}
+
+	target.ID = model.NextID()
+	t.Targets = append(t.Targets, target)
+	t.sort()
+	return target, t.Save()
+}
+
+// Remove removes the target with the given ID
+func (t *Targets) Remove(id string) (Target, error) {
+	for i, target := range t.Targets {
+		if target.ID == id {
+			t.Targets = append(t.Targets[:i], t.Targets[i+1:]...)
+			return target, t.Save()
+		}
+	}
+	return Target{}, fmt.Errorf("%s: %s", ErrNotFound.Error(), id)
+}
+
+// At returns the target, which applies to the day. If the validity periods of targets overlap, then the target with the latest first day applies.
+// Nil is returned if no target applies.
+func (t *Targets) At(day time.Time) *Target {
+	var result *Target
+	for i := range t.Targets {
+		target := &t.Targets[i]
+		if target.Contains(day) && (result == nil || result.From == nil || target.From != nil && !target.From.Before(*result.From)) {
+			result = target
+		}
+	}
+	return result
+}
+
+// Duration returns the target time of the day, it's 0 if no target applies
+func (t *Targets) Duration(day time.Time) time.Duration {
+	if target := t.At(day); target != nil {
+		return target.Duration(day)
+	}
+	return 0
+}
+
+// Start returns the first day of the targets. It's nil if a target has no first day or if no targets are defined.
+func (t *Targets) Start() *time.Time {
+	var result *time.Time
+	for _, target := range t.Targets {
+		if target.From == nil {
+			return nil
+		}
+		if result == nil || target.From.Before(*result) {
+			result = target.From
+		}
+	}
+	return result
+}
+
+func (t *Targets) sort() {
+	sort.SliceStable(t.Targets, func(i, j int) bool {
+		a, b := t.Targets[i].From, t.Targets[j].From
+		return a == nil && b != nil || a != nil && b != nil && a.Before(*b)
+	})
+}
+
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,125 @@
+package worktime
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jansorg/tom/go-tom/store"
+)
+
+func newDay(year int, month time.Month, day int) *time.Time {
+	date := time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+	return &date
+}
+
+func Test_ParseWeekdays(t *testing.T) {
+	days, err := ParseWeekdays([]string{"mon-wed", "fri"})
+	require.NoError(t, err)
+	assert.EqualValues(t, []string{"mon", "tue", "wed", "fri"}, days)
+
+	days, err = ParseWeekdays([]string{"sat-mon"})
+	require.NoError(t, err)
+	assert.EqualValues(t, []string{"sat", "sun", "mon"}, days)
+
+	_, err = ParseWeekdays([]string{"monday"})
+	assert.Error(t, err)
+}
+
+func Test_TargetHours(t *testing.T) {
+	// 40h from Monday to Friday with 6h on Friday
+	weekly := Target{WeeklyHours: 40, Overrides: map[string]float64{"fri": 6}}
+	require.NoError(t, weekly.Validate())
+	assert.EqualValues(t, 8.5, weekly.Hours(time.Monday))
+	assert.EqualValues(t, 6, weekly.Hours(time.Friday))
+	assert.EqualValues(t, 0, weekly.Hours(time.Saturday))
+	assert.EqualValues(t, []string{"mon", "tue", "wed", "thu"}, weekly.WeekdayNames())
+
+	daily := Target{DailyHours: 6, Weekdays: []string{"mon", "tue", "wed"}}
+	require.NoError(t, daily.Validate())
+	assert.EqualValues(t, 6, daily.Hours(time.Wednesday))
+	assert.EqualValues(t, 0, daily.Hours(time.Thursday))
+
+	assert.Error(t, Target{}.Validate(), "hours are required")
+	assert.Error(t, Target{DailyHours: 8, WeeklyHours: 40}.Validate(), "either daily or weekly hours")
+	assert.Error(t, Target{WeeklyHours: 10, Overrides: map[string]float64{"fri": 12}}.Validate(), "overrides exceed the weekly hours")
+	assert.Error(t, Target{DailyHours: 8, From: newDay(2019, time.March, 2), Until: newDay(2019, time.March, 1)}.Validate(), "invalid validity period")
+}
+
+func Test_Targets(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tom-worktime")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	s, err := store.NewStore(dir, filepath.Join(dir, "backup"), 10)
+	require.NoError(t, err)
+	targets, err := Load(s)
+	require.NoError(t, err)
+	assert.True(t, targets.Empty())
+	assert.Nil(t, targets.At(*newDay(2019, time.March, 4)))
+
+	fullTime, err := targets.Add(Target{WeeklyHours: 40, From: newDay(2019, time.January, 1)})
+	require.NoError(t, err)
+	assert.NotEmpty(t, fullTime.ID)
+	// the newer target overrides the open validity period of the older target
+	partTime, err := targets.Add(Target{DailyHours: 4, From: newDay(2019, time.March, 1), Until: newDay(2019, time.March, 31)})
+	require.NoError(t, err)
+
+	targets, err = Load(s)
+	require.NoError(t, err)
+	require.Len(t, targets.Targets, 2)
+	assert.EqualValues(t, newDay(2019, time.January, 1).Unix(), targets.Start().Unix())
+
+	assert.Nil(t, targets.At(*newDay(2018, time.December, 31)))
+	assert.EqualValues(t, fullTime.ID, targets.At(*newDay(2019, time.February, 28)).ID)
+	assert.EqualValues(t, partTime.ID, targets.At(*newDay(2019, time.March, 31)).ID)
+	assert.EqualValues(t, fullTime.ID, targets.At(*newDay(2019, time.April, 1)).ID)
+	assert.EqualValues(t, 4*time.Hour, targets.Duration(*newDay(2019, time.March, 4)))
+	assert.EqualValues(t, 0, targets.Duration(*newDay(2019, time.March, 9)), "saturday isn't a working day")
+
+	_, err = targets.Remove(partTime.ID)
+	require.NoError(t, err)
+	_, err = targets.Remove(partTime.ID)
+	assert.Error(t, err)
+	assert.EqualValues(t, 8*time.Hour, targets.Duration(*newDay(2019, time.March, 4)))
+}
+
+func Test_TargetsStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tom-worktime")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	s, err := store.NewStore(dir, filepath.Join(dir, "backup"), 10)
+	require.NoError(t, err)
+	require.NoError(t, store.Encrypt(s, "secret"))
+	targets, err := Load(s)
+	require.NoError(t, err)
+	_, err = targets.Add(Target{WeeklyHours: 40})
+	require.NoError(t, err)
+
+	// the targets are encrypted with the data
+	data, err := ioutil.ReadFile(filepath.Join(dir, Filename))
+	require.NoError(t, err)
+	assert.False(t, bytes.Contains(data, []byte("weeklyHours")))
+
+	// the change of the targets is reverted by undo
+	s, err = store.Open(store.Options{Dir: dir, BackupDir: filepath.Join(dir, "backup"), MaxBackups: 10, Passphrase: "secret"})
+	require.NoError(t, err)
+	targets, err = Load(s)
+	require.NoError(t, err)
+	_, err = targets.Add(Target{DailyHours: 4})
+	require.NoError(t, err)
+	_, _, err = store.Undo(s)
+	require.NoError(t, err)
+
+	targets, err = Load(s)
+	require.NoError(t, err)
+	require.Len(t, targets.Targets, 1)
+	assert.EqualValues(t, 40, targets.Targets[0].WeeklyHours)
+}